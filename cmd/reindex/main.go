@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/ports"
+	store "github.com/Stuhub-io/internal/repository"
+	"github.com/Stuhub-io/internal/repository/postgres"
+	"github.com/Stuhub-io/internal/search/elasticsearch"
+	"github.com/Stuhub-io/logger"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+// Rebuilds the page search index from the database.
+//
+// By default every organization is indexed into a new index, the search alias is swapped to it
+// once complete, so searches keep hitting the previous index meanwhile.
+// With -org only that organization is reindexed, in place.
+func main() {
+	orgSlug := flag.String("org", "", "only reindex the organization with this slug, in place")
+	batchSize := flag.Int("batch-size", 500, "pages per bulk request")
+	keepOld := flag.Bool("keep-old", false, "keep the previous index after the alias swap")
+	flag.Parse()
+
+	cfg := config.LoadConfig(config.GetDefaultConfigLoaders())
+
+	logger := logger.NewLogrusLogger()
+
+	if cfg.SearchDriver == config.SearchDriverPostgres {
+		logger.Info("[Reindex]: postgres search vectors are generated columns, nothing to reindex")
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	postgresDB := postgres.Must(cfg.DBDsn, cfg.Debug, logger)
+	dbStore := store.NewDBStore(postgresDB, nil, nil)
+
+	userRepository := postgres.NewUserRepository(postgres.NewUserRepositoryParams{
+		Store: dbStore,
+		Cfg:   cfg,
+	})
+	r := &reindexer{
+		logger:    logger,
+		batchSize: max(*batchSize, 1),
+		pageRepository: postgres.NewPageRepository(postgres.NewPageRepositoryParams{
+			Cfg:   cfg,
+			Store: dbStore,
+		}),
+		orgRepository: postgres.NewOrganizationRepository(postgres.NewOrganizationRepositoryParams{
+			Store:          dbStore,
+			Cfg:            cfg,
+			UserRepository: userRepository,
+		}),
+		pageIndexer: elasticsearch.NewPageIndexer(elasticsearch.NewPageIndexerParams{
+			Client: elasticsearch.Must(cfg.ElasticSearchURL, logger),
+			Logger: logger,
+		}),
+	}
+
+	var err error
+	if *orgSlug != "" {
+		err = r.reindexOrg(ctx, *orgSlug)
+	} else {
+		err = r.reindexAll(ctx, *keepOld)
+	}
+	if err != nil {
+		logger.Error(err, "[Reindex]: Failed")
+		os.Exit(1)
+	}
+}
+
+type reindexer struct {
+	logger         logger.Logger
+	batchSize      int
+	pageRepository ports.PageRepository
+	orgRepository  ports.OrganizationRepository
+	pageIndexer    *elasticsearch.PageIndexer
+}
+
+// reindexAll indexes every organization into a new index and swaps the alias to it.
+func (r *reindexer) reindexAll(ctx context.Context, keepOld bool) error {
+	startedAt := time.Now()
+
+	orgs, dErr := r.orgRepository.GetOrgs(ctx)
+	if dErr != nil {
+		return errors.New(dErr.Message)
+	}
+
+	index, err := r.pageIndexer.CreateIndex(ctx)
+	if err != nil {
+		return err
+	}
+	target := r.pageIndexer.WithIndex(index)
+
+	total := 0
+	for idx, org := range orgs {
+		count, err := r.indexOrg(ctx, target, org)
+		if err != nil {
+			// The alias still points to the previous index, drop the partial one
+			if dErr := r.pageIndexer.DeleteIndices(context.Background(), []string{index}); dErr != nil {
+				r.logger.Errorf(dErr, "[Reindex]: Failed to delete the partial index %s", index)
+			}
+			return fmt.Errorf("org %s: %w", org.Slug, err)
+		}
+		total += count
+		r.logger.Infof("[Reindex]: org %d/%d %s done, %d pages", idx+1, len(orgs), org.Slug, count)
+	}
+
+	oldIndices, err := r.pageIndexer.SwapAlias(ctx, index)
+	if err != nil {
+		return err
+	}
+	r.logger.Infof("[Reindex]: alias swapped to %s, %d pages in %s", index, total, time.Since(startedAt).Round(time.Second))
+
+	// Pages edited while the reindex ran were written to the previous index only
+	for _, oldIndex := range oldIndices {
+		changedPkIDs, err := r.pageIndexer.WithIndex(oldIndex).ChangedSince(ctx, startedAt)
+		if err != nil {
+			return err
+		}
+		if err := r.indexPages(ctx, r.pageIndexer, changedPkIDs); err != nil {
+			return err
+		}
+		r.logger.Infof("[Reindex]: caught up %d pages changed during the reindex", len(changedPkIDs))
+	}
+
+	// Pages purged while the reindex ran were deleted from the previous index only
+	purgedCount, err := r.deletePurgedPages(ctx, oldIndices)
+	if err != nil {
+		return err
+	}
+	r.logger.Infof("[Reindex]: deleted %d pages purged during the reindex", purgedCount)
+
+	if keepOld {
+		return nil
+	}
+	if err := r.pageIndexer.DeleteIndices(ctx, oldIndices); err != nil {
+		return err
+	}
+	r.logger.Infof("[Reindex]: deleted previous indices %v", oldIndices)
+	return nil
+}
+
+// reindexOrg upserts the organization's pages in the live index, then drops its pages that were not rewritten.
+func (r *reindexer) reindexOrg(ctx context.Context, slug string) error {
+	startedAt := time.Now()
+
+	org, dErr := r.orgRepository.GetOrgBySlug(ctx, slug)
+	if dErr != nil {
+		return errors.New(dErr.Message)
+	}
+
+	if err := r.pageIndexer.EnsureIndex(ctx); err != nil {
+		return err
+	}
+
+	count, err := r.indexOrg(ctx, r.pageIndexer, org)
+	if err != nil {
+		return err
+	}
+
+	// Pages purged or archived since they were indexed, concurrent edits are newer than startedAt
+	if err := r.pageIndexer.DeleteOrgPagesBefore(ctx, org.PkId, startedAt); err != nil {
+		return err
+	}
+
+	r.logger.Infof("[Reindex]: org %s done, %d pages in %s", org.Slug, count, time.Since(startedAt).Round(time.Second))
+	return nil
+}
+
+// indexOrg streams the non-archived pages of the organization into the index, returns the number indexed.
+func (r *reindexer) indexOrg(ctx context.Context, target ports.PageSearcher, org *domain.Organization) (int, error) {
+	isArchived := false
+	var afterPkID int64
+	count := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		pages, dErr := r.pageRepository.ListWithBody(ctx, domain.PageListQuery{
+			OrgPkID:    &org.PkId,
+			IsArchived: &isArchived,
+			IsAll:      true,
+			AfterPkID:  &afterPkID,
+			Limit:      r.batchSize,
+		})
+		if dErr != nil {
+			return count, errors.New(dErr.Message)
+		}
+		if len(pages) == 0 {
+			return count, nil
+		}
+
+		if dErr := target.IndexPages(ctx, sliceutils.Map(pages, pageutils.TransformPageToSearchDocument)); dErr != nil {
+			return count, errors.New(dErr.Message)
+		}
+
+		count += len(pages)
+		afterPkID = pages[len(pages)-1].PkID
+		r.logger.Infof("[Reindex]: org %s, %d pages indexed", org.Slug, count)
+
+		if len(pages) < r.batchSize {
+			return count, nil
+		}
+	}
+}
+
+// deletePurgedPages deletes from the live index the pages missing from the previous indices that no longer
+// exist, returns the number deleted. Only those pages can have been purged while the reindex ran.
+func (r *reindexer) deletePurgedPages(ctx context.Context, oldIndices []string) (int, error) {
+	if len(oldIndices) == 0 {
+		return 0, nil
+	}
+
+	oldPkIDs := make(map[int64]struct{})
+	for _, oldIndex := range oldIndices {
+		pagePkIDs, err := r.pageIndexer.WithIndex(oldIndex).ListPagePkIDs(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, pagePkID := range pagePkIDs {
+			oldPkIDs[pagePkID] = struct{}{}
+		}
+	}
+
+	pagePkIDs, err := r.pageIndexer.ListPagePkIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	candidatePkIDs := sliceutils.Filter(pagePkIDs, func(pagePkID int64) bool {
+		_, ok := oldPkIDs[pagePkID]
+		return !ok
+	})
+
+	count := 0
+	for start := 0; start < len(candidatePkIDs); start += r.batchSize {
+		batch := candidatePkIDs[start:min(start+r.batchSize, len(candidatePkIDs))]
+
+		pages, dErr := r.pageRepository.ListWithBody(ctx, domain.PageListQuery{
+			PagePkIDs: batch,
+			IsAll:     true,
+		})
+		if dErr != nil {
+			return count, errors.New(dErr.Message)
+		}
+
+		existing := make(map[int64]struct{}, len(pages))
+		for _, page := range pages {
+			existing[page.PkID] = struct{}{}
+		}
+		purgedPkIDs := sliceutils.Filter(batch, func(pagePkID int64) bool {
+			_, ok := existing[pagePkID]
+			return !ok
+		})
+
+		if dErr := r.pageIndexer.DeletePages(ctx, purgedPkIDs); dErr != nil {
+			return count, errors.New(dErr.Message)
+		}
+		count += len(purgedPkIDs)
+	}
+	return count, nil
+}
+
+func (r *reindexer) indexPages(ctx context.Context, target ports.PageSearcher, pagePkIDs []int64) error {
+	for start := 0; start < len(pagePkIDs); start += r.batchSize {
+		batch := pagePkIDs[start:min(start+r.batchSize, len(pagePkIDs))]
+
+		pages, dErr := r.pageRepository.ListWithBody(ctx, domain.PageListQuery{
+			PagePkIDs: batch,
+			IsAll:     true,
+		})
+		if dErr != nil {
+			return errors.New(dErr.Message)
+		}
+		if dErr := target.IndexPages(ctx, sliceutils.Map(pages, pageutils.TransformPageToSearchDocument)); dErr != nil {
+			return errors.New(dErr.Message)
+		}
+	}
+	return nil
+}
@@ -11,11 +11,16 @@ import (
 	"time"
 
 	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/core/ports"
 	"github.com/Stuhub-io/core/services/activity"
 	"github.com/Stuhub-io/core/services/auth"
+	"github.com/Stuhub-io/core/services/collaboration"
 	"github.com/Stuhub-io/core/services/organization"
+	"github.com/Stuhub-io/core/services/outbox"
 	"github.com/Stuhub-io/core/services/page"
 	pageAccessLog "github.com/Stuhub-io/core/services/page_access_log"
+	"github.com/Stuhub-io/core/services/presence"
+	"github.com/Stuhub-io/core/services/search"
 	"github.com/Stuhub-io/core/services/upload"
 	"github.com/Stuhub-io/core/services/user"
 	_ "github.com/Stuhub-io/docs"
@@ -31,6 +36,7 @@ import (
 	"github.com/Stuhub-io/internal/repository/postgres"
 	"github.com/Stuhub-io/internal/repository/scylla"
 	"github.com/Stuhub-io/internal/search/elasticsearch"
+	searchpostgres "github.com/Stuhub-io/internal/search/postgres"
 	"github.com/Stuhub-io/internal/token"
 	"github.com/Stuhub-io/internal/uploader"
 	"github.com/Stuhub-io/logger"
@@ -59,8 +65,6 @@ func main() {
 
 	redisCache := redis.Must(cfg.RedisUrl, logger)
 
-	elasticSearch := elasticsearch.Must(cfg.ElasticSearchURL, logger)
-
 	tokenMaker := token.Must(cfg.SecretKey)
 
 	cacheStore := cache.NewCacheStore(redisCache)
@@ -112,10 +116,33 @@ func main() {
 		Cfg:   cfg,
 		Store: dbStore,
 	})
+	outboxRepository := postgres.NewOutboxRepository(postgres.NewOutboxRepositoryParams{
+		Cfg:   cfg,
+		Store: dbStore,
+	})
+	pageExportRepository := postgres.NewPageExportRepository(postgres.NewPageExportRepositoryParams{
+		Cfg:   cfg,
+		Store: dbStore,
+	})
 
 	// indexers
-	pageIndexer := elasticsearch.NewPageIndexer(elasticSearch)
-	pageIndexer.Index(context.Background())
+	var pageSearcher ports.PageSearcher
+	switch cfg.SearchDriver {
+	case config.SearchDriverPostgres:
+		pageSearcher = searchpostgres.NewPageSearcher(searchpostgres.NewPageSearcherParams{
+			Store:  dbStore,
+			Logger: logger,
+		})
+	default:
+		pageIndexer := elasticsearch.NewPageIndexer(elasticsearch.NewPageIndexerParams{
+			Client: elasticsearch.Must(cfg.ElasticSearchURL, logger),
+			Logger: logger,
+		})
+		if err := pageIndexer.EnsureIndex(context.Background()); err != nil {
+			logger.Error(err, "failed to ensure the page search index")
+		}
+		pageSearcher = pageIndexer
+	}
 
 	// services
 	cloudinaryUploader := uploader.NewCloudinaryUploader(cfg)
@@ -147,14 +174,22 @@ func main() {
 		Mailer:                       mailer,
 		RemoteRoute:                  remoteRoute,
 		OrganizationInviteRepository: organizationInviteRepository,
+		PageRepository:               pageRepository,
 	})
 	pageService := page.NewService(page.NewServiceParams{
 		Config:                  cfg,
 		Logger:                  logger,
 		PageRepository:          pageRepository,
 		PageAccessLogRepository: pageAccessLogsRepository,
-		Mailer:                  mailer,
+		OrganizationRepository:  orgRepository,
 		ActivityRepository:      activityRepository,
+		PageExportRepository:    pageExportRepository,
+		UserRepository:          userRepository,
+		Hasher:                  hasher,
+	})
+	searchService := search.NewService(search.NewServiceParams{
+		PageRepository: pageRepository,
+		PageSearcher:   pageSearcher,
 	})
 	uploadService := upload.NewUploadService(upload.NewUploadServiceParams{
 		Config:   cfg,
@@ -174,6 +209,38 @@ func main() {
 		UserRepository:         userRepository,
 	})
 
+	collaborationService := collaboration.NewService(collaboration.NewServiceParams{
+		Config:         cfg,
+		Logger:         logger,
+		PageRepository: pageRepository,
+	})
+	outboxService := outbox.NewService(outbox.NewServiceParams{
+		Config:             cfg,
+		Logger:             logger,
+		OutboxRepository:   outboxRepository,
+		ActivityRepository: activityRepository,
+		PageRepository:     pageRepository,
+		PageSearcher:       pageSearcher,
+		Mailer:             mailer,
+	})
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	collaborationDone := make(chan struct{})
+	go func() {
+		collaborationService.Run(workerCtx)
+		close(collaborationDone)
+	}()
+	presenceService := presence.NewService(presence.NewServiceParams{
+		Config:         cfg,
+		Logger:         logger,
+		PageRepository: pageRepository,
+		CacheStore:     cacheStore,
+	})
+	presenceService.Subscribe(collaborationService.BroadcastPresence)
+	go presenceService.Run(workerCtx)
+	go pageService.RunTrashPurge(workerCtx)
+	go pageService.RunPageExports(workerCtx)
+	go outboxService.Run(workerCtx)
+
 	// handlers
 	v1 := r.Group("/v1")
 	{
@@ -196,6 +263,11 @@ func main() {
 			AuthMiddleware: authMiddleware,
 			PageService:    pageService,
 		}))
+		api.UseSearchHandler(api.NewSearchHandlerParams{
+			Router:         v1,
+			AuthMiddleware: authMiddleware,
+			SearchService:  searchService,
+		})
 		api.UseUploadHandler(api.NewUploadHandlerParams{
 			Router:         v1,
 			AuthMiddleware: authMiddleware,
@@ -211,6 +283,17 @@ func main() {
 			AuthMiddleware:  authMiddleware,
 			ActivityService: activityService,
 		})
+		api.UseCollaborationHandler(api.NewCollaborationHandlerParams{
+			Router:               v1,
+			AuthMiddleware:       authMiddleware,
+			CollaborationService: collaborationService,
+			AllowedOrigins:       cfg.GetCORS(),
+		})
+		api.UsePresenceHandler(api.NewPresenceHandlerParams{
+			Router:          v1,
+			AuthMiddleware:  authMiddleware,
+			PresenceService: presenceService,
+		})
 	}
 
 	r.GET("/", func(c *gin.Context) {
@@ -242,6 +325,10 @@ func main() {
 	<-quit
 
 	shutdownServer(srv, cfg.GetShutdownTimeout(), logger)
+
+	// Stop background workers, persisting pending live edits before exiting
+	stopWorkers()
+	<-collaborationDone
 }
 
 func shutdownServer(srv *http.Server, timeout time.Duration, logger logger.Logger) {
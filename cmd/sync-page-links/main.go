@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/core/domain"
+	store "github.com/Stuhub-io/internal/repository"
+	"github.com/Stuhub-io/internal/repository/postgres"
+	"github.com/Stuhub-io/logger"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+// Rebuilds the page links from the documents of every non-archived page,
+// links are otherwise only updated when a document is saved.
+func main() {
+	batchSize := flag.Int("batch-size", 500, "pages synced per transaction")
+	flag.Parse()
+
+	cfg := config.LoadConfig(config.GetDefaultConfigLoaders())
+
+	logger := logger.NewLogrusLogger()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	postgresDB := postgres.Must(cfg.DBDsn, cfg.Debug, logger)
+	dbStore := store.NewDBStore(postgresDB, nil, nil)
+
+	pageRepository := postgres.NewPageRepository(postgres.NewPageRepositoryParams{
+		Cfg:   cfg,
+		Store: dbStore,
+	})
+
+	isArchived := false
+	var afterPkID int64
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			logger.Error(err, "[SyncPageLinks]: Interrupted")
+			os.Exit(1)
+		}
+
+		pages, dErr := pageRepository.ListWithBody(ctx, domain.PageListQuery{
+			IsArchived: &isArchived,
+			IsAll:      true,
+			AfterPkID:  &afterPkID,
+			Limit:      max(*batchSize, 1),
+		})
+		if dErr != nil {
+			logger.Error(errors.New(dErr.Message), "[SyncPageLinks]: Failed to list pages")
+			os.Exit(1)
+		}
+		if len(pages) == 0 {
+			break
+		}
+
+		pagePkIDs := sliceutils.Map(pages, func(page domain.Page) int64 { return page.PkID })
+		if dErr := pageRepository.SyncLinks(ctx, pagePkIDs); dErr != nil {
+			logger.Error(errors.New(dErr.Message), "[SyncPageLinks]: Failed to sync links")
+			os.Exit(1)
+		}
+
+		count += len(pages)
+		afterPkID = pages[len(pages)-1].PkID
+		logger.Infof("[SyncPageLinks]: %d pages synced", count)
+	}
+
+	logger.Infof("[SyncPageLinks]: done, %d pages synced", count)
+}
@@ -11,6 +11,12 @@ import (
 const DEFAULT_ENV_PATH = "build/staging/api"
 const DEFAULT_ENV_FILENAME = ".env"
 
+// Page search backends.
+const (
+	SearchDriverElasticsearch = "elasticsearch"
+	SearchDriverPostgres      = "postgres"
+)
+
 type Loader interface {
 	LoadEnv(viper.Viper) (*viper.Viper, error)
 }
@@ -36,6 +42,27 @@ type Config struct {
 	RedisUrl string
 
 	ElasticSearchURL string
+	// Page search backend, postgres for installs without Elasticsearch
+	SearchDriver string
+
+	// Interval between persisting live edited documents
+	CollaborationFlushInterval time.Duration
+
+	// Archived pages are purged after this many days, 0 keeps them forever
+	TrashRetentionDays int
+
+	// Interval between polls of the outbox for due events
+	OutboxPollInterval time.Duration
+	// Delivery attempts of an outbox event before it is dead lettered
+	OutboxMaxAttempts int
+
+	// Page exports download the asset files into the archive, otherwise assets are exported as links
+	ExportFetchAssets bool
+	// Hosts assets are downloaded from, assets stored elsewhere are exported as links
+	ExportAssetHosts []string
+	// Directory of the TrueType fonts of pdf exports, regular.ttf, bold.ttf, italic.ttf, bold-italic.ttf and mono.ttf.
+	// Without it the standard pdf fonts are used, which only cover latin characters
+	PDFFontDir string
 
 	ScyllaHosts    []string
 	ScyllaKeyspace string
@@ -112,6 +139,18 @@ func generateConfigFromViper(v *viper.Viper) Config {
 		RedisUrl: v.GetString("REDIS_URL"),
 
 		ElasticSearchURL: v.GetString("ELASTIC_SEARCH_URL"),
+		SearchDriver:     v.GetString("SEARCH_DRIVER"),
+
+		CollaborationFlushInterval: v.GetDuration("COLLABORATION_FLUSH_INTERVAL"),
+
+		TrashRetentionDays: v.GetInt("TRASH_RETENTION_DAYS"),
+
+		OutboxPollInterval: v.GetDuration("OUTBOX_POLL_INTERVAL"),
+		OutboxMaxAttempts:  v.GetInt("OUTBOX_MAX_ATTEMPTS"),
+
+		ExportFetchAssets: v.GetBool("EXPORT_FETCH_ASSETS"),
+		ExportAssetHosts:  strings.Split(v.GetString("EXPORT_ASSET_HOSTS"), ","),
+		PDFFontDir:        v.GetString("PDF_FONT_DIR"),
 
 		SecretKey:                       v.GetString("SECRET_KEY"),
 		SendgridKey:                     v.GetString("SENDGRID_API_KEY"),
@@ -132,6 +171,13 @@ func LoadConfig(loaders []Loader) Config {
 	v.SetDefault("PORT", "5000")
 	v.SetDefault("ENV", "local")
 	v.SetDefault("DEBUG", true)
+	v.SetDefault("COLLABORATION_FLUSH_INTERVAL", "10s")
+	v.SetDefault("TRASH_RETENTION_DAYS", 30)
+	v.SetDefault("SEARCH_DRIVER", SearchDriverElasticsearch)
+	v.SetDefault("OUTBOX_POLL_INTERVAL", "2s")
+	v.SetDefault("OUTBOX_MAX_ATTEMPTS", 10)
+	v.SetDefault("EXPORT_FETCH_ASSETS", true)
+	v.SetDefault("EXPORT_ASSET_HOSTS", "res.cloudinary.com")
 
 	for idx := range loaders {
 		newV, err := loaders[idx].LoadEnv(*v)
@@ -147,3 +193,24 @@ func LoadConfig(loaders []Loader) Config {
 func (c *Config) GetShutdownTimeout() time.Duration {
 	return 10 * time.Second
 }
+
+func (c *Config) GetCollaborationFlushInterval() time.Duration {
+	if c.CollaborationFlushInterval <= 0 {
+		return 10 * time.Second
+	}
+	return c.CollaborationFlushInterval
+}
+
+func (c *Config) GetOutboxPollInterval() time.Duration {
+	if c.OutboxPollInterval <= 0 {
+		return 2 * time.Second
+	}
+	return c.OutboxPollInterval
+}
+
+func (c *Config) GetOutboxMaxAttempts() int {
+	if c.OutboxMaxAttempts <= 0 {
+		return 10
+	}
+	return c.OutboxMaxAttempts
+}
@@ -8,6 +8,8 @@ const (
 	ActionUserMovePage       ActionCode = "user.move.page"
 	ActionUserVisitPage      ActionCode = "user.visit.page"
 	ActionUserUpdatePageInfo ActionCode = "user.update.page"
+	ActionUserRestorePage    ActionCode = "user.restore.page"
+	ActionUserDuplicatePage  ActionCode = "user.duplicate.page"
 )
 
 func (a ActionCode) String() string {
@@ -0,0 +1,47 @@
+package domain
+
+import "encoding/json"
+
+type LiveMessageType string
+
+const (
+	// Client -> Server, relayed to the other clients.
+	LiveMessageUpdate LiveMessageType = "update"
+	// Client -> Server, merged document state to persist.
+	LiveMessageSnapshot LiveMessageType = "snapshot"
+
+	// Server -> Client.
+	LiveMessageInit   LiveMessageType = "init"
+	LiveMessageAck    LiveMessageType = "ack"
+	LiveMessageJoined LiveMessageType = "joined"
+	LiveMessageLeft   LiveMessageType = "left"
+	LiveMessageSaved  LiveMessageType = "saved"
+	// The document was changed outside of the room, clients replace their state with JsonContent.
+	LiveMessageResync LiveMessageType = "resync"
+	// Presence changes of the page, including viewers not connected live.
+	LiveMessagePresence LiveMessageType = "presence"
+	LiveMessageError    LiveMessageType = "error"
+)
+
+// LiveMessage is the envelope exchanged over the live document connection.
+// Update payloads are opaque to the server (OT operations or CRDT updates),
+// they are only sequenced, relayed and buffered for clients joining later.
+// A snapshot carries the Seq of the last update it includes.
+type LiveMessage struct {
+	Type        LiveMessageType `json:"type"`
+	ClientID    string          `json:"client_id,omitempty"`
+	Seq         int64           `json:"seq,omitempty"`
+	Data        json.RawMessage `json:"data,omitempty"`
+	Updates     []LiveMessage   `json:"updates,omitempty"`
+	JsonContent string          `json:"json_content,omitempty"`
+	Version     int64           `json:"version,omitempty"`
+	User        *User           `json:"user,omitempty"`
+	Presence    *PresenceEvent  `json:"presence,omitempty"`
+	CanEdit     bool            `json:"can_edit,omitempty"`
+	Message     string          `json:"message,omitempty"`
+}
+
+func (m LiveMessage) Bytes() []byte {
+	b, _ := json.Marshal(m)
+	return b
+}
@@ -7,13 +7,41 @@ type Document struct {
 	UpdatedAt   string `json:"updated_at"`
 	CreatedAt   string `json:"created_at"`
 	PagePkID    int64  `json:"page_pkid"`
+	Version     int64  `json:"version"`
 }
 
+type DocumentFormat string
+
+const (
+	DocumentFormatMarkdown DocumentFormat = "md"
+	DocumentFormatHTML     DocumentFormat = "html"
+	DocumentFormatText     DocumentFormat = "txt"
+)
+
 type DocumentInput struct {
 	JsonContent string `json:"json_content"`
+	// Version the client based its changes on, nil skips the check.
+	Version *int64 `json:"version"`
 }
 
 type DocumentPageInput struct {
 	PageInput
 	Document DocumentInput `json:"document"`
 }
+
+type DocumentRevision struct {
+	PkID         int64  `json:"pkid"`
+	DocumentPkID int64  `json:"document_pkid"`
+	PagePkID     int64  `json:"page_pkid"`
+	AuthorPkID   *int64 `json:"author_pkid"`
+	Author       *User  `json:"author"`
+	JsonContent  string `json:"json_content,omitempty"`
+	Size         int64  `json:"size"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type DocumentRevisionListQuery struct {
+	PagePkID int64 `json:"page_pkid"`
+	Offset   int   `json:"offset"`
+	Limit    int   `json:"limit"`
+}
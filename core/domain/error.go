@@ -155,6 +155,19 @@ var (
 	}
 )
 
+var (
+	ErrSearchIndex = &Error{
+		Code:    InternalServerErrCode,
+		Error:   InternalServerErr,
+		Message: "Failed to update the search index.",
+	}
+	ErrSearchQuery = &Error{
+		Code:    InternalServerErrCode,
+		Error:   InternalServerErr,
+		Message: "Failed to search pages. Please try again!",
+	}
+)
+
 var (
 	ErrGetGoogleInfo = &Error{
 		Code:    InternalServerErrCode,
@@ -181,6 +194,46 @@ var (
 		Error:   NotFoundErr,
 		Message: "The member does not exist.",
 	}
+	ErrInvalidOrgMemberRole = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The member role is invalid.",
+	}
+	ErrOrgOwnerRemoval = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The owner cannot leave or be removed, transfer the ownership first.",
+	}
+	ErrOrgMemberNotActivated = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The member has not accepted the invitation yet.",
+	}
+	ErrShareLinkNotFound = &Error{
+		Code:    NotFoundCode,
+		Error:   NotFoundErr,
+		Message: "The share link does not exist.",
+	}
+	ErrShareLinkInactive = &Error{
+		Code:    ResourceInvalidOrExpiredCode,
+		Error:   BadRequestErr,
+		Message: "The share link was revoked, has expired or reached its maximum uses.",
+	}
+	ErrShareLinkPassword = &Error{
+		Code:    UnauthorizedCode,
+		Error:   UnauthorizedErr,
+		Message: "The share link password is missing or incorrect.",
+	}
+	ErrOrgGroupNotFound = &Error{
+		Code:    NotFoundCode,
+		Error:   NotFoundErr,
+		Message: "The group does not exist.",
+	}
+	ErrOrgGroupNameTaken = &Error{
+		Code:    ConflictCode,
+		Error:   ConflictErr,
+		Message: "The organization already has a group with this name.",
+	}
 	ErrExistOrgMember = func(userPkID int64) *Error {
 		return &Error{
 			Code:  BadRequestCode,
@@ -219,6 +272,119 @@ var (
 	}
 )
 
+var (
+	ErrDocumentRevisionNotFound = &Error{
+		Code:    NotFoundCode,
+		Error:   NotFoundErr,
+		Message: "The document revision does not exist.",
+	}
+	ErrPageNotDocument = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The page is not a document",
+	}
+	ErrInvalidDocumentContent = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The document content is not a valid editor json.",
+	}
+	ErrInvalidImportArchive = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The zip archive could not be read.",
+	}
+	ErrImportTooLarge = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The import exceeds the allowed number of files or size.",
+	}
+	ErrUnsupportedImportFile = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "Only markdown (.md, .markdown) and html (.html, .htm) files can be imported.",
+	}
+	ErrInvalidImportFile = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The file is not valid UTF-8 text.",
+	}
+	ErrPageExportNotFound = &Error{
+		Code:    NotFoundCode,
+		Error:   NotFoundErr,
+		Message: "The page export does not exist or has expired.",
+	}
+	ErrPageExportNotReady = &Error{
+		Code:    ConflictCode,
+		Error:   ConflictErr,
+		Message: "The page export is not ready to download.",
+	}
+	ErrPageExportTooLarge = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The exported pages exceed the maximum archive size.",
+	}
+	ErrPagePropertyNotFound = &Error{
+		Code:    NotFoundCode,
+		Error:   NotFoundErr,
+		Message: "The page property does not exist.",
+	}
+	ErrPagePropertyNameTaken = &Error{
+		Code:    ConflictCode,
+		Error:   ConflictErr,
+		Message: "The folder already has a property with this name.",
+	}
+	ErrPageNotFolder = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "Properties can only be defined on folders.",
+	}
+	ErrInvalidPageProperty = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The property name, type or options are invalid.",
+	}
+	ErrInvalidPropertyValue = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The value does not match the property type.",
+	}
+	ErrInvalidPropertyFilter = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The property filter or sort is invalid.",
+	}
+	ErrPageNotArchived = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The page is not in trash.",
+	}
+	ErrPageArchived = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The page is archived.",
+	}
+	ErrPageNotTemplate = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The page is not a template.",
+	}
+	ErrPageNotTrashRoot = &Error{
+		Code:    BadRequestCode,
+		Error:   BadRequestErr,
+		Message: "The page was archived along with its parent, restore the parent instead.",
+	}
+	ErrStaleVersion = func(currentVersion int64) *Error {
+		return &Error{
+			Code:  ConflictCode,
+			Error: ConflictErr,
+			Message: fmt.Sprintf(
+				"The resource has been modified, the current version is %d.",
+				currentVersion,
+			),
+		}
+	}
+)
+
 func NewErr(msg string, code int) *Error {
 	return &Error{
 		Code:    code,
@@ -0,0 +1,25 @@
+package domain
+
+// OrgGroup is a named set of members of an organization, pages can be shared with it at once.
+type OrgGroup struct {
+	PkID             int64  `json:"pkid"`
+	ID               string `json:"id"`
+	OrganizationPkID int64  `json:"organization_pkid"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	MemberCount      int64  `json:"member_count"`
+	Members          []User `json:"members,omitempty"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
+}
+
+type OrgGroupInput struct {
+	OrganizationPkID int64  `json:"organization_pkid"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+}
+
+type OrgGroupUpdateInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
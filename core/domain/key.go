@@ -3,5 +3,8 @@ package domain
 import "fmt"
 
 var (
-	UserKey = func(userPkID int64) string { return fmt.Sprintf("user:%d", userPkID) }
+	UserKey         = func(userPkID int64) string { return fmt.Sprintf("user:%d", userPkID) }
+	PagePresenceKey = func(pagePkID int64) string { return fmt.Sprintf("page_presence:%d", pagePkID) }
+	// Sorted set of all presence sessions scored by their last heartbeat.
+	PagePresenceSessionsKey = "page_presence_sessions"
 )
@@ -19,10 +19,58 @@ type OrganizationMemberRole int
 const (
 	Owner OrganizationMemberRole = iota + 1
 	Member
+	Admin
+	Guest
 )
 
 func (r OrganizationMemberRole) String() string {
-	return [...]string{"owner", "member"}[r-1]
+	return [...]string{"owner", "member", "admin", "guest"}[r-1]
+}
+
+func OrganizationMemberRoleFromString(role string) (OrganizationMemberRole, bool) {
+	switch role {
+	case "owner":
+		return Owner, true
+	case "admin":
+		return Admin, true
+	case "member":
+		return Member, true
+	case "guest":
+		return Guest, true
+	}
+	return 0, false
+}
+
+// CanManage reports whether the role can invite, change or remove members of the target role.
+// Owners manage everyone else, admins manage members and guests.
+func (r OrganizationMemberRole) CanManage(target OrganizationMemberRole) bool {
+	switch r {
+	case Owner:
+		return target != Owner
+	case Admin:
+		return target == Member || target == Guest
+	}
+	return false
+}
+
+// CanUpdateSettings reports whether the role can change the name, description and avatar of the organization.
+func (r OrganizationMemberRole) CanUpdateSettings() bool {
+	return r == Owner || r == Admin
+}
+
+// CanManageGroups reports whether the role can create groups and change their members.
+func (r OrganizationMemberRole) CanManageGroups() bool {
+	return r == Owner || r == Admin
+}
+
+// CanAuditSharing reports whether the role can see the pages of the organization shared outside of it.
+func (r OrganizationMemberRole) CanAuditSharing() bool {
+	return r == Owner || r == Admin
+}
+
+// CanListMembers reports whether the role can see the other members, guests only see the pages shared with them.
+func (r OrganizationMemberRole) CanListMembers() bool {
+	return r != Guest
 }
 
 type OrganizationMember struct {
@@ -36,4 +84,19 @@ type OrganizationMember struct {
 	User             *User  `json:"user"`
 }
 
+type OrganizationUpdateInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Avatar      *string `json:"avatar"`
+}
+
+// OrganizationMemberInviteInput invites a user to the organization, the invite id is chosen by the caller
+// so the invitation mail staged with it can link to the invite.
+type OrganizationMemberInviteInput struct {
+	OrgPkID  int64
+	UserPkID int64
+	Role     string
+	InviteID string
+}
+
 const InviteToOrgSubject = "Accept organization invitation"
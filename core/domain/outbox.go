@@ -0,0 +1,66 @@
+package domain
+
+import "encoding/json"
+
+type OutboxTopic string
+
+const (
+	OutboxTopicActivity OutboxTopic = "activity"
+	OutboxTopicSearch   OutboxTopic = "search"
+	OutboxTopicMail     OutboxTopic = "mail"
+)
+
+func (t OutboxTopic) String() string {
+	return string(t)
+}
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxDelivered OutboxStatus = "delivered"
+	// Dead events exhausted their attempts, they are listed by the outbox_dead_letters view.
+	OutboxDead OutboxStatus = "dead"
+)
+
+func (s OutboxStatus) String() string {
+	return string(s)
+}
+
+// OutboxEvent is a side effect of a mutation, committed in the same transaction and delivered later.
+type OutboxEvent struct {
+	PkID          int64           `json:"pkid"`
+	Topic         OutboxTopic     `json:"topic"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        OutboxStatus    `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error"`
+	NextAttemptAt string          `json:"next_attempt_at"`
+	CreatedAt     string          `json:"created_at"`
+	DeliveredAt   string          `json:"delivered_at"`
+}
+
+// OutboxEventInput payload is marshalled to JSON, ActivityInput, OutboxSearchPayload or OutboxMailPayload by topic.
+type OutboxEventInput struct {
+	Topic   OutboxTopic
+	Payload any
+}
+
+// OutboxSearchPayload lists the pages to refresh in the search index.
+type OutboxSearchPayload struct {
+	PagePkIDs []int64 `json:"page_pkids,omitempty"`
+	// Pages refreshed with all their descendants, moves and archives change the whole subtree.
+	TreePagePkIDs    []int64 `json:"tree_page_pkids,omitempty"`
+	DeletedPagePkIDs []int64 `json:"deleted_page_pkids,omitempty"`
+}
+
+// OutboxMailPayload is sent with the SendGrid template TemplateID when set, else with the HTML template.
+type OutboxMailPayload struct {
+	FromName         string            `json:"from_name,omitempty"`
+	ToName           string            `json:"to_name"`
+	ToAddress        string            `json:"to_address"`
+	TemplateID       string            `json:"template_id,omitempty"`
+	TemplateHTMLName string            `json:"template_html_name"`
+	Data             map[string]string `json:"data"`
+	Subject          string            `json:"subject"`
+}
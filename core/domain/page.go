@@ -30,6 +30,11 @@ type Page struct {
 	Permissions      *PageRolePermissions `json:"permissions"`
 	ParentPage       *Page                `json:"parent_page"`
 	PageStar         *PageStar            `json:"page_star"`
+	Version          int64                `json:"version"`
+	IsTemplate       bool                 `json:"is_template"`
+	Position         string               `json:"position"`
+	// Values of the properties inherited from the folders above the page, when requested
+	Properties []PagePropertyValue `json:"properties,omitempty"`
 }
 
 type PageRoleUser struct {
@@ -41,6 +46,8 @@ type PageRoleUser struct {
 	CreatedAt       string   `json:"created_at"`
 	UpdatedAt       string   `json:"updated_at"`
 	InheritFromPage *Page    `json:"inherit_from_page"`
+	// Set for roles given to a group, instead of a user and email
+	Group *OrgGroup `json:"group,omitempty"`
 }
 
 type PageInput struct {
@@ -50,21 +57,42 @@ type PageInput struct {
 	CoverImage       string       `json:"cover_image"`
 	AuthorPkID       int64        `json:"author_pkid"`
 	OrganizationPkID int64        `json:"organization_pkid"`
+	IsTemplate       bool         `json:"is_template"`
 }
 
 type PageUpdateInput struct {
 	Name       *string       `json:"name"`
 	ViewType   *PageViewType `json:"view_type"`
 	CoverImage *string       `json:"cover_image"`
+	IsTemplate *bool         `json:"is_template"`
 	Document   *struct {
 		JsonContent string `json:"json_content"`
 	} `json:"document"`
+	// Version the client based its changes on, nil skips the check.
+	Version *int64 `json:"version"`
+}
+
+type PageDuplicateInput struct {
+	ParentPagePkID *int64  `json:"parent_page_pkid"`
+	Name           *string `json:"name"`
+	// Descendants to copy along, the ones the actor can view.
+	DescendantPkIDs []int64 `json:"descendant_pkids"`
+	IncludeRoles    bool    `json:"include_roles"`
+	ActorPkID       int64   `json:"actor_pkid"`
+	// Values of the {{variable}} placeholders replaced in names and document contents.
+	Variables map[string]string `json:"variables"`
 }
 
 type PageMoveInput struct {
 	ParentPagePkID *int64 `json:"parent_page_pkid"`
 }
 
+// PageReorderInput places the page right after AfterPagePkID or right before BeforePagePkID among its siblings.
+type PageReorderInput struct {
+	BeforePagePkID *int64 `json:"before_page_pkid"`
+	AfterPagePkID  *int64 `json:"after_page_pkid"`
+}
+
 type PageListQuery struct {
 	OrgPkID             *int64         `json:"org_pkid"`
 	ViewTypes           []PageViewType `json:"view_type"`
@@ -78,6 +106,17 @@ type PageListQuery struct {
 	ExcludeGeneralRole  []PageRole     `json:"exclude_general_role"`
 	PathBeginWith       string         `json:"path_begin_with"`
 	IsStarredByUserPkID *int64         `json:"is_starred_by_user_pkid"`
+	IsTemplate          *bool          `json:"is_template"`
+	// Defaults to position for a single sibling set, updated_at desc otherwise.
+	OrderBy        PageOrderBy    `json:"order_by"`
+	OrderDirection OrderDirection `json:"order_direction"`
+	// Keyset pagination, only pages with a greater pkid, ordered by pkid.
+	AfterPkID *int64 `json:"after_pkid"`
+	// Pages matching every condition, sorted on the property before OrderBy when set.
+	PropertyConditions []PagePropertyCondition `json:"property_conditions"`
+	PropertySort       *PagePropertySort       `json:"property_sort"`
+	// Attaches the property values to the listed pages, not used by repositories.
+	WithProperties bool `json:"with_properties"`
 }
 
 type PageGeneralAccessUpdateInput struct {
@@ -85,9 +124,11 @@ type PageGeneralAccessUpdateInput struct {
 	GeneralRole PageRole `json:"general_role"`
 }
 
+// Page role inputs target either the email of a user or a group of the page organization.
 type PageRoleCreateInput struct {
 	PagePkID int64    `json:"page_pkid"`
 	Email    string   `json:"email"`
+	GroupID  string   `json:"group_id"`
 	Role     PageRole `json:"role"`
 }
 
@@ -95,6 +136,7 @@ type PageRoleUpdateInput struct {
 	AuthorPkID int64    `json:"author_pkid"`
 	PagePkID   int64    `json:"page_pkid"`
 	Email      string   `json:"email"`
+	GroupID    string   `json:"group_id"`
 	Role       PageRole `json:"role"`
 }
 
@@ -102,6 +144,7 @@ type PageRoleDeleteInput struct {
 	AuthorPkID int64  `json:"author_pkid"`
 	PagePkID   int64  `json:"page_pkid"`
 	Email      string `json:"email"`
+	GroupID    string `json:"group_id"`
 }
 
 type PageRoleGetAllInput struct {
@@ -149,6 +192,22 @@ func PageViewFromString(val string) PageViewType {
 	}
 }
 
+type PageOrderBy string
+
+const (
+	PageOrderByPosition  PageOrderBy = "position"
+	PageOrderByName      PageOrderBy = "name"
+	PageOrderByCreatedAt PageOrderBy = "created_at"
+	PageOrderByUpdatedAt PageOrderBy = "updated_at"
+)
+
+type OrderDirection string
+
+const (
+	OrderAsc  OrderDirection = "asc"
+	OrderDesc OrderDirection = "desc"
+)
+
 type PageDetailOptions struct {
 	Document     bool `json:"document"`
 	Asset        bool `json:"asset"`
@@ -199,6 +258,35 @@ func PageRoleFromString(val string) PageRole {
 	}
 }
 
+// IsSharedRole reports whether the role can be given through groups and share links, which only grant access.
+func (r PageRole) IsSharedRole() bool {
+	return r == PageViewer || r == PageEditor
+}
+
+// rank orders the roles by the access they give, inherit roles must be resolved first.
+func (r PageRole) rank() int {
+	switch r {
+	case PageEditor:
+		return 3
+	case PageViewer:
+		return 2
+	case PageRestrict:
+		return 1
+	}
+	return 0
+}
+
+// HighestPageRole returns the role giving the most access among the roles, nil when all are nil.
+func HighestPageRole(roles ...*PageRole) *PageRole {
+	var highest *PageRole
+	for _, role := range roles {
+		if role != nil && (highest == nil || role.rank() > highest.rank()) {
+			highest = role
+		}
+	}
+	return highest
+}
+
 func (p *Page) IsEmailAuthor(email string) bool {
 	if p.Author == nil {
 		return false
@@ -226,6 +314,8 @@ type PageRolePermissionCheckInput struct {
 	User     *User     `json:"user"`
 	Page     Page      `json:"page"`
 	PageRole *PageRole `json:"page_role"`
+	// Highest role given to the user through groups and share links, inherited roles resolved
+	SharedRole *PageRole `json:"shared_role"`
 }
 
 type PageRolePermissionBatchCheckInput struct {
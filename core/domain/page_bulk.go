@@ -0,0 +1,30 @@
+package domain
+
+type PageBulkAction string
+
+const (
+	PageBulkMove          PageBulkAction = "move"
+	PageBulkArchive       PageBulkAction = "archive"
+	PageBulkRestore       PageBulkAction = "restore"
+	PageBulkStar          PageBulkAction = "star"
+	PageBulkUnstar        PageBulkAction = "unstar"
+	PageBulkGeneralAccess PageBulkAction = "general_access"
+)
+
+type PageBulkInput struct {
+	Action    PageBulkAction `json:"action"`
+	PagePkIDs []int64        `json:"page_pkids"`
+	// Target parent of move, the root when nil.
+	ParentPagePkID *int64 `json:"parent_page_pkid"`
+	// New general role of general access updates.
+	GeneralRole *PageRole `json:"general_role"`
+	ActorPkID   int64     `json:"actor_pkid"`
+}
+
+// PageBulkResult is the outcome of a bulk action on a single page.
+type PageBulkResult struct {
+	PagePkID int64  `json:"page_pkid"`
+	Success  bool   `json:"success"`
+	Page     *Page  `json:"page,omitempty"`
+	Error    *Error `json:"error,omitempty"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+type PageExportStatus string
+
+const (
+	PageExportPending PageExportStatus = "pending"
+	PageExportRunning PageExportStatus = "running"
+	PageExportDone    PageExportStatus = "done"
+	PageExportFailed  PageExportStatus = "failed"
+)
+
+func (s PageExportStatus) String() string {
+	return string(s)
+}
+
+// PageExport is a job building a zip archive of a page and its descendants, downloadable until it expires.
+type PageExport struct {
+	PkID          int64            `json:"pkid"`
+	ID            string           `json:"id"`
+	PagePkID      int64            `json:"page_pkid"`
+	RequesterPkID int64            `json:"requester_pkid"`
+	Format        DocumentFormat   `json:"format"`
+	Status        PageExportStatus `json:"status"`
+	Attempts      int              `json:"attempts"`
+	Error         string           `json:"error"`
+	FileName      string           `json:"file_name"`
+	Size          int64            `json:"size"`
+	PageCount     int              `json:"page_count"`
+	CreatedAt     string           `json:"created_at"`
+	CompletedAt   string           `json:"completed_at"`
+	ExpiresAt     string           `json:"expires_at"`
+}
+
+type PageExportInput struct {
+	PagePkID      int64
+	RequesterPkID int64
+	Format        DocumentFormat
+	ExpiresAt     time.Time
+}
+
+// PageExportArchive is the zip archive built by an export.
+type PageExportArchive struct {
+	FileName  string
+	Content   []byte
+	PageCount int
+}
@@ -0,0 +1,23 @@
+package domain
+
+// PageImportFile is an uploaded file, a single markdown or html file or a zip archive of them.
+type PageImportFile struct {
+	// Slash separated path, relative to the archive root for archive entries.
+	Path    string
+	Content []byte
+}
+
+type PageImportInput struct {
+	OrgPkID int64
+	// Imported pages are created under the parent, at the root when nil.
+	ParentPagePkID *int64
+	File           PageImportFile
+}
+
+// PageImportResult is the outcome of importing a single file, or creating the folder of an archive directory.
+type PageImportResult struct {
+	Path    string `json:"path"`
+	Success bool   `json:"success"`
+	Page    *Page  `json:"page,omitempty"`
+	Error   *Error `json:"error,omitempty"`
+}
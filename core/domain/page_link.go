@@ -0,0 +1,32 @@
+package domain
+
+type PageLinkKind string
+
+const (
+	PageLinkMention PageLinkKind = "mention"
+	PageLinkLink    PageLinkKind = "link"
+)
+
+func (k PageLinkKind) String() string {
+	return string(k)
+}
+
+// PageLink is a reference from the document of the source page to the target page.
+type PageLink struct {
+	SourcePagePkID int64        `json:"source_page_pkid"`
+	TargetPagePkID int64        `json:"target_page_pkid"`
+	Kind           PageLinkKind `json:"kind"`
+	CreatedAt      string       `json:"created_at"`
+}
+
+// PageBacklink is a page referencing another one, with the kinds of its references.
+type PageBacklink struct {
+	Page  Page           `json:"page"`
+	Kinds []PageLinkKind `json:"kinds"`
+}
+
+// PageLinkGraph holds the links between the pages of an organization, and the pages they connect.
+type PageLinkGraph struct {
+	Pages []Page     `json:"pages"`
+	Links []PageLink `json:"links"`
+}
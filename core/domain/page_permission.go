@@ -0,0 +1,38 @@
+package domain
+
+// PagePermissionSource is where a rule of a permission explanation comes from.
+type PagePermissionSource string
+
+const (
+	PagePermissionAuthor        PagePermissionSource = "author"
+	PagePermissionDirectRole    PagePermissionSource = "direct_role"
+	PagePermissionInheritedRole PagePermissionSource = "inherited_role"
+	PagePermissionGroupRole     PagePermissionSource = "group_role"
+	PagePermissionShareLink     PagePermissionSource = "share_link"
+	PagePermissionGeneralRole   PagePermissionSource = "general_role"
+)
+
+func (s PagePermissionSource) String() string {
+	return string(s)
+}
+
+// PagePermissionRule is a rule that gives the user a role on the page. FromPage is the page the
+// rule is set on, the page itself or one of its ancestors. Applied marks the rules the resolved
+// permissions come from.
+type PagePermissionRule struct {
+	Source    PagePermissionSource `json:"source"`
+	Role      PageRole             `json:"role"`
+	FromPage  *Page                `json:"from_page"`
+	Group     *OrgGroup            `json:"group,omitempty"`
+	ShareLink *PageShareLink       `json:"share_link,omitempty"`
+	Applied   bool                 `json:"applied"`
+}
+
+// PagePermissionExplanation is the permissions of the user with the email on the page with the rules
+// they are resolved from.
+type PagePermissionExplanation struct {
+	PagePkID    int64                `json:"page_pkid"`
+	Email       string               `json:"email"`
+	Permissions PageRolePermissions  `json:"permissions"`
+	Rules       []PagePermissionRule `json:"rules"`
+}
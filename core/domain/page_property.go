@@ -0,0 +1,149 @@
+package domain
+
+type PagePropertyType string
+
+const (
+	PagePropertyText        PagePropertyType = "text"
+	PagePropertyNumber      PagePropertyType = "number"
+	PagePropertyDate        PagePropertyType = "date"
+	PagePropertySelect      PagePropertyType = "select"
+	PagePropertyMultiSelect PagePropertyType = "multi_select"
+	PagePropertyUser        PagePropertyType = "user"
+	PagePropertyCheckbox    PagePropertyType = "checkbox"
+)
+
+func (t PagePropertyType) String() string {
+	return string(t)
+}
+
+func (t PagePropertyType) HasOptions() bool {
+	return t == PagePropertySelect || t == PagePropertyMultiSelect
+}
+
+type PagePropertyOption struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// PageProperty is a typed field defined on a folder, the pages below the folder have a value for it.
+type PageProperty struct {
+	PkID           int64                `json:"pkid"`
+	ID             string               `json:"id"`
+	FolderPagePkID int64                `json:"folder_page_pkid"`
+	Name           string               `json:"name"`
+	Type           PagePropertyType     `json:"type"`
+	Options        []PagePropertyOption `json:"options"`
+	Position       int                  `json:"position"`
+	CreatedAt      string               `json:"created_at"`
+	UpdatedAt      string               `json:"updated_at"`
+}
+
+type PagePropertyInput struct {
+	FolderPagePkID int64                `json:"folder_page_pkid"`
+	Name           string               `json:"name"`
+	Type           PagePropertyType     `json:"type"`
+	Options        []PagePropertyOption `json:"options"`
+}
+
+type PagePropertyUpdateInput struct {
+	Name *string `json:"name"`
+	// Replaces the options when not nil, values of removed options are cleared
+	Options []PagePropertyOption `json:"options"`
+}
+
+// PagePropertyData is a property value, only the field of the property type is set.
+// Dates are formatted as 2006-01-02, select values hold the id of their option.
+type PagePropertyData struct {
+	Text      *string  `json:"text,omitempty"`
+	Number    *float64 `json:"number,omitempty"`
+	Date      *string  `json:"date,omitempty"`
+	Checkbox  *bool    `json:"checkbox,omitempty"`
+	UserPkID  *int64   `json:"user_pkid,omitempty"`
+	OptionIDs []string `json:"option_ids,omitempty"`
+}
+
+// PagePropertyValue is the value of a property for a page, Value is nil when the page has none.
+type PagePropertyValue struct {
+	PagePkID  int64        `json:"page_pkid"`
+	Property  PageProperty `json:"property"`
+	Value     any          `json:"value"`
+	UpdatedAt string       `json:"updated_at"`
+}
+
+type PagePropertyOperator string
+
+const (
+	PagePropertyEq          PagePropertyOperator = "eq"
+	PagePropertyNeq         PagePropertyOperator = "neq"
+	PagePropertyGt          PagePropertyOperator = "gt"
+	PagePropertyGte         PagePropertyOperator = "gte"
+	PagePropertyLt          PagePropertyOperator = "lt"
+	PagePropertyLte         PagePropertyOperator = "lte"
+	PagePropertyContains    PagePropertyOperator = "contains"
+	PagePropertyNotContains PagePropertyOperator = "not_contains"
+	PagePropertyIsEmpty     PagePropertyOperator = "is_empty"
+	PagePropertyIsNotEmpty  PagePropertyOperator = "is_not_empty"
+)
+
+// PagePropertyFilter filters listed pages on the value of a property, as requested.
+type PagePropertyFilter struct {
+	PropertyID string               `json:"property_id"`
+	Operator   PagePropertyOperator `json:"operator"`
+	Value      any                  `json:"value"`
+}
+
+// PagePropertyCondition is a filter resolved against its property, the operand typed as a value.
+type PagePropertyCondition struct {
+	Property PageProperty         `json:"property"`
+	Operator PagePropertyOperator `json:"operator"`
+	Operand  PagePropertyData     `json:"operand"`
+}
+
+type PagePropertySortInput struct {
+	PropertyID string         `json:"property_id"`
+	Direction  OrderDirection `json:"direction"`
+}
+
+type PagePropertySort struct {
+	Property  PageProperty   `json:"property"`
+	Direction OrderDirection `json:"direction"`
+}
+
+// IsEmpty reports whether the data holds no value, empty values are not stored.
+func (d PagePropertyData) IsEmpty() bool {
+	return (d.Text == nil || *d.Text == "") &&
+		d.Number == nil &&
+		d.Date == nil &&
+		(d.Checkbox == nil || !*d.Checkbox) &&
+		d.UserPkID == nil &&
+		len(d.OptionIDs) == 0
+}
+
+// Value returns the data as exchanged with clients for a property of type t, nil when empty.
+func (d PagePropertyData) Value(t PagePropertyType) any {
+	if d.IsEmpty() {
+		if t == PagePropertyCheckbox {
+			return false
+		}
+		return nil
+	}
+
+	switch t {
+	case PagePropertyText:
+		return d.Text
+	case PagePropertyNumber:
+		return d.Number
+	case PagePropertyDate:
+		return d.Date
+	case PagePropertyCheckbox:
+		return d.Checkbox
+	case PagePropertyUser:
+		return d.UserPkID
+	case PagePropertySelect:
+		return d.OptionIDs[0]
+	case PagePropertyMultiSelect:
+		return d.OptionIDs
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// PageShareLink opens a page, and its descendants, with the role of the link to whoever has it.
+// Signed-in users keep the role while the link is active, anonymous users can only view.
+type PageShareLink struct {
+	PkID           int64    `json:"pkid"`
+	ID             string   `json:"id"`
+	PagePkID       int64    `json:"page_pkid"`
+	CreatedByPkID  *int64   `json:"created_by_pkid"`
+	Role           PageRole `json:"role"`
+	ExpiresAt      string   `json:"expires_at"`
+	HasPassword    bool     `json:"has_password"`
+	MaxUses        *int32   `json:"max_uses"`
+	UseCount       int32    `json:"use_count"`
+	OrgMembersOnly bool     `json:"org_members_only"`
+	LastUsedAt     string   `json:"last_used_at"`
+	RevokedAt      string   `json:"revoked_at"`
+	IsActive       bool     `json:"is_active"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+	PasswordHash   string   `json:"-"`
+	PasswordSalt   string   `json:"-"`
+}
+
+type PageShareLinkInput struct {
+	PagePkID       int64      `json:"page_pkid"`
+	CreatedByPkID  int64      `json:"created_by_pkid"`
+	Role           PageRole   `json:"role"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	Password       string     `json:"password"`
+	MaxUses        *int32     `json:"max_uses"`
+	OrgMembersOnly bool       `json:"org_members_only"`
+	PasswordHash   string     `json:"-"`
+	PasswordSalt   string     `json:"-"`
+}
+
+// PageShareLinkAccess tracks a signed-in user who opened a share link.
+type PageShareLinkAccess struct {
+	User            *User  `json:"user"`
+	AccessCount     int32  `json:"access_count"`
+	FirstAccessedAt string `json:"first_accessed_at"`
+	LastAccessedAt  string `json:"last_accessed_at"`
+}
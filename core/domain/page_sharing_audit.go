@@ -0,0 +1,23 @@
+package domain
+
+// PageSharingAuditEntry is a page visible to people outside the organization, through its general role,
+// roles given to emails that are not members of the organization or share links open to anyone.
+// GeneralRoleFromPage is set when the general role is inherited from an ancestor.
+type PageSharingAuditEntry struct {
+	Page                *Page                  `json:"page"`
+	GeneralRole         PageRole               `json:"general_role"`
+	GeneralRoleFromPage *Page                  `json:"general_role_from_page"`
+	OutsideRoles        []PageRoleUser         `json:"outside_roles"`
+	ShareLinks          []PageSharingAuditLink `json:"share_links"`
+}
+
+// PageSharingAuditLink is a share link opening the page, FromPage is set when the link is on an ancestor.
+type PageSharingAuditLink struct {
+	Link     *PageShareLink `json:"link"`
+	FromPage *Page          `json:"from_page"`
+}
+
+// IsPublic reports whether the general role of the page lets anyone with the link open it.
+func (e PageSharingAuditEntry) IsPublic() bool {
+	return e.GeneralRole != PageRestrict
+}
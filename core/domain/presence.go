@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type PagePresence struct {
+	// One user may have the page open in several tabs, each one is a session.
+	SessionID  string          `json:"session_id"`
+	PagePkID   int64           `json:"page_pkid"`
+	User       *User           `json:"user"`
+	Cursor     json.RawMessage `json:"cursor,omitempty"`
+	JoinedAt   time.Time       `json:"joined_at"`
+	LastSeenAt time.Time       `json:"last_seen_at"`
+}
+
+type PresenceEventType string
+
+const (
+	PresenceJoined  PresenceEventType = "joined"
+	PresenceUpdated PresenceEventType = "updated"
+	PresenceLeft    PresenceEventType = "left"
+)
+
+type PresenceEvent struct {
+	Type     PresenceEventType `json:"type"`
+	PagePkID int64             `json:"page_pkid"`
+	Presence PagePresence      `json:"presence"`
+}
+
+type PresenceHeartbeatInput struct {
+	PagePkID  int64           `json:"page_pkid"`
+	SessionID string          `json:"session_id"`
+	Cursor    json.RawMessage `json:"cursor"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+// PageSearchDocument is the indexed form of a page.
+type PageSearchDocument struct {
+	PkID           int64        `json:"pkid"`
+	ID             string       `json:"id"`
+	OrgPkID        int64        `json:"org_pkid"`
+	ParentPagePkID *int64       `json:"parent_page_pkid"`
+	Name           string       `json:"name"`
+	Content        string       `json:"content"`
+	ViewType       PageViewType `json:"view_type"`
+	AssetExtension string       `json:"asset_extension"`
+	Path           string       `json:"path"`
+	IsArchived     bool         `json:"is_archived"`
+	UpdatedAt      string       `json:"updated_at"`
+}
+
+type PageSearchQuery struct {
+	OrgPkID   int64          `json:"org_pkid"`
+	Query     string         `json:"query"`
+	ViewTypes []PageViewType `json:"view_types"`
+	Offset    int            `json:"offset"`
+	Limit     int            `json:"limit"`
+}
+
+// PageSearchHit is a matching page of the index, before permissions are applied.
+type PageSearchHit struct {
+	PagePkID   int64               `json:"page_pkid"`
+	Score      float64             `json:"score"`
+	Highlights PageSearchHighlight `json:"highlights"`
+}
+
+// PageSearchHighlight holds the matched fragments as HTML, the text is escaped and matches are wrapped in <mark> tags.
+type PageSearchHighlight struct {
+	Name    []string `json:"name"`
+	Content []string `json:"content"`
+}
+
+type PageSearchResult struct {
+	Page       Page                `json:"page"`
+	Score      float64             `json:"score"`
+	Highlights PageSearchHighlight `json:"highlights"`
+}
+
+const (
+	PageSearchHighlightPreTag  = "<mark>"
+	PageSearchHighlightPostTag = "</mark>"
+)
@@ -10,9 +10,21 @@ type Cache interface {
 	Set(key string, value any, duration time.Duration) error
 	Get(key string) (string, error)
 	Delete(key string) error
+	HSet(key string, field string, value any, duration time.Duration) error
+	HGet(key string, field string) (string, error)
+	HGetAll(key string) (map[string]string, error)
+	HDel(key string, fields ...string) error
+	ZAdd(key string, member string, score float64) error
+	ZRangeByScore(key string, max float64) ([]string, error)
+	ZRem(key string, member string) (bool, error)
 }
 
 type CacheStore interface {
 	SetUser(user *domain.User, duration time.Duration) error
 	GetUser(userPkID int64) *domain.User
+	SetPagePresence(presence domain.PagePresence, duration time.Duration) error
+	GetPagePresence(pagePkID int64, userPkID int64, sessionID string) *domain.PagePresence
+	GetPagePresences(pagePkID int64) []domain.PagePresence
+	DeletePagePresence(pagePkID int64, userPkID int64, sessionID string) *domain.PagePresence
+	DeleteExpiredPagePresences(lastSeenBefore time.Time) []domain.PagePresence
 }
@@ -57,6 +57,7 @@ type OrganizationRepository interface {
 		ctx context.Context,
 		ownerPkID, pkId int64,
 	) (*domain.Organization, *domain.Error)
+	GetOrgs(ctx context.Context) ([]*domain.Organization, *domain.Error)
 	GetOrgsByUserPkID(ctx context.Context, usePkID int64) ([]*domain.Organization, *domain.Error)
 	GetOrgMemberByEmail(
 		ctx context.Context,
@@ -79,11 +80,39 @@ type OrganizationRepository interface {
 		userPkID *int64,
 		role string,
 	) (*domain.OrganizationMember, *domain.Error)
+	InviteMemberToOrg(ctx context.Context, input domain.OrganizationMemberInviteInput) *domain.Error
 	SetOrgMemberActivatedAt(
 		ctx context.Context,
 		pkID int64,
 		activatedAt time.Time,
 	) (*domain.OrganizationMember, *domain.Error)
+	UpdateOrg(
+		ctx context.Context,
+		pkID int64,
+		input domain.OrganizationUpdateInput,
+	) (*domain.Organization, *domain.Error)
+	UpdateOrgMemberRole(
+		ctx context.Context,
+		orgPkID int64,
+		userPkID int64,
+		role string,
+	) (*domain.OrganizationMember, *domain.Error)
+	RemoveOrgMember(ctx context.Context, orgPkID int64, userPkID int64) *domain.Error
+	TransferOrgOwnership(ctx context.Context, orgPkID int64, newOwnerPkID int64) *domain.Error
+
+	// Groups
+	ListOrgGroups(ctx context.Context, orgPkID int64) ([]domain.OrgGroup, *domain.Error)
+	GetOrgGroupByID(ctx context.Context, orgPkID int64, groupID string) (*domain.OrgGroup, *domain.Error)
+	ListOrgGroupsByUser(ctx context.Context, orgPkID int64, userPkID int64) ([]domain.OrgGroup, *domain.Error)
+	CreateOrgGroup(ctx context.Context, input domain.OrgGroupInput) (*domain.OrgGroup, *domain.Error)
+	UpdateOrgGroup(
+		ctx context.Context,
+		group domain.OrgGroup,
+		input domain.OrgGroupUpdateInput,
+	) (*domain.OrgGroup, *domain.Error)
+	DeleteOrgGroup(ctx context.Context, groupPkID int64) *domain.Error
+	AddOrgGroupMembers(ctx context.Context, groupPkID int64, userPkIDs []int64) *domain.Error
+	RemoveOrgGroupMember(ctx context.Context, groupPkID int64, userPkID int64) *domain.Error
 }
 
 type PageRepository interface {
@@ -92,12 +121,16 @@ type PageRepository interface {
 		query domain.PageListQuery,
 		curUser *domain.User,
 	) ([]domain.Page, *domain.Error)
+	// ListWithBody skips permission checks, for internal jobs only.
+	ListWithBody(ctx context.Context, query domain.PageListQuery) ([]domain.Page, *domain.Error)
 	Update(
 		ctx context.Context,
 		pagePkID int64,
 		page domain.PageUpdateInput,
 	) (*domain.Page, *domain.Error)
 	Move(ctx context.Context, pagePkID int64, parentPagePkID *int64) (*domain.Page, *domain.Error)
+	Reorder(ctx context.Context, pagePkID int64, input domain.PageReorderInput) (*domain.Page, *domain.Error)
+	BulkUpdate(ctx context.Context, input domain.PageBulkInput) ([]domain.PageBulkResult, *domain.Error)
 	GetByID(
 		ctx context.Context,
 		pageID string,
@@ -106,18 +139,19 @@ type PageRepository interface {
 		actorPkID *int64,
 	) (*domain.Page, *domain.Error)
 	Archive(ctx context.Context, pagePkID int64) (*domain.Page, *domain.Error)
+	Restore(ctx context.Context, pagePkID int64) (*domain.Page, *domain.Error)
+	DuplicatePage(
+		ctx context.Context,
+		pagePkID int64,
+		input domain.PageDuplicateInput,
+	) (*domain.Page, *domain.Error)
+	Purge(ctx context.Context, pagePkIDs []int64) *domain.Error
+	PurgeArchivedBefore(ctx context.Context, before time.Time) (int, *domain.Error)
 	UpdateGeneralAccess(
 		ctx context.Context,
 		pagePkID int64,
 		updateInput domain.PageGeneralAccessUpdateInput,
 	) (*domain.Page, *domain.Error)
-	// publication
-	CreatePublicToken(ctx context.Context, pagePkID int64) (*domain.PagePublicToken, *domain.Error)
-	ArchiveAllPublicToken(ctx context.Context, pagePkID int64) *domain.Error
-	GetPublicTokenByID(
-		ctx context.Context,
-		publicTokenID string,
-	) (*domain.PagePublicToken, *domain.Error)
 
 	// Document Page
 	CreateDocumentPage(
@@ -128,8 +162,44 @@ type PageRepository interface {
 		ctx context.Context,
 		pagePkID int64,
 		content domain.DocumentInput,
+		authorPkID *int64,
 	) (*domain.Page, *domain.Error)
 
+	// Document Revision
+	ListDocumentRevisions(
+		ctx context.Context,
+		query domain.DocumentRevisionListQuery,
+	) ([]domain.DocumentRevision, *domain.Error)
+	GetDocumentRevision(
+		ctx context.Context,
+		pagePkID int64,
+		revisionPkID int64,
+	) (*domain.DocumentRevision, *domain.Error)
+
+	// Page Link
+	ListBacklinks(ctx context.Context, pagePkID int64) ([]domain.PageLink, *domain.Error)
+	ListOrgLinks(ctx context.Context, orgPkID int64) ([]domain.PageLink, *domain.Error)
+	SyncLinks(ctx context.Context, pagePkIDs []int64) *domain.Error
+
+	// Page Property
+	ListProperties(ctx context.Context, folderPagePkIDs []int64) ([]domain.PageProperty, *domain.Error)
+	GetPropertyByID(ctx context.Context, propertyID string) (*domain.PageProperty, *domain.Error)
+	GetPropertiesByIDs(ctx context.Context, orgPkID int64, propertyIDs []string) ([]domain.PageProperty, *domain.Error)
+	CreateProperty(ctx context.Context, input domain.PagePropertyInput) (*domain.PageProperty, *domain.Error)
+	UpdateProperty(
+		ctx context.Context,
+		propertyPkID int64,
+		input domain.PagePropertyUpdateInput,
+	) (*domain.PageProperty, *domain.Error)
+	DeleteProperty(ctx context.Context, propertyPkID int64) *domain.Error
+	ListPropertyValues(ctx context.Context, pagePkIDs []int64) ([]domain.PagePropertyValue, *domain.Error)
+	SetPropertyValue(
+		ctx context.Context,
+		pagePkID int64,
+		property domain.PageProperty,
+		data domain.PagePropertyData,
+	) (*domain.PagePropertyValue, *domain.Error)
+
 	// Asset Page
 	CreateAsset(ctx context.Context, asset domain.AssetPageInput) (*domain.Page, *domain.Error)
 
@@ -163,6 +233,40 @@ type PageRepository interface {
 		input domain.PageRolePermissionCheckInput,
 	) domain.PageRolePermissions
 
+	// Page Group Role
+	CreatePageGroupRole(
+		ctx context.Context,
+		pagePkID int64,
+		group domain.OrgGroup,
+		role domain.PageRole,
+	) (*domain.PageRoleUser, *domain.Error)
+	GetPageGroupRole(ctx context.Context, pagePkID, groupPkID int64) (*domain.PageRoleUser, *domain.Error)
+	UpdatePageGroupRole(ctx context.Context, pagePkID, groupPkID int64, role domain.PageRole) *domain.Error
+	DeletePageGroupRole(ctx context.Context, pagePkID, groupPkID int64) *domain.Error
+	GetUserPageRoles(
+		ctx context.Context,
+		pagePkID int64,
+		user domain.User,
+	) (directRole *domain.PageRole, sharedRole *domain.PageRole)
+	// GetSharedRolesByUser returns the highest role given to the user on each page through groups and share links.
+	GetSharedRolesByUser(
+		ctx context.Context,
+		userPkID int64,
+		pagePkIDs []int64,
+	) (map[int64]domain.PageRole, *domain.Error)
+
+	// Page Share Link
+	CreateShareLink(ctx context.Context, input domain.PageShareLinkInput) (*domain.PageShareLink, *domain.Error)
+	ListShareLinks(ctx context.Context, pagePkID int64) ([]domain.PageShareLink, *domain.Error)
+	GetShareLinkByID(ctx context.Context, linkID string) (*domain.PageShareLink, *domain.Error)
+	RevokeShareLink(ctx context.Context, linkPkID int64) *domain.Error
+	UseShareLink(ctx context.Context, linkPkID int64, userPkID *int64) *domain.Error
+	ListShareLinkAccesses(ctx context.Context, linkPkID int64) ([]domain.PageShareLinkAccess, *domain.Error)
+	ListOpenedShareLinks(ctx context.Context, userPkID int64, pagePkID int64) ([]domain.PageShareLink, *domain.Error)
+
+	// Sharing audit
+	ListOrgSharingAudit(ctx context.Context, orgPkID int64) ([]domain.PageSharingAuditEntry, *domain.Error)
+
 	SyncPageRoleWithNewUser(
 		ctx context.Context,
 		user domain.User,
@@ -225,3 +329,22 @@ type ActivityRepository interface {
 		input domain.ActivityInput,
 	) (*domain.Activity, *domain.Error)
 }
+
+type OutboxRepository interface {
+	// Claim leases due pending events, attempts are counted when claimed.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxEvent, *domain.Error)
+	MarkDelivered(ctx context.Context, eventPkID int64) *domain.Error
+	MarkFailed(ctx context.Context, eventPkID int64, lastError string, nextAttemptAt *time.Time) *domain.Error
+	DeleteDeliveredBefore(ctx context.Context, before time.Time) (int, *domain.Error)
+}
+
+type PageExportRepository interface {
+	Create(ctx context.Context, input domain.PageExportInput) (*domain.PageExport, *domain.Error)
+	GetByID(ctx context.Context, id string) (*domain.PageExport, *domain.Error)
+	GetArchive(ctx context.Context, id string) ([]byte, *domain.Error)
+	// Claim leases the oldest unfinished export, nil when there is none. Attempts are counted when claimed.
+	Claim(ctx context.Context, lease time.Duration) (*domain.PageExport, *domain.Error)
+	Complete(ctx context.Context, exportPkID int64, archive domain.PageExportArchive) *domain.Error
+	Fail(ctx context.Context, exportPkID int64, reason string) *domain.Error
+	DeleteExpired(ctx context.Context, now time.Time) (int, *domain.Error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+type PageSearcher interface {
+	IndexPages(ctx context.Context, pages []domain.PageSearchDocument) *domain.Error
+	DeletePages(ctx context.Context, pagePkIDs []int64) *domain.Error
+	// Search returns the matching pages of the organization, the caller filters them by permission.
+	Search(ctx context.Context, query domain.PageSearchQuery) ([]domain.PageSearchHit, *domain.Error)
+}
@@ -105,16 +105,13 @@ func (s Service) ListPageActivities(curUser *domain.User, pagePkID int64) ([]dom
 		return nil, domain.ErrNotFound
 	}
 
-	var userRole *domain.PageRole = nil
-	pageRole, _ := s.pageRepository.GetPageRoleByEmail(context.Background(), pagePkID, curUser.Email)
-	if pageRole != nil {
-		userRole = &pageRole.Role
-	}
+	userRole, sharedRole := s.pageRepository.GetUserPageRoles(context.Background(), pagePkID, *curUser)
 
 	permisisons := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
-		Page:     *page,
-		User:     curUser,
-		PageRole: userRole,
+		Page:       *page,
+		User:       curUser,
+		PageRole:   userRole,
+		SharedRole: sharedRole,
 	})
 
 	if !permisisons.CanView {
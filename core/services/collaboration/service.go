@@ -0,0 +1,481 @@
+package collaboration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/ports"
+	"github.com/Stuhub-io/logger"
+	"github.com/Stuhub-io/utils/outboxutils"
+	"github.com/google/uuid"
+)
+
+const (
+	// Outbound messages buffered per client, a client falling further behind is disconnected.
+	clientSendBuffer = 256
+	// Updates kept per room for clients joining later, older ones are covered by snapshots.
+	maxBufferedUpdates = 1000
+)
+
+type Service struct {
+	cfg            config.Config
+	logger         logger.Logger
+	pageRepository ports.PageRepository
+
+	mu    sync.Mutex
+	rooms map[int64]*room
+}
+
+type NewServiceParams struct {
+	config.Config
+	logger.Logger
+	ports.PageRepository
+}
+
+func NewService(params NewServiceParams) *Service {
+	return &Service{
+		cfg:            params.Config,
+		logger:         params.Logger,
+		pageRepository: params.PageRepository,
+		rooms:          map[int64]*room{},
+	}
+}
+
+// Client is a single live connection to a page.
+type Client struct {
+	ID       string
+	PagePkID int64
+	User     *domain.User
+	CanEdit  bool
+
+	send   chan []byte
+	closed bool
+}
+
+// Outbound is closed once the client left the room or was disconnected.
+func (c *Client) Outbound() <-chan []byte {
+	return c.send
+}
+
+type snapshot struct {
+	jsonContent string
+	seq         int64
+	author      *domain.User
+}
+
+type room struct {
+	mu       sync.Mutex
+	pagePkID int64
+	clients  map[string]*Client
+	seq      int64
+	updates  []domain.LiveMessage
+	// Latest persisted or pending state.
+	content string
+	version int64
+	pending *snapshot
+}
+
+func (r *room) send(client *Client, msg domain.LiveMessage) {
+	if client.closed {
+		return
+	}
+	select {
+	case client.send <- msg.Bytes():
+	default:
+		// Slow consumer, drop it instead of blocking the room.
+		r.remove(client)
+	}
+}
+
+func (r *room) broadcast(msg domain.LiveMessage, except *Client) {
+	for _, client := range r.clients {
+		if client != except {
+			r.send(client, msg)
+		}
+	}
+}
+
+func (r *room) remove(client *Client) bool {
+	if _, ok := r.clients[client.ID]; !ok {
+		return false
+	}
+	delete(r.clients, client.ID)
+	client.closed = true
+	close(client.send)
+	return true
+}
+
+func (s *Service) Join(pagePkID int64, user *domain.User) (*Client, *domain.Error) {
+	if user == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	page, err := s.getDocumentPage(pagePkID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := s.permissions(page, user)
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	client := &Client{
+		ID:       uuid.NewString(),
+		PagePkID: pagePkID,
+		User:     user,
+		CanEdit:  permissions.CanEdit,
+		send:     make(chan []byte, clientSendBuffer),
+	}
+
+	s.mu.Lock()
+	r, ok := s.rooms[pagePkID]
+	if !ok {
+		r = &room{
+			pagePkID: pagePkID,
+			clients:  map[string]*Client{},
+			content:  page.Document.JsonContent,
+			version:  page.Document.Version,
+		}
+		s.rooms[pagePkID] = r
+	}
+	r.mu.Lock()
+	s.mu.Unlock()
+	defer r.mu.Unlock()
+
+	content, seq := r.content, int64(0)
+	if r.pending != nil {
+		content, seq = r.pending.jsonContent, r.pending.seq
+	}
+	updates := []domain.LiveMessage{}
+	for _, update := range r.updates {
+		if update.Seq > seq {
+			updates = append(updates, update)
+		}
+	}
+
+	r.clients[client.ID] = client
+	r.send(client, domain.LiveMessage{
+		Type:        domain.LiveMessageInit,
+		ClientID:    client.ID,
+		Seq:         seq,
+		JsonContent: content,
+		Updates:     updates,
+		Version:     r.version,
+		CanEdit:     client.CanEdit,
+	})
+	r.broadcast(domain.LiveMessage{
+		Type:     domain.LiveMessageJoined,
+		ClientID: client.ID,
+		User:     user,
+	}, client)
+
+	return client, nil
+}
+
+func (s *Service) Receive(client *Client, raw []byte) {
+	r := s.getRoom(client.PagePkID)
+	if r == nil {
+		return
+	}
+
+	var msg domain.LiveMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		r.mu.Lock()
+		r.send(client, domain.LiveMessage{Type: domain.LiveMessageError, Message: "Invalid message"})
+		r.mu.Unlock()
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if client.closed {
+		return
+	}
+
+	if msg.Type != domain.LiveMessageUpdate && msg.Type != domain.LiveMessageSnapshot {
+		r.send(client, domain.LiveMessage{Type: domain.LiveMessageError, Message: "Unsupported message type"})
+		return
+	}
+
+	if !client.CanEdit {
+		r.send(client, domain.LiveMessage{Type: domain.LiveMessageError, Message: domain.ErrPermissionDenied.Message})
+		return
+	}
+
+	switch msg.Type {
+	case domain.LiveMessageUpdate:
+		r.seq++
+		update := domain.LiveMessage{
+			Type:     domain.LiveMessageUpdate,
+			ClientID: client.ID,
+			Seq:      r.seq,
+			Data:     msg.Data,
+		}
+		r.updates = append(r.updates, update)
+		if len(r.updates) > maxBufferedUpdates {
+			r.updates = r.updates[len(r.updates)-maxBufferedUpdates:]
+		}
+
+		r.send(client, domain.LiveMessage{Type: domain.LiveMessageAck, Seq: update.Seq})
+		r.broadcast(update, client)
+	case domain.LiveMessageSnapshot:
+		if msg.Seq > r.seq {
+			msg.Seq = r.seq
+		}
+		if r.pending != nil && msg.Seq < r.pending.seq {
+			// Older than what is already pending.
+			return
+		}
+		r.pending = &snapshot{
+			jsonContent: msg.JsonContent,
+			seq:         msg.Seq,
+			author:      client.User,
+		}
+
+		// Updates included in the snapshot are no longer needed by new clients.
+		for len(r.updates) > 0 && r.updates[0].Seq <= msg.Seq {
+			r.updates = r.updates[1:]
+		}
+	}
+}
+
+func (s *Service) Leave(client *Client) {
+	s.mu.Lock()
+	r, ok := s.rooms[client.PagePkID]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+
+	r.mu.Lock()
+	r.remove(client)
+	r.broadcast(domain.LiveMessage{
+		Type:     domain.LiveMessageLeft,
+		ClientID: client.ID,
+		User:     client.User,
+	}, nil)
+	empty := len(r.clients) == 0
+	r.mu.Unlock()
+	s.mu.Unlock()
+
+	if !empty {
+		return
+	}
+
+	// Keep the room while persisting, so a client joining meanwhile gets the latest state.
+	s.persist(r)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.clients) == 0 && r.pending == nil && s.rooms[r.pagePkID] == r {
+		delete(s.rooms, r.pagePkID)
+	}
+}
+
+// Run persists pending snapshots periodically until ctx is done.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.GetCollaborationFlushInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.Flush()
+			return
+		case <-ticker.C:
+			s.Flush()
+		}
+	}
+}
+
+func (s *Service) Flush() {
+	s.mu.Lock()
+	rooms := make([]*room, 0, len(s.rooms))
+	for _, r := range s.rooms {
+		rooms = append(rooms, r)
+	}
+	s.mu.Unlock()
+
+	for _, r := range rooms {
+		s.persist(r)
+	}
+}
+
+func (s *Service) persist(r *room) {
+	r.mu.Lock()
+	pending, version := r.pending, r.version
+	r.pending = nil
+	users := []*domain.User{}
+	for _, client := range r.clients {
+		users = append(users, client.User)
+	}
+	r.mu.Unlock()
+
+	if pending == nil {
+		return
+	}
+
+	page, err := s.getDocumentPage(r.pagePkID)
+	if err != nil {
+		s.logger.Errorf(errors.New(err.Message), "[Collaboration]: Failed to load page %d", r.pagePkID)
+		s.restorePending(r, pending)
+		return
+	}
+
+	// Roles may have changed since the clients joined.
+	canEdit := map[int64]bool{}
+	for _, user := range append(users, pending.author) {
+		if _, ok := canEdit[user.PkID]; !ok {
+			canEdit[user.PkID] = s.permissions(page, user).CanEdit
+		}
+	}
+	s.refreshCanEdit(r, canEdit)
+	if !canEdit[pending.author.PkID] {
+		s.logger.Infof("[Collaboration]: Dropped snapshot of page %d, user %d can no longer edit", r.pagePkID, pending.author.PkID)
+		return
+	}
+
+	page, err = s.pageRepository.UpdateContent(
+		outboxutils.WithEvents(context.Background(), outboxutils.IndexPages),
+		r.pagePkID,
+		domain.DocumentInput{
+			JsonContent: pending.jsonContent,
+			Version:     &version,
+		},
+		&pending.author.PkID,
+	)
+	if err != nil && err.Code == domain.ConflictCode {
+		// Retrying the same snapshot would conflict forever, start over from the stored document.
+		s.resync(r)
+		return
+	}
+
+	if err != nil {
+		s.logger.Errorf(errors.New(err.Message), "[Collaboration]: Failed to persist page %d", r.pagePkID)
+		s.restorePending(r, pending)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.content = pending.jsonContent
+	if page.Document != nil {
+		r.version = page.Document.Version
+	}
+	r.broadcast(domain.LiveMessage{
+		Type:    domain.LiveMessageSaved,
+		Seq:     pending.seq,
+		Version: r.version,
+	}, nil)
+}
+
+// restorePending puts a snapshot that failed to persist back, unless a newer one arrived meanwhile.
+func (s *Service) restorePending(r *room, pending *snapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == nil {
+		r.pending = pending
+	}
+}
+
+func (s *Service) refreshCanEdit(r *room, canEdit map[int64]bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, client := range r.clients {
+		allowed, ok := canEdit[client.User.PkID]
+		if !ok || allowed == client.CanEdit {
+			continue
+		}
+		client.CanEdit = allowed
+		if !allowed {
+			r.send(client, domain.LiveMessage{Type: domain.LiveMessageError, Message: domain.ErrPermissionDenied.Message})
+		}
+	}
+}
+
+// resync replaces the room state with the stored document after a concurrent write,
+// updates and snapshots based on the older content are discarded.
+func (s *Service) resync(r *room) {
+	page, err := s.getDocumentPage(r.pagePkID)
+	if err != nil {
+		s.logger.Errorf(errors.New(err.Message), "[Collaboration]: Failed to reload page %d", r.pagePkID)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.content = page.Document.JsonContent
+	r.version = page.Document.Version
+	r.updates = []domain.LiveMessage{}
+	r.pending = nil
+	r.broadcast(domain.LiveMessage{
+		Type:        domain.LiveMessageResync,
+		Seq:         r.seq,
+		JsonContent: r.content,
+		Version:     r.version,
+	}, nil)
+}
+
+func (s *Service) getDocumentPage(pagePkID int64) (*domain.Page, *domain.Error) {
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{
+			Document: true,
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.ViewType != domain.PageViewTypeDoc || page.Document == nil {
+		return nil, domain.ErrPageNotDocument
+	}
+
+	return page, nil
+}
+
+func (s *Service) permissions(page *domain.Page, user *domain.User) domain.PageRolePermissions {
+	curRole, sharedRole := s.pageRepository.GetUserPageRoles(context.Background(), page.PkID, *user)
+	return s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:       *page,
+		User:       user,
+		PageRole:   curRole,
+		SharedRole: sharedRole,
+	})
+}
+
+func (s *Service) getRoom(pagePkID int64) *room {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rooms[pagePkID]
+}
+
+// BroadcastPresence pushes presence changes of the page to its live clients,
+// all of them passed the view permission check when joining.
+func (s *Service) BroadcastPresence(event domain.PresenceEvent) {
+	r := s.getRoom(event.PagePkID)
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.broadcast(domain.LiveMessage{
+		Type:     domain.LiveMessagePresence,
+		Presence: &event,
+	}, nil)
+}
@@ -31,7 +31,7 @@ type EmailInviteInfo struct {
 }
 
 type InviteMemberByEmailsDto struct {
-	Owner       *domain.User
+	Inviter     *domain.User
 	OrgInfo     OrgInviteInfo
 	InviteInfos []EmailInviteInfo
 }
@@ -56,3 +56,55 @@ type ActivateMemberDto struct {
 	MemberPkID int64
 	OrgPkID    int64
 }
+
+type UpdateMemberRoleDto struct {
+	Actor      *domain.User
+	OrgPkID    int64
+	MemberPkID int64
+	Role       string
+}
+
+type RemoveMemberDto struct {
+	Actor      *domain.User
+	OrgPkID    int64
+	MemberPkID int64
+}
+
+type TransferOwnershipDto struct {
+	Owner        *domain.User
+	OrgPkID      int64
+	NewOwnerPkID int64
+}
+
+type UpdateOrganizationDto struct {
+	Actor   *domain.User
+	OrgPkID int64
+	Input   domain.OrganizationUpdateInput
+}
+
+type CreateGroupDto struct {
+	Actor   *domain.User
+	OrgPkID int64
+	Input   domain.OrgGroupInput
+}
+
+type UpdateGroupDto struct {
+	Actor   *domain.User
+	OrgPkID int64
+	GroupID string
+	Input   domain.OrgGroupUpdateInput
+}
+
+type AddGroupMembersDto struct {
+	Actor       *domain.User
+	OrgPkID     int64
+	GroupID     string
+	MemberPkIDs []int64
+}
+
+type RemoveGroupMemberDto struct {
+	Actor      *domain.User
+	OrgPkID    int64
+	GroupID    string
+	MemberPkID int64
+}
@@ -0,0 +1,137 @@
+package organization
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+// GetGroups lists the groups of the organization, guests cannot see them.
+func (s *Service) GetGroups(orgPkID int64, curUser *domain.User) ([]domain.OrgGroup, *domain.Error) {
+	role, err := s.getMemberRole(orgPkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+	if !role.CanListMembers() {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	return s.orgRepository.ListOrgGroups(context.Background(), orgPkID)
+}
+
+func (s *Service) GetGroup(orgPkID int64, groupID string, curUser *domain.User) (*domain.OrgGroup, *domain.Error) {
+	role, err := s.getMemberRole(orgPkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+	if !role.CanListMembers() {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	return s.orgRepository.GetOrgGroupByID(context.Background(), orgPkID, groupID)
+}
+
+func (s *Service) CreateGroup(dto CreateGroupDto) (*domain.OrgGroup, *domain.Error) {
+	if err := s.checkManageGroups(dto.OrgPkID, dto.Actor); err != nil {
+		return nil, err
+	}
+
+	input := dto.Input
+	input.OrganizationPkID = dto.OrgPkID
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, domain.ErrBadRequest
+	}
+
+	return s.orgRepository.CreateOrgGroup(context.Background(), input)
+}
+
+func (s *Service) UpdateGroup(dto UpdateGroupDto) (*domain.OrgGroup, *domain.Error) {
+	group, err := s.getManagedGroup(dto.OrgPkID, dto.GroupID, dto.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	input := dto.Input
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, domain.ErrBadRequest
+		}
+		input.Name = &name
+	}
+
+	return s.orgRepository.UpdateOrgGroup(context.Background(), *group, input)
+}
+
+// DeleteGroup deletes the group, its members lose the page roles given to the group.
+func (s *Service) DeleteGroup(orgPkID int64, groupID string, curUser *domain.User) *domain.Error {
+	group, err := s.getManagedGroup(orgPkID, groupID, curUser)
+	if err != nil {
+		return err
+	}
+
+	return s.orgRepository.DeleteOrgGroup(context.Background(), group.PkID)
+}
+
+// AddGroupMembers adds members of the organization to the group.
+func (s *Service) AddGroupMembers(dto AddGroupMembersDto) (*domain.OrgGroup, *domain.Error) {
+	group, err := s.getManagedGroup(dto.OrgPkID, dto.GroupID, dto.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	memberPkIDs := sliceutils.Uniquify(dto.MemberPkIDs)
+	for _, memberPkID := range memberPkIDs {
+		if _, _, err := s.getMember(dto.OrgPkID, memberPkID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.orgRepository.AddOrgGroupMembers(context.Background(), group.PkID, memberPkIDs); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepository.GetOrgGroupByID(context.Background(), dto.OrgPkID, dto.GroupID)
+}
+
+// RemoveGroupMember removes a member from the group, members can also leave by removing themselves.
+func (s *Service) RemoveGroupMember(dto RemoveGroupMemberDto) (*domain.OrgGroup, *domain.Error) {
+	var group *domain.OrgGroup
+	var err *domain.Error
+	if dto.Actor != nil && dto.Actor.PkID == dto.MemberPkID {
+		group, err = s.orgRepository.GetOrgGroupByID(context.Background(), dto.OrgPkID, dto.GroupID)
+	} else {
+		group, err = s.getManagedGroup(dto.OrgPkID, dto.GroupID, dto.Actor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepository.RemoveOrgGroupMember(context.Background(), group.PkID, dto.MemberPkID); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepository.GetOrgGroupByID(context.Background(), dto.OrgPkID, dto.GroupID)
+}
+
+func (s *Service) checkManageGroups(orgPkID int64, user *domain.User) *domain.Error {
+	role, err := s.getMemberRole(orgPkID, user)
+	if err != nil {
+		return err
+	}
+	if !role.CanManageGroups() {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *Service) getManagedGroup(orgPkID int64, groupID string, user *domain.User) (*domain.OrgGroup, *domain.Error) {
+	if err := s.checkManageGroups(orgPkID, user); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepository.GetOrgGroupByID(context.Background(), orgPkID, groupID)
+}
@@ -0,0 +1,156 @@
+package organization
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+// GetMembers lists the members of the organization, guests cannot see them.
+func (s *Service) GetMembers(orgPkID int64, curUser *domain.User) ([]domain.OrganizationMember, *domain.Error) {
+	role, err := s.getMemberRole(orgPkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+	if !role.CanListMembers() {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	return s.orgRepository.GetOrgMembers(context.Background(), orgPkID)
+}
+
+// UpdateMemberRole changes the role of a member, the ownership is only changed by a transfer.
+func (s *Service) UpdateMemberRole(dto UpdateMemberRoleDto) (*domain.OrganizationMember, *domain.Error) {
+	newRole, ok := domain.OrganizationMemberRoleFromString(dto.Role)
+	if !ok || newRole == domain.Owner {
+		return nil, domain.ErrInvalidOrgMemberRole
+	}
+
+	actorRole, err := s.getMemberRole(dto.OrgPkID, dto.Actor)
+	if err != nil {
+		return nil, err
+	}
+	member, memberRole, err := s.getMember(dto.OrgPkID, dto.MemberPkID)
+	if err != nil {
+		return nil, err
+	}
+	if !actorRole.CanManage(memberRole) || !actorRole.CanManage(newRole) {
+		return nil, domain.ErrPermissionDenied
+	}
+	if memberRole == newRole {
+		return member, nil
+	}
+
+	return s.orgRepository.UpdateOrgMemberRole(context.Background(), dto.OrgPkID, dto.MemberPkID, newRole.String())
+}
+
+// RemoveMember removes a member from the organization, members can also leave by removing themselves.
+// The removed member loses the page roles given in the organization.
+func (s *Service) RemoveMember(dto RemoveMemberDto) *domain.Error {
+	_, memberRole, err := s.getMember(dto.OrgPkID, dto.MemberPkID)
+	if err != nil {
+		return err
+	}
+	if memberRole == domain.Owner {
+		return domain.ErrOrgOwnerRemoval
+	}
+
+	if dto.Actor == nil || dto.Actor.PkID != dto.MemberPkID {
+		actorRole, err := s.getMemberRole(dto.OrgPkID, dto.Actor)
+		if err != nil {
+			return err
+		}
+		if !actorRole.CanManage(memberRole) {
+			return domain.ErrPermissionDenied
+		}
+	}
+
+	return s.orgRepository.RemoveOrgMember(context.Background(), dto.OrgPkID, dto.MemberPkID)
+}
+
+// TransferOwnership hands the organization over to another member who accepted the invitation,
+// the previous owner becomes an admin.
+func (s *Service) TransferOwnership(dto TransferOwnershipDto) (*domain.Organization, *domain.Error) {
+	actorRole, err := s.getMemberRole(dto.OrgPkID, dto.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if actorRole != domain.Owner {
+		return nil, domain.ErrPermissionDenied
+	}
+	if dto.Owner.PkID == dto.NewOwnerPkID {
+		return s.orgRepository.GetOrgByPkID(context.Background(), dto.OrgPkID)
+	}
+
+	member, memberRole, err := s.getMember(dto.OrgPkID, dto.NewOwnerPkID)
+	if err != nil {
+		return nil, err
+	}
+	if memberRole == domain.Guest {
+		return nil, domain.ErrInvalidOrgMemberRole
+	}
+	if member.ActivatedAt == "" {
+		return nil, domain.ErrOrgMemberNotActivated
+	}
+
+	if err := s.orgRepository.TransferOrgOwnership(context.Background(), dto.OrgPkID, dto.NewOwnerPkID); err != nil {
+		return nil, err
+	}
+	return s.orgRepository.GetOrgByPkID(context.Background(), dto.OrgPkID)
+}
+
+// UpdateOrganization changes the name, description or avatar of the organization, its slug is kept.
+func (s *Service) UpdateOrganization(dto UpdateOrganizationDto) (*domain.Organization, *domain.Error) {
+	role, err := s.getMemberRole(dto.OrgPkID, dto.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if !role.CanUpdateSettings() {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	input := dto.Input
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, domain.ErrBadRequest
+		}
+		input.Name = &name
+	}
+
+	return s.orgRepository.UpdateOrg(context.Background(), dto.OrgPkID, input)
+}
+
+// getMemberRole returns the role of the user in the organization. Invited members only act with
+// their role once they accepted the invitation, the owner is a member from the start.
+func (s *Service) getMemberRole(orgPkID int64, user *domain.User) (domain.OrganizationMemberRole, *domain.Error) {
+	if user == nil {
+		return 0, domain.ErrUnauthorized
+	}
+
+	member, role, err := s.getMember(orgPkID, user.PkID)
+	if err != nil {
+		if err == domain.ErrOrgMemberNotFound {
+			return 0, domain.ErrPermissionDenied
+		}
+		return 0, err
+	}
+	if role != domain.Owner && member.ActivatedAt == "" {
+		return 0, domain.ErrPermissionDenied
+	}
+	return role, nil
+}
+
+func (s *Service) getMember(orgPkID int64, userPkID int64) (*domain.OrganizationMember, domain.OrganizationMemberRole, *domain.Error) {
+	member, err := s.orgRepository.GetOrgMemberByUserPkID(context.Background(), orgPkID, userPkID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	role, ok := domain.OrganizationMemberRoleFromString(member.Role)
+	if !ok {
+		return nil, 0, domain.ErrInvalidOrgMemberRole
+	}
+	return member, role, nil
+}
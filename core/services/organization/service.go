@@ -3,14 +3,15 @@ package organization
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/Stuhub-io/config"
 	"github.com/Stuhub-io/core/domain"
 	"github.com/Stuhub-io/core/ports"
 	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/outboxutils"
 	"github.com/Stuhub-io/utils/userutils"
+	"github.com/google/uuid"
 )
 
 type Service struct {
@@ -22,6 +23,7 @@ type Service struct {
 	mailer                       ports.Mailer
 	remoteRoute                  ports.RemoteRoute
 	organizationInviteRepository ports.OrganizationInviteRepository
+	pageRepository               ports.PageRepository
 }
 
 type NewServiceParams struct {
@@ -33,6 +35,7 @@ type NewServiceParams struct {
 	ports.Mailer
 	ports.RemoteRoute
 	ports.OrganizationInviteRepository
+	ports.PageRepository
 }
 
 func NewService(params NewServiceParams) *Service {
@@ -45,6 +48,7 @@ func NewService(params NewServiceParams) *Service {
 		mailer:                       params.Mailer,
 		remoteRoute:                  params.RemoteRoute,
 		organizationInviteRepository: params.OrganizationInviteRepository,
+		pageRepository:               params.PageRepository,
 	}
 }
 
@@ -63,6 +67,11 @@ func (s *Service) CreateOrganization(dto CreateOrganizationDto) (*CreateOrganiza
 		return nil, err
 	}
 
+	// The organization is usable without them, a failed seed is not a failed creation
+	if err := s.seedBuiltInTemplates(org); err != nil {
+		fmt.Printf("Failed to seed built-in templates of organization %d: %s\n", org.PkId, err.Message)
+	}
+
 	return &CreateOrganizationResponse{
 		Org: org,
 	}, nil
@@ -95,85 +104,70 @@ func (s *Service) GetInviteDetails(inviteID string) (*domain.OrganizationInvite,
 }
 
 func (s *Service) InviteMemberByEmails(dto InviteMemberByEmailsDto) (*InviteMemberByEmailsResponse, *domain.Error) {
-	org, err := s.orgRepository.GetOwnerOrgByPkID(context.Background(), dto.Owner.PkID, dto.OrgInfo.PkID)
+	inviterRole, err := s.getMemberRole(dto.OrgInfo.PkID, dto.Inviter)
 	if err != nil {
 		return nil, err
 	}
 
-	if dto.Owner.PkID != org.OwnerID {
-		return nil, domain.ErrUnauthorized
+	// Invitees get at most the roles the inviter manages
+	for _, info := range dto.InviteInfos {
+		role, ok := domain.OrganizationMemberRoleFromString(info.Role)
+		if !ok || role == domain.Owner {
+			return nil, domain.ErrInvalidOrgMemberRole
+		}
+		if !inviterRole.CanManage(role) {
+			return nil, domain.ErrPermissionDenied
+		}
 	}
 
-	ownerFullName := userutils.GetUserFullName(dto.Owner.FirstName, dto.Owner.LastName)
-
-	var sentEmails []string
-	var failedEmails []string
+	ownerFullName := userutils.GetUserFullName(dto.Inviter.FirstName, dto.Inviter.LastName)
+	fromName := fmt.Sprintf("%s via Stuhub", ownerFullName)
 
-	var wg sync.WaitGroup
+	sentEmails := []string{}
+	failedEmails := []string{}
 
 	for _, info := range dto.InviteInfos {
-		wg.Add(1)
-		go func(info EmailInviteInfo) {
-			defer wg.Done()
-
-			existingMember, _ := s.orgRepository.GetOrgMemberByEmail(context.Background(), dto.OrgInfo.PkID, info.Email)
-			if existingMember != nil && existingMember.ActivatedAt != "" {
-				return
-			}
-
-			var memberUserPkID int64
-			memberUser, err := s.userRepository.GetUserByEmail(context.Background(), info.Email)
-			if err != nil && err.Error == domain.NotFoundErr {
-				salt := s.hasher.GenerateSalt()
-				newUser, err, _ := s.userRepository.GetOrCreateUserByEmail(context.Background(), info.Email, salt)
-				if err != nil {
-					fmt.Printf("Failed to create new user: %s", info.Email)
-					return
-				}
-
-				memberUserPkID = newUser.PkID
-			}
-
-			if memberUser != nil {
-				memberUserPkID = memberUser.PkID
-			}
-
-			_, err = s.orgRepository.AddMemberToOrg(context.Background(), dto.OrgInfo.PkID, &memberUserPkID, info.Role)
-			if err != nil {
-				fmt.Printf("Err add member to org: %s", info.Email)
-				return
-			}
-
-			invite, err := s.organizationInviteRepository.CreateInvite(context.Background(), dto.OrgInfo.PkID, memberUserPkID)
-			if err != nil {
-				fmt.Printf("Err to create org invite: %s", info.Email)
-				return
-			}
-
-			fromName := fmt.Sprintf("%s via Stuhub", ownerFullName)
-			err = s.mailer.SendMail(ports.SendSendGridMailPayload{
-				FromName:   &fromName,
-				ToName:     "",
+		existingMember, _ := s.orgRepository.GetOrgMemberByEmail(context.Background(), dto.OrgInfo.PkID, info.Email)
+		if existingMember != nil && existingMember.ActivatedAt != "" {
+			continue
+		}
+
+		salt := s.hasher.GenerateSalt()
+		memberUser, err, _ := s.userRepository.GetOrCreateUserByEmail(context.Background(), info.Email, salt)
+		if err != nil {
+			failedEmails = append(failedEmails, info.Email)
+			continue
+		}
+
+		// The mail is staged with the member and the invite, it is delivered by the outbox once they commit
+		inviteID := uuid.NewString()
+		ctx := outboxutils.WithEvents(context.Background(), func(pages []domain.Page) []domain.OutboxEventInput {
+			return []domain.OutboxEventInput{outboxutils.MailEvent(domain.OutboxMailPayload{
+				FromName:   fromName,
 				ToAddress:  info.Email,
-				TemplateId: s.cfg.SendgridOrgInvitationTemplateId,
+				TemplateID: s.cfg.SendgridOrgInvitationTemplateId,
 				Data: map[string]string{
-					"url":        s.MakeValidateInvitationURL(invite.ID),
+					"url":        s.MakeValidateInvitationURL(inviteID),
 					"owner_name": ownerFullName,
 					"org_name":   dto.OrgInfo.Name,
 					"org_avatar": dto.OrgInfo.Avatar,
 				},
 				Subject: domain.InviteToOrgSubject,
-			})
-			if err != nil {
-				failedEmails = append(failedEmails, info.Email)
-				fmt.Printf("Err sending invitation for email: %s", info.Email)
-				return
-			}
+			})}
+		})
+
+		if err := s.orgRepository.InviteMemberToOrg(ctx, domain.OrganizationMemberInviteInput{
+			OrgPkID:  dto.OrgInfo.PkID,
+			UserPkID: memberUser.PkID,
+			Role:     info.Role,
+			InviteID: inviteID,
+		}); err != nil {
+			failedEmails = append(failedEmails, info.Email)
+			continue
+		}
 
-			sentEmails = append(sentEmails, info.Email)
-		}(info)
+		sentEmails = append(sentEmails, info.Email)
 	}
-	wg.Wait()
 
 	return &InviteMemberByEmailsResponse{
 		SentEmails:   sentEmails,
@@ -0,0 +1,126 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+const builtInTemplatesFolderName = "Templates"
+
+type builtInTemplate struct {
+	Name    string
+	Content documentutils.Node
+}
+
+var builtInTemplates = []builtInTemplate{
+	{
+		Name: "Meeting Notes {{date}}",
+		Content: templateDoc(
+			templateHeading(1, "Meeting Notes"),
+			templateParagraph("Date: {{date}} · Note taker: {{author}}"),
+			templateHeading(2, "Attendees"),
+			templateBulletList(""),
+			templateHeading(2, "Agenda"),
+			templateBulletList(""),
+			templateHeading(2, "Action Items"),
+			templateBulletList(""),
+		),
+	},
+	{
+		Name: "Project Brief",
+		Content: templateDoc(
+			templateHeading(1, "Project Brief"),
+			templateParagraph("Owner: {{author}} · Created: {{date}}"),
+			templateHeading(2, "Goal"),
+			templateParagraph(""),
+			templateHeading(2, "Scope"),
+			templateBulletList("In scope:", "Out of scope:"),
+			templateHeading(2, "Milestones"),
+			templateBulletList(""),
+		),
+	},
+	{
+		Name: "Weekly Report {{date}}",
+		Content: templateDoc(
+			templateHeading(1, "Weekly Report"),
+			templateParagraph("Week of {{date}} · {{author}}"),
+			templateHeading(2, "Done"),
+			templateBulletList(""),
+			templateHeading(2, "Next"),
+			templateBulletList(""),
+			templateHeading(2, "Blockers"),
+			templateBulletList(""),
+		),
+	},
+}
+
+// seedBuiltInTemplates creates the built-in templates of a new organization, under a templates folder.
+func (s *Service) seedBuiltInTemplates(org *domain.Organization) *domain.Error {
+	folder, err := s.pageRepository.CreateDocumentPage(context.Background(), domain.DocumentPageInput{
+		PageInput: domain.PageInput{
+			Name:             builtInTemplatesFolderName,
+			ViewType:         domain.PageViewTypeFolder,
+			AuthorPkID:       org.OwnerID,
+			OrganizationPkID: org.PkId,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, template := range builtInTemplates {
+		if _, err := s.pageRepository.CreateDocumentPage(context.Background(), domain.DocumentPageInput{
+			PageInput: domain.PageInput{
+				Name:             template.Name,
+				ParentPagePkID:   &folder.PkID,
+				ViewType:         domain.PageViewTypeDoc,
+				AuthorPkID:       org.OwnerID,
+				OrganizationPkID: org.PkId,
+				IsTemplate:       true,
+			},
+			Document: domain.DocumentInput{
+				JsonContent: template.Content.String(),
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func templateDoc(blocks ...documentutils.Node) documentutils.Node {
+	return documentutils.Node{Type: documentutils.NodeDoc, Content: blocks}
+}
+
+func templateText(text string) []documentutils.Node {
+	if text == "" {
+		return nil
+	}
+	return []documentutils.Node{{Type: documentutils.NodeText, Text: text}}
+}
+
+func templateHeading(level int, text string) documentutils.Node {
+	return documentutils.Node{
+		Type:    "heading",
+		Attrs:   map[string]any{"level": level},
+		Content: templateText(text),
+	}
+}
+
+func templateParagraph(text string) documentutils.Node {
+	return documentutils.Node{Type: "paragraph", Content: templateText(text)}
+}
+
+func templateBulletList(items ...string) documentutils.Node {
+	list := documentutils.Node{Type: "bulletList"}
+	for _, item := range items {
+		list.Content = append(list.Content, documentutils.Node{
+			Type:    "listItem",
+			Content: []documentutils.Node{templateParagraph(item)},
+		})
+	}
+	return list
+}
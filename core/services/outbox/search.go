@@ -0,0 +1,79 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+// handleSearch reads the pages at delivery time, a retried event indexes the latest state of the pages
+// and pages purged since are not indexed again.
+func (s *Service) handleSearch(ctx context.Context, payload domain.OutboxSearchPayload) error {
+	pages := []domain.Page{}
+
+	if len(payload.PagePkIDs) > 0 {
+		listed, err := s.pageRepository.ListWithBody(ctx, domain.PageListQuery{
+			PagePkIDs: payload.PagePkIDs,
+			IsAll:     true,
+		})
+		if err != nil {
+			return errors.New(err.Message)
+		}
+		pages = append(pages, listed...)
+	}
+
+	for _, pagePkID := range payload.TreePagePkIDs {
+		tree, err := s.listPageTree(ctx, pagePkID)
+		if err != nil {
+			return errors.New(err.Message)
+		}
+		pages = append(pages, tree...)
+	}
+
+	if len(pages) > 0 {
+		if err := s.pageSearcher.IndexPages(ctx, sliceutils.Map(pages, pageutils.TransformPageToSearchDocument)); err != nil {
+			return errors.New(err.Message)
+		}
+	}
+
+	if len(payload.DeletedPagePkIDs) > 0 {
+		if err := s.pageSearcher.DeletePages(ctx, payload.DeletedPagePkIDs); err != nil {
+			return errors.New(err.Message)
+		}
+	}
+
+	return nil
+}
+
+// listPageTree returns the page and all its descendants, archived ones included.
+func (s *Service) listPageTree(ctx context.Context, pagePkID int64) ([]domain.Page, *domain.Error) {
+	root, err := s.pageRepository.ListWithBody(ctx, domain.PageListQuery{
+		PagePkIDs: []int64{pagePkID},
+		IsAll:     true,
+	})
+	if err != nil || len(root) == 0 {
+		return root, err
+	}
+
+	descendantPath := pageutils.AppendPath(root[0].Path, strconv.FormatInt(pagePkID, 10))
+	pages, err := s.pageRepository.ListWithBody(ctx, domain.PageListQuery{
+		OrgPkID:       &root[0].OrganizationPkID,
+		PathBeginWith: descendantPath,
+		IsAll:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// PathBeginWith is a plain prefix match, "1/2" also matches "1/23"
+	pages = sliceutils.Filter(pages, func(p domain.Page) bool {
+		return p.Path == descendantPath || strings.HasPrefix(p.Path, descendantPath+"/")
+	})
+
+	return append(root, pages...), nil
+}
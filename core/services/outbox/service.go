@@ -0,0 +1,200 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/ports"
+	"github.com/Stuhub-io/logger"
+)
+
+const (
+	claimBatchSize = 100
+	// Events claimed by a dispatcher that died are claimed again once the lease ends,
+	// delivery is at least once so handlers must be idempotent.
+	claimLease = 5 * time.Minute
+	maxBackoff = time.Hour
+	// Delivered events are kept a while to inspect recent deliveries.
+	deliveredRetention = 7 * 24 * time.Hour
+)
+
+// Service delivers the outbox events written along with mutations to the activity log, search index and mailer.
+type Service struct {
+	cfg                config.Config
+	logger             logger.Logger
+	outboxRepository   ports.OutboxRepository
+	activityRepository ports.ActivityRepository
+	pageRepository     ports.PageRepository
+	pageSearcher       ports.PageSearcher
+	mailer             ports.Mailer
+}
+
+type NewServiceParams struct {
+	config.Config
+	logger.Logger
+	ports.OutboxRepository
+	ports.ActivityRepository
+	ports.PageRepository
+	ports.PageSearcher
+	ports.Mailer
+}
+
+func NewService(params NewServiceParams) *Service {
+	return &Service{
+		cfg:                params.Config,
+		logger:             params.Logger,
+		outboxRepository:   params.OutboxRepository,
+		activityRepository: params.ActivityRepository,
+		pageRepository:     params.PageRepository,
+		pageSearcher:       params.PageSearcher,
+		mailer:             params.Mailer,
+	}
+}
+
+// Run dispatches due events periodically until ctx is done.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.GetOutboxPollInterval())
+	defer ticker.Stop()
+	cleanupTicker := time.NewTicker(time.Hour)
+	defer cleanupTicker.Stop()
+
+	for {
+		s.Dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-cleanupTicker.C:
+			s.cleanup(ctx)
+		}
+	}
+}
+
+// Dispatch delivers the due events until none is left.
+func (s *Service) Dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		events, err := s.outboxRepository.Claim(ctx, claimBatchSize, claimLease)
+		if err != nil {
+			s.logger.Error(errors.New(err.Message), "[Outbox]: Failed to claim events")
+			return
+		}
+
+		for _, event := range events {
+			s.deliver(ctx, event)
+		}
+
+		if len(events) < claimBatchSize {
+			return
+		}
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, event domain.OutboxEvent) {
+	hErr := s.handle(ctx, event)
+	if hErr == nil {
+		if err := s.outboxRepository.MarkDelivered(ctx, event.PkID); err != nil {
+			s.logger.Errorf(errors.New(err.Message), "[Outbox]: Failed to mark event %d delivered", event.PkID)
+		}
+		return
+	}
+
+	// Attempts are counted when claimed, nil moves the event to the dead letters
+	var nextAttemptAt *time.Time
+	if event.Attempts < s.cfg.GetOutboxMaxAttempts() {
+		next := time.Now().Add(backoff(event.Attempts))
+		nextAttemptAt = &next
+		s.logger.Errorf(hErr, "[Outbox]: Failed to deliver %s event %d, attempt %d", event.Topic, event.PkID, event.Attempts)
+	} else {
+		s.logger.Errorf(hErr, "[Outbox]: Dead lettered %s event %d after %d attempts", event.Topic, event.PkID, event.Attempts)
+	}
+
+	if err := s.outboxRepository.MarkFailed(ctx, event.PkID, hErr.Error(), nextAttemptAt); err != nil {
+		s.logger.Errorf(errors.New(err.Message), "[Outbox]: Failed to mark event %d failed", event.PkID)
+	}
+}
+
+func (s *Service) handle(ctx context.Context, event domain.OutboxEvent) error {
+	switch event.Topic {
+	case domain.OutboxTopicActivity:
+		var input domain.ActivityInput
+		if err := json.Unmarshal(event.Payload, &input); err != nil {
+			return err
+		}
+		if _, err := s.activityRepository.Create(ctx, input); err != nil {
+			return errors.New(err.Message)
+		}
+		return nil
+
+	case domain.OutboxTopicSearch:
+		var payload domain.OutboxSearchPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		return s.handleSearch(ctx, payload)
+
+	case domain.OutboxTopicMail:
+		var payload domain.OutboxMailPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		return s.sendMail(payload)
+
+	default:
+		return fmt.Errorf("unknown outbox topic %q", event.Topic)
+	}
+}
+
+func (s *Service) sendMail(payload domain.OutboxMailPayload) error {
+	var fromName *string
+	if payload.FromName != "" {
+		fromName = &payload.FromName
+	}
+
+	var err *domain.Error
+	if payload.TemplateID != "" {
+		err = s.mailer.SendMail(ports.SendSendGridMailPayload{
+			FromName:   fromName,
+			ToName:     payload.ToName,
+			ToAddress:  payload.ToAddress,
+			TemplateId: payload.TemplateID,
+			Data:       payload.Data,
+			Subject:    payload.Subject,
+		})
+	} else {
+		err = s.mailer.SendMailCustomTemplate(ports.SendSendGridMailCustomTemplatePayload{
+			FromName:         fromName,
+			ToName:           payload.ToName,
+			ToAddress:        payload.ToAddress,
+			TemplateHTMLName: payload.TemplateHTMLName,
+			Data:             payload.Data,
+			Subject:          payload.Subject,
+		})
+	}
+	if err != nil {
+		return errors.New(err.Message)
+	}
+	return nil
+}
+
+func (s *Service) cleanup(ctx context.Context) {
+	count, err := s.outboxRepository.DeleteDeliveredBefore(ctx, time.Now().Add(-deliveredRetention))
+	if err != nil {
+		s.logger.Error(errors.New(err.Message), "[Outbox]: Failed to delete delivered events")
+		return
+	}
+
+	if count > 0 {
+		s.logger.Infof("[Outbox]: Deleted %d delivered events", count)
+	}
+}
+
+// backoff doubles the delay after each attempt, 2s, 4s, 8s... up to maxBackoff.
+func backoff(attempts int) time.Duration {
+	return min(time.Second<<min(attempts, 12), maxBackoff)
+}
@@ -0,0 +1,252 @@
+package page
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Stuhub-io/core/domain"
+	commonutils "github.com/Stuhub-io/utils"
+	"github.com/Stuhub-io/utils/activityutils"
+	"github.com/Stuhub-io/utils/outboxutils"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+// Upper bound of pages per bulk request.
+const maxBulkPages = 200
+
+// BulkUpdatePages applies the action to the pages the user is allowed to, returns a result per page.
+func (s *Service) BulkUpdatePages(
+	input domain.PageBulkInput,
+	curUser *domain.User,
+) ([]domain.PageBulkResult, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	// Keep the request order, results are returned in the same order
+	pagePkIDs := make([]int64, 0, len(input.PagePkIDs))
+	for _, pagePkID := range input.PagePkIDs {
+		if !slices.Contains(pagePkIDs, pagePkID) {
+			pagePkIDs = append(pagePkIDs, pagePkID)
+		}
+	}
+	if len(pagePkIDs) == 0 {
+		return []domain.PageBulkResult{}, nil
+	}
+	if len(pagePkIDs) > maxBulkPages {
+		return nil, domain.NewErr("Too many pages in a single bulk request", domain.BadRequestCode)
+	}
+
+	pages, permissions, err := s.getPagesPermissions(pagePkIDs, curUser)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentPage *domain.Page
+	if input.Action == domain.PageBulkMove && input.ParentPagePkID != nil {
+		parentPage, err = s.pageRepository.GetByID(
+			context.Background(),
+			"",
+			input.ParentPagePkID,
+			domain.PageDetailOptions{},
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		parentRole := s.GetPageRolesByUser(context.Background(), parentPage.PkID, curUser)
+		parentPermissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+			Page:     *parentPage,
+			User:     curUser,
+			PageRole: parentRole,
+		})
+		if !parentPermissions.CanEdit {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+
+	results := make(map[int64]domain.PageBulkResult, len(pagePkIDs))
+	allowedPkIDs := make([]int64, 0, len(pagePkIDs))
+	for _, pagePkID := range pagePkIDs {
+		permission, ok := permissions[pagePkID]
+		if !ok {
+			results[pagePkID] = domain.PageBulkResult{PagePkID: pagePkID, Error: domain.ErrNotFound}
+			continue
+		}
+		if !isBulkActionAllowed(input.Action, permission) {
+			results[pagePkID] = domain.PageBulkResult{PagePkID: pagePkID, Error: domain.ErrPermissionDenied}
+			continue
+		}
+		allowedPkIDs = append(allowedPkIDs, pagePkID)
+	}
+
+	input.PagePkIDs = allowedPkIDs
+	input.ActorPkID = curUser.PkID
+	ctx := context.Background()
+	// Move, archive and restore change the path or archived state of the whole subtree
+	if input.Action == domain.PageBulkMove || input.Action == domain.PageBulkArchive || input.Action == domain.PageBulkRestore {
+		ctx = outboxutils.WithEvents(ctx, outboxutils.IndexPageTrees)
+	}
+	ctx = outboxutils.WithEvents(ctx, bulkActivities(input.Action, pages, parentPage, curUser))
+
+	updated, err := s.pageRepository.BulkUpdate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range updated {
+		results[result.PagePkID] = result
+	}
+
+	return sliceutils.Map(pagePkIDs, func(pagePkID int64) domain.PageBulkResult {
+		return results[pagePkID]
+	}), nil
+}
+
+func isBulkActionAllowed(action domain.PageBulkAction, permission domain.PageRolePermissions) bool {
+	switch action {
+	case domain.PageBulkMove:
+		return permission.CanMove
+	case domain.PageBulkArchive, domain.PageBulkRestore:
+		return permission.CanDelete
+	case domain.PageBulkStar, domain.PageBulkUnstar:
+		return permission.CanView
+	case domain.PageBulkGeneralAccess:
+		return permission.CanShare
+	}
+	return false
+}
+
+// getPagesPermissions resolves the user's permissions of many pages at once,
+// inherit roles resolve to the closest ancestor role like GetPageRoleByEmail does.
+func (s *Service) getPagesPermissions(
+	pagePkIDs []int64,
+	curUser *domain.User,
+) (map[int64]domain.Page, map[int64]domain.PageRolePermissions, *domain.Error) {
+	pages, err := s.pageRepository.List(context.Background(), domain.PageListQuery{
+		PagePkIDs: pagePkIDs,
+		IsAll:     true,
+	}, curUser)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rolePages := []domain.Page{}
+	for _, page := range pages {
+		rolePages = append(rolePages, page)
+		for _, ancestorPkID := range pageutils.PagePathToPkIDs(page.Path) {
+			rolePages = append(rolePages, domain.Page{PkID: ancestorPkID})
+		}
+	}
+	rolePages = sliceutils.UniqueByField(rolePages, "PkID")
+
+	roleInputs, err := s.pageRepository.GetPagesRole(context.Background(), domain.PageRolePermissionBatchCheckInput{
+		User:  curUser,
+		Pages: rolePages,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	directRoles := make(map[int64]domain.PageRole, len(roleInputs))
+	sharedRoles := make(map[int64]*domain.PageRole, len(roleInputs))
+	for _, input := range roleInputs {
+		if input.PageRole != nil {
+			directRoles[input.Page.PkID] = *input.PageRole
+		}
+		sharedRoles[input.Page.PkID] = input.SharedRole
+	}
+
+	pagesByPkID := make(map[int64]domain.Page, len(pages))
+	permissions := make(map[int64]domain.PageRolePermissions, len(pages))
+	for _, page := range pages {
+		var pageRole *domain.PageRole
+		if role, ok := directRoles[page.PkID]; ok {
+			pageRole = &role
+		}
+
+		if pageRole != nil && *pageRole == domain.PageInherit {
+			// Default role if no ancestor role is found
+			baseRole := domain.PageViewer
+			ancestorPkIDs := pageutils.PagePathToPkIDs(page.Path)
+			slices.Reverse(ancestorPkIDs)
+			for _, ancestorPkID := range ancestorPkIDs {
+				if role, ok := directRoles[ancestorPkID]; ok && role != domain.PageInherit {
+					baseRole = role
+					break
+				}
+			}
+			pageRole = &baseRole
+		}
+
+		pagesByPkID[page.PkID] = page
+		permissions[page.PkID] = s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+			Page:       page,
+			User:       curUser,
+			PageRole:   pageRole,
+			SharedRole: sharedRoles[page.PkID],
+		})
+	}
+
+	return pagesByPkID, permissions, nil
+}
+
+// bulkActivities logs an activity per page updated by the bulk action.
+func bulkActivities(
+	action domain.PageBulkAction,
+	pages map[int64]domain.Page,
+	parentPage *domain.Page,
+	curUser *domain.User,
+) outboxutils.EventsBuilder {
+	return func(updatedPages []domain.Page) []domain.OutboxEventInput {
+		var actionCode domain.ActionCode
+		switch action {
+		case domain.PageBulkMove:
+			actionCode = domain.ActionUserMovePage
+		case domain.PageBulkArchive:
+			actionCode = domain.ActionUserRemovePage
+		case domain.PageBulkRestore:
+			actionCode = domain.ActionUserRestorePage
+		default:
+			return nil
+		}
+
+		events := make([]domain.OutboxEventInput, 0, len(updatedPages))
+		for _, newPage := range updatedPages {
+			page := pages[newPage.PkID]
+
+			var meta any
+			switch action {
+			case domain.PageBulkMove:
+				var newParentPageName *string
+				if parentPage != nil {
+					newParentPageName = &parentPage.Name
+				}
+				meta = activityutils.UserMovePageMeta{
+					OldParentPagePkID: page.ParentPagePkID,
+					NewParentPagePkID: newPage.ParentPagePkID,
+					NewParentPageName: newParentPageName,
+				}
+			case domain.PageBulkArchive:
+				meta = activityutils.UserRemovePageMeta{
+					OldParentPagePkID: page.ParentPagePkID,
+				}
+			case domain.PageBulkRestore:
+				meta = activityutils.UserRestorePageMeta{
+					ParentPagePkID: newPage.ParentPagePkID,
+				}
+			}
+			metadata := commonutils.ToJsonStr(meta)
+
+			events = append(events, outboxutils.ActivityEvent(domain.ActivityInput{
+				ActionCode: actionCode,
+				PagePkID:   &page.PkID,
+				OrgPkID:    &page.OrganizationPkID,
+				ActorPkID:  curUser.PkID,
+				MetaData:   &metadata,
+			}))
+		}
+		return events
+	}
+}
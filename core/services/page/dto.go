@@ -0,0 +1,14 @@
+package page
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+// DocumentRevisionDiffResponse compares From with To, a nil To means the live document.
+type DocumentRevisionDiffResponse struct {
+	From    *domain.DocumentRevision    `json:"from"`
+	To      *domain.DocumentRevision    `json:"to"`
+	Changes []documentutils.BlockChange `json:"changes"`
+	Stats   documentutils.DiffStats     `json:"stats"`
+}
@@ -0,0 +1,143 @@
+package page
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+	commonutils "github.com/Stuhub-io/utils"
+	"github.com/Stuhub-io/utils/activityutils"
+	"github.com/Stuhub-io/utils/outboxutils"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+// DuplicatePage copies the page, and its descendants the user can view when includeDescendants is set,
+// into the given parent, the source's parent by default.
+func (s *Service) DuplicatePage(
+	pagePkID int64,
+	input domain.PageDuplicateInput,
+	includeDescendants bool,
+	curUser *domain.User,
+) (*domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	return s.duplicatePage(page, input, includeDescendants, curUser, func(parentPage *domain.Page) outboxutils.EventsBuilder {
+		return func(pages []domain.Page) []domain.OutboxEventInput {
+			newPage := pages[0]
+			var parentPageName *string
+			if parentPage != nil {
+				parentPageName = &parentPage.Name
+			}
+			metadata := commonutils.ToJsonStr(activityutils.UserDuplicatePageMeta{
+				SourcePagePkID: page.PkID,
+				SourcePageName: page.Name,
+				ParentPagePkID: newPage.ParentPagePkID,
+				ParentPageName: parentPageName,
+			})
+
+			return []domain.OutboxEventInput{outboxutils.ActivityEvent(domain.ActivityInput{
+				ActionCode: domain.ActionUserDuplicatePage,
+				PagePkID:   &newPage.PkID,
+				OrgPkID:    &newPage.OrganizationPkID,
+				ActorPkID:  curUser.PkID,
+				MetaData:   &metadata,
+			})}
+		}
+	})
+}
+
+// duplicatePage copies a page the user can view, activity builds the activity events of the copy
+// from the parent it is copied into.
+func (s *Service) duplicatePage(
+	page *domain.Page,
+	input domain.PageDuplicateInput,
+	includeDescendants bool,
+	curUser *domain.User,
+	activity func(parentPage *domain.Page) outboxutils.EventsBuilder,
+) (*domain.Page, *domain.Error) {
+	var err *domain.Error
+
+	if input.ParentPagePkID == nil {
+		input.ParentPagePkID = page.ParentPagePkID
+	}
+
+	var parentPage *domain.Page
+	if input.ParentPagePkID != nil {
+		parentPage, err = s.pageRepository.GetByID(
+			context.Background(),
+			"",
+			input.ParentPagePkID,
+			domain.PageDetailOptions{},
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if parentPage.OrganizationPkID != page.OrganizationPkID {
+			return nil, domain.NewErr("Parent page belongs to another organization", domain.BadRequestCode)
+		}
+
+		parentRole := s.GetPageRolesByUser(context.Background(), parentPage.PkID, curUser)
+		parentPermissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+			Page:     *parentPage,
+			User:     curUser,
+			PageRole: parentRole,
+		})
+		if !parentPermissions.CanEdit {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+
+	input.ActorPkID = curUser.PkID
+	input.DescendantPkIDs = nil
+	if includeDescendants {
+		descendantPath := pageutils.AppendPath(page.Path, strconv.FormatInt(page.PkID, 10))
+		isArchived := false
+		descendants, err := s.pageRepository.List(context.Background(), domain.PageListQuery{
+			OrgPkID:       &page.OrganizationPkID,
+			IsArchived:    &isArchived,
+			PathBeginWith: descendantPath,
+			IsAll:         true,
+		}, curUser)
+		if err != nil {
+			return nil, err
+		}
+
+		// PathBeginWith is a plain prefix match, "1/2" also matches "1/23"
+		descendants = sliceutils.Filter(descendants, func(p domain.Page) bool {
+			return p.Path == descendantPath || strings.HasPrefix(p.Path, descendantPath+"/")
+		})
+		input.DescendantPkIDs = sliceutils.Map(descendants, func(p domain.Page) int64 { return p.PkID })
+	}
+
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.IndexPageTrees)
+	ctx = outboxutils.WithEvents(ctx, activity(parentPage))
+
+	return s.pageRepository.DuplicatePage(ctx, page.PkID, input)
+}
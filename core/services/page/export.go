@@ -0,0 +1,175 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+const (
+	// Exports are built in the background, the lease is the time a worker has to build one.
+	pageExportLease        = 10 * time.Minute
+	pageExportPollInterval = 5 * time.Second
+	pageExportRetention    = 24 * time.Hour
+	maxPageExportAttempts  = 3
+)
+
+// RequestPageExport queues an export of the page and its descendants in the format, md or html.
+func (s *Service) RequestPageExport(
+	pagePkID int64,
+	format domain.DocumentFormat,
+	curUser *domain.User,
+) (*domain.PageExport, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanDownload {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	if format == "" {
+		format = domain.DocumentFormatMarkdown
+	}
+
+	return s.pageExportRepository.Create(context.Background(), domain.PageExportInput{
+		PagePkID:      page.PkID,
+		RequesterPkID: curUser.PkID,
+		Format:        format,
+		ExpiresAt:     time.Now().Add(pageExportRetention),
+	})
+}
+
+// GetPageExport returns the export, only to the user who requested it.
+func (s *Service) GetPageExport(exportID string, curUser *domain.User) (*domain.PageExport, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	export, err := s.pageExportRepository.GetByID(context.Background(), exportID)
+	if err != nil {
+		return nil, err
+	}
+	if export.RequesterPkID != curUser.PkID {
+		return nil, domain.ErrPageExportNotFound
+	}
+
+	return export, nil
+}
+
+func (s *Service) DownloadPageExport(exportID string, curUser *domain.User) (*domain.PageExportArchive, *domain.Error) {
+	export, err := s.GetPageExport(exportID, curUser)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != domain.PageExportDone {
+		return nil, domain.ErrPageExportNotReady
+	}
+
+	content, err := s.pageExportRepository.GetArchive(context.Background(), exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PageExportArchive{
+		FileName:  export.FileName,
+		Content:   content,
+		PageCount: export.PageCount,
+	}, nil
+}
+
+// RunPageExports builds the queued exports and deletes the expired ones until ctx is done.
+func (s *Service) RunPageExports(ctx context.Context) {
+	ticker := time.NewTicker(pageExportPollInterval)
+	defer ticker.Stop()
+
+	cleanupTicker := time.NewTicker(time.Hour)
+	defer cleanupTicker.Stop()
+
+	for {
+		s.processPageExports(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-cleanupTicker.C:
+			count, err := s.pageExportRepository.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				s.logger.Error(errors.New(err.Message), "[Export]: Failed to delete expired exports")
+			} else if count > 0 {
+				s.logger.Infof("[Export]: Deleted %d expired exports", count)
+			}
+		}
+	}
+}
+
+// processPageExports builds the queued exports one at a time until none is left.
+func (s *Service) processPageExports(ctx context.Context) {
+	for ctx.Err() == nil {
+		export, err := s.pageExportRepository.Claim(ctx, pageExportLease)
+		if err != nil {
+			s.logger.Error(errors.New(err.Message), "[Export]: Failed to claim an export")
+			return
+		}
+		if export == nil {
+			return
+		}
+
+		s.runPageExport(ctx, *export)
+	}
+}
+
+func (s *Service) runPageExport(ctx context.Context, export domain.PageExport) {
+	// Exports whose worker died repeatedly, e.g. out of memory, are not claimed forever
+	if export.Attempts > maxPageExportAttempts {
+		s.failPageExport(ctx, export, domain.ErrInternalServerError)
+		return
+	}
+
+	buildCtx, cancel := context.WithTimeout(ctx, pageExportLease)
+	defer cancel()
+
+	archive, err := s.buildPageExport(buildCtx, export)
+	if err != nil {
+		// Shutting down, the export is claimed again once its lease ends
+		if ctx.Err() != nil {
+			return
+		}
+		s.failPageExport(ctx, export, err)
+		return
+	}
+
+	if err := s.pageExportRepository.Complete(ctx, export.PkID, *archive); err != nil {
+		s.logger.Errorf(errors.New(err.Message), "[Export]: Failed to save export %s", export.ID)
+		return
+	}
+	s.logger.Infof("[Export]: Export %s done, %d pages", export.ID, archive.PageCount)
+}
+
+func (s *Service) failPageExport(ctx context.Context, export domain.PageExport, reason *domain.Error) {
+	s.logger.Errorf(errors.New(reason.Message), "[Export]: Export %s failed", export.ID)
+	if err := s.pageExportRepository.Fail(ctx, export.PkID, reason.Message); err != nil {
+		s.logger.Errorf(errors.New(err.Message), "[Export]: Failed to mark export %s as failed", export.ID)
+	}
+}
@@ -0,0 +1,368 @@
+package page
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/documentutils"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/Stuhub-io/utils/renderutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+const (
+	// Upper bound of the uncompressed content of an export, archives are built in memory and stored in
+	// the database.
+	maxPageExportSize      = 50 << 20
+	maxExportAssetSize     = 20 << 20
+	exportAssetTimeout     = 30 * time.Second
+	maxExportAssetRedirect = 5
+	maxExportNameRunes     = 100
+)
+
+var errExportAssetRedirect = errors.New("redirect to a host not allowed for export assets")
+
+var exportNameReplacer = strings.NewReplacer(
+	"/", "-", `\`, "-", ":", "-", "*", "-", "?", "-", `"`, "-", "<", "-", ">", "-", "|", "-",
+)
+
+type exportNode struct {
+	page     domain.Page
+	children []*exportNode
+}
+
+// buildPageExport zips the page and the descendants the requester can download, directories mirror the page tree.
+// Permissions are resolved when the export runs, roles may have changed since it was requested.
+func (s *Service) buildPageExport(ctx context.Context, export domain.PageExport) (*domain.PageExportArchive, *domain.Error) {
+	requester, err := s.userRepository.GetUserByPkID(ctx, export.RequesterPkID)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := s.listExportPages(ctx, export.PagePkID, requester)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := &exportWriter{
+		service: s,
+		ctx:     ctx,
+		format:  export.Format,
+		zip:     zip.NewWriter(&buf),
+		now:     time.Now(),
+	}
+
+	root := exportTree(pages)
+	if err := writer.writeNode(root, "", map[string]bool{}); err != nil {
+		return nil, err
+	}
+	if err := writer.zip.Close(); err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+
+	return &domain.PageExportArchive{
+		FileName:  exportName(root.page.Name) + ".zip",
+		Content:   buf.Bytes(),
+		PageCount: len(pages),
+	}, nil
+}
+
+// listExportPages returns the page followed by its non archived descendants the user can download, in position order.
+func (s *Service) listExportPages(ctx context.Context, pagePkID int64, user *domain.User) ([]domain.Page, *domain.Error) {
+	page, err := s.pageRepository.GetByID(ctx, "", &pagePkID, domain.PageDetailOptions{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	isArchived := false
+	roots, err := s.pageRepository.List(ctx, domain.PageListQuery{
+		OrgPkID:    &page.OrganizationPkID,
+		PagePkIDs:  []int64{page.PkID},
+		IsArchived: &isArchived,
+		IsAll:      true,
+	}, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) == 0 || roots[0].Permissions == nil || !roots[0].Permissions.CanDownload {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	descendantPath := pageutils.AppendPath(page.Path, strconv.FormatInt(page.PkID, 10))
+	descendants, err := s.pageRepository.List(ctx, domain.PageListQuery{
+		OrgPkID:       &page.OrganizationPkID,
+		PathBeginWith: descendantPath,
+		IsArchived:    &isArchived,
+		IsAll:         true,
+		OrderBy:       domain.PageOrderByPosition,
+	}, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// PathBeginWith is a plain prefix match, "1/2" also matches "1/23"
+	descendants = sliceutils.Filter(descendants, func(p domain.Page) bool {
+		return (p.Path == descendantPath || strings.HasPrefix(p.Path, descendantPath+"/")) &&
+			p.Permissions != nil && p.Permissions.CanDownload
+	})
+
+	return append(roots[:1], descendants...), nil
+}
+
+// exportTree nests the pages under their parent, pages whose parent is not exported
+// are attached to their closest exported ancestor.
+func exportTree(pages []domain.Page) *exportNode {
+	nodes := make(map[int64]*exportNode, len(pages))
+	for _, page := range pages {
+		nodes[page.PkID] = &exportNode{page: page}
+	}
+
+	root := nodes[pages[0].PkID]
+	for _, page := range pages[1:] {
+		parent := root
+		ancestorPkIDs := pageutils.PagePathToPkIDs(page.Path)
+		for i := len(ancestorPkIDs) - 1; i >= 0; i-- {
+			if ancestor, ok := nodes[ancestorPkIDs[i]]; ok {
+				parent = ancestor
+				break
+			}
+		}
+		parent.children = append(parent.children, nodes[page.PkID])
+	}
+
+	return root
+}
+
+type exportWriter struct {
+	service *Service
+	ctx     context.Context
+	format  domain.DocumentFormat
+	zip     *zip.Writer
+	now     time.Time
+	size    int64
+}
+
+// writeNode writes the page into dir, folders become directories, documents and assets files.
+// Children of documents and assets are written in a directory named after them, next to their file.
+func (w *exportWriter) writeNode(node *exportNode, dir string, usedNames map[string]bool) *domain.Error {
+	if w.ctx.Err() != nil {
+		return domain.ErrInternalServerError
+	}
+
+	page := node.page
+	name := exportName(page.Name)
+
+	switch {
+	case page.ViewType == domain.PageViewTypeFolder:
+		name = uniqueExportName(usedNames, name, "/")
+		if err := w.writeDir(dir + name + "/"); err != nil {
+			return err
+		}
+
+	case page.ViewType == domain.PageViewTypeAsset && page.Asset != nil:
+		extension := ""
+		if page.Asset.Extension != "" {
+			extension = "." + strings.TrimPrefix(page.Asset.Extension, ".")
+		}
+		if len(name) > len(extension) && strings.EqualFold(name[len(name)-len(extension):], extension) {
+			name = name[:len(name)-len(extension)]
+		}
+
+		if content, ok := w.service.fetchExportAsset(w.ctx, page.Asset.URL); ok {
+			name = uniqueExportName(usedNames, name, extension, "/")
+			if err := w.writeFile(dir+name+extension, content); err != nil {
+				return err
+			}
+			break
+		}
+
+		// Assets not fetched are exported as a document linking to them
+		linkExtension := extension + "." + string(w.format)
+		name = uniqueExportName(usedNames, name, linkExtension, "/")
+		if err := w.writeFile(dir+name+linkExtension, w.renderAssetLink(page)); err != nil {
+			return err
+		}
+
+	default:
+		extension := "." + string(w.format)
+		name = uniqueExportName(usedNames, name, extension, "/")
+		if err := w.writeFile(dir+name+extension, w.renderDocument(page)); err != nil {
+			return err
+		}
+	}
+
+	childNames := map[string]bool{}
+	for _, child := range node.children {
+		if err := w.writeNode(child, dir+name+"/", childNames); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *exportWriter) renderDocument(page domain.Page) []byte {
+	jsonContent := "{}"
+	if page.Document != nil {
+		jsonContent = page.Document.JsonContent
+	}
+
+	rendered, err := renderutils.RenderFile(page.Name, jsonContent, w.format)
+	if err != nil {
+		// Unparsable documents keep their title, the rest of the export is still useful
+		w.service.logger.Warnf("[Export]: Failed to render the document of page %d: %v", page.PkID, err)
+		rendered, _ = renderutils.RenderFile(page.Name, "{}", w.format)
+	}
+	return []byte(rendered)
+}
+
+func (w *exportWriter) renderAssetLink(page domain.Page) []byte {
+	doc := documentutils.Node{Type: documentutils.NodeDoc, Content: []documentutils.Node{{
+		Type: "paragraph",
+		Content: []documentutils.Node{{
+			Type:  documentutils.NodeText,
+			Text:  page.Asset.URL,
+			Marks: []documentutils.Mark{{Type: "link", Attrs: map[string]any{"href": page.Asset.URL}}},
+		}},
+	}}}
+
+	rendered, _ := renderutils.RenderFile(page.Name, doc.String(), w.format)
+	return []byte(rendered)
+}
+
+func (w *exportWriter) writeDir(name string) *domain.Error {
+	if _, err := w.zip.CreateHeader(&zip.FileHeader{Name: name, Modified: w.now}); err != nil {
+		return domain.ErrInternalServerError
+	}
+	return nil
+}
+
+func (w *exportWriter) writeFile(name string, content []byte) *domain.Error {
+	if w.size += int64(len(content)); w.size > maxPageExportSize {
+		return domain.ErrPageExportTooLarge
+	}
+
+	file, err := w.zip.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: w.now})
+	if err != nil {
+		return domain.ErrInternalServerError
+	}
+	if _, err := file.Write(content); err != nil {
+		return domain.ErrInternalServerError
+	}
+	return nil
+}
+
+// fetchExportAsset downloads the asset file, when fetching is enabled and the url is on an allowed host.
+// Asset urls are given by clients, other hosts are never requested, redirects included.
+func (s *Service) fetchExportAsset(ctx context.Context, rawURL string) ([]byte, bool) {
+	if !s.cfg.ExportFetchAssets {
+		return nil, false
+	}
+
+	assetURL, err := url.Parse(rawURL)
+	if err != nil || (assetURL.Scheme != "http" && assetURL.Scheme != "https") || !s.isExportAssetHost(assetURL.Hostname()) {
+		return nil, false
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, assetURL.String(), nil)
+	if err != nil {
+		return nil, false
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		s.logger.Warnf("[Export]: Failed to fetch asset %s: %v", rawURL, err)
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		s.logger.Warnf("[Export]: Failed to fetch asset %s: status %d", rawURL, resp.StatusCode)
+		return nil, false
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxExportAssetSize+1))
+	if err != nil || len(content) > maxExportAssetSize {
+		return nil, false
+	}
+	return content, true
+}
+
+// checkExportAssetRedirect only follows redirects to allowed hosts.
+func (s *Service) checkExportAssetRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxExportAssetRedirect {
+		return errExportAssetRedirect
+	}
+	if (req.URL.Scheme != "http" && req.URL.Scheme != "https") || !s.isExportAssetHost(req.URL.Hostname()) {
+		return errExportAssetRedirect
+	}
+	return nil
+}
+
+func (s *Service) isExportAssetHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range s.cfg.ExportAssetHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed != "" && (host == allowed || strings.HasSuffix(host, "."+allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// exportName turns the page name into a file name valid on common file systems.
+func exportName(name string) string {
+	name = exportNameReplacer.Replace(name)
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+
+	if runes := []rune(name); len(runes) > maxExportNameRunes {
+		name = string(runes[:maxExportNameRunes])
+	}
+
+	// Windows drops trailing dots and spaces, leading dots hide files
+	name = strings.Trim(name, ". ")
+	if name == "" {
+		return "Untitled"
+	}
+	return name
+}
+
+// uniqueExportName suffixes the name with a counter until none of the entries named with the suffixes exists,
+// names are compared case insensitively as on most desktop file systems.
+func uniqueExportName(usedNames map[string]bool, name string, suffixes ...string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		available := true
+		for _, suffix := range suffixes {
+			if usedNames[strings.ToLower(candidate+suffix)] {
+				available = false
+				break
+			}
+		}
+		if available {
+			break
+		}
+		candidate = fmt.Sprintf("%s (%d)", name, i)
+	}
+
+	for _, suffix := range suffixes {
+		usedNames[strings.ToLower(candidate+suffix)] = true
+	}
+	return candidate
+}
@@ -0,0 +1,41 @@
+package page
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Stuhub-io/config"
+)
+
+func TestCheckExportAssetRedirect(t *testing.T) {
+	s := &Service{cfg: config.Config{ExportAssetHosts: []string{"assets.example.com"}}}
+
+	tests := []struct {
+		name    string
+		url     string
+		hops    int
+		allowed bool
+	}{
+		{name: "allowed host", url: "https://assets.example.com/a.png", allowed: true},
+		{name: "subdomain of an allowed host", url: "https://cdn.assets.example.com/a.png", allowed: true},
+		{name: "other host", url: "http://169.254.169.254/latest/meta-data", allowed: false},
+		{name: "allowed name inside another host", url: "https://assets.example.com.evil.test/a.png", allowed: false},
+		{name: "other scheme", url: "ftp://assets.example.com/a.png", allowed: false},
+		{name: "too many redirects", url: "https://assets.example.com/a.png", hops: maxExportAssetRedirect, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			via := make([]*http.Request, max(tt.hops, 1))
+
+			err = s.checkExportAssetRedirect(req, via)
+			if allowed := err == nil; allowed != tt.allowed {
+				t.Fatalf("checkExportAssetRedirect(%s) error = %v, want allowed %v", tt.url, err, tt.allowed)
+			}
+		})
+	}
+}
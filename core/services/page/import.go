@@ -0,0 +1,174 @@
+package page
+
+import (
+	"context"
+	"path"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/importutils"
+)
+
+// ImportPages creates a document page per markdown or html file of the upload under the parent page,
+// the directories of zip archives become folder pages. Returns a result per file and directory, in path order.
+func (s *Service) ImportPages(
+	input domain.PageImportInput,
+	curUser *domain.User,
+) ([]domain.PageImportResult, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	// Root pages are created without a parent to check, the user must be a member writing to the organization
+	if err := s.checkImportOrg(input.OrgPkID, curUser); err != nil {
+		return nil, err
+	}
+
+	files, err := importutils.ReadFiles(input.File)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked upfront so a denied import fails as a whole, CreateDocumentPage checks it again per page
+	if input.ParentPagePkID != nil {
+		parentPage, err := s.pageRepository.GetByID(
+			context.Background(),
+			"",
+			input.ParentPagePkID,
+			domain.PageDetailOptions{},
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if parentPage.OrganizationPkID != input.OrgPkID {
+			return nil, domain.ErrNotFound
+		}
+
+		curRole := s.GetPageRolesByUser(context.Background(), parentPage.PkID, curUser)
+		permission := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+			Page:     *parentPage,
+			User:     curUser,
+			PageRole: curRole,
+		})
+		if !permission.CanEdit {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+
+	importer := &pageImporter{
+		service: s,
+		input:   input,
+		curUser: curUser,
+		folders: map[string]folderImport{},
+		results: []domain.PageImportResult{},
+	}
+	for _, file := range files {
+		importer.importFile(file)
+	}
+
+	return importer.results, nil
+}
+
+// checkImportOrg checks the user is an activated member of the organization other than a guest.
+func (s *Service) checkImportOrg(orgPkID int64, curUser *domain.User) *domain.Error {
+	member, err := s.orgRepository.GetOrgMemberByUserPkID(context.Background(), orgPkID, curUser.PkID)
+	if err != nil {
+		if err == domain.ErrOrgMemberNotFound {
+			return domain.ErrPermissionDenied
+		}
+		return err
+	}
+
+	role, ok := domain.OrganizationMemberRoleFromString(member.Role)
+	if !ok || role == domain.Guest || (role != domain.Owner && member.ActivatedAt == "") {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+type folderImport struct {
+	pagePkID *int64
+	err      *domain.Error
+}
+
+// pageImporter creates the folder of a directory once, before its first file.
+type pageImporter struct {
+	service *Service
+	input   domain.PageImportInput
+	curUser *domain.User
+	folders map[string]folderImport
+	results []domain.PageImportResult
+}
+
+func (i *pageImporter) importFile(file domain.PageImportFile) {
+	// Parsed first, directories holding unsupported files only do not get a folder
+	doc, err := importutils.Parse(file)
+	if err != nil {
+		i.results = append(i.results, domain.PageImportResult{Path: file.Path, Error: err})
+		return
+	}
+
+	parentPagePkID, err := i.folder(path.Dir(file.Path))
+	if err != nil {
+		i.results = append(i.results, domain.PageImportResult{Path: file.Path, Error: err})
+		return
+	}
+
+	page, err := i.service.CreateDocumentPage(domain.DocumentPageInput{
+		PageInput: domain.PageInput{
+			Name:             importutils.PageName(file.Path),
+			ParentPagePkID:   parentPagePkID,
+			ViewType:         domain.PageViewTypeDoc,
+			AuthorPkID:       i.curUser.PkID,
+			OrganizationPkID: i.input.OrgPkID,
+		},
+		Document: domain.DocumentInput{
+			JsonContent: doc.String(),
+		},
+	}, i.curUser)
+	if err != nil {
+		i.results = append(i.results, domain.PageImportResult{Path: file.Path, Error: err})
+		return
+	}
+
+	i.results = append(i.results, domain.PageImportResult{Path: file.Path, Success: true, Page: page})
+}
+
+// folder returns the folder page of the directory, creating it and its ancestors on first use.
+// Files of a directory whose folder could not be created fail with the same error.
+func (i *pageImporter) folder(dir string) (*int64, *domain.Error) {
+	if dir == "." {
+		return i.input.ParentPagePkID, nil
+	}
+	if folder, ok := i.folders[dir]; ok {
+		return folder.pagePkID, folder.err
+	}
+
+	parentPagePkID, err := i.folder(path.Dir(dir))
+	if err != nil {
+		i.folders[dir] = folderImport{err: err}
+		return nil, err
+	}
+
+	page, err := i.service.CreateDocumentPage(domain.DocumentPageInput{
+		PageInput: domain.PageInput{
+			Name:             importutils.PageName(dir),
+			ParentPagePkID:   parentPagePkID,
+			ViewType:         domain.PageViewTypeFolder,
+			AuthorPkID:       i.curUser.PkID,
+			OrganizationPkID: i.input.OrgPkID,
+		},
+		Document: domain.DocumentInput{
+			JsonContent: "{}",
+		},
+	}, i.curUser)
+	if err != nil {
+		i.folders[dir] = folderImport{err: err}
+		i.results = append(i.results, domain.PageImportResult{Path: dir + "/", Error: err})
+		return nil, err
+	}
+
+	i.folders[dir] = folderImport{pagePkID: &page.PkID}
+	i.results = append(i.results, domain.PageImportResult{Path: dir + "/", Success: true, Page: page})
+	return &page.PkID, nil
+}
@@ -0,0 +1,139 @@
+package page
+
+import (
+	"context"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+// GetBacklinks returns the pages whose document mentions or links to the page, among the ones the user can view.
+func (s *Service) GetBacklinks(pagePkID int64, curUser *domain.User) ([]domain.PageBacklink, *domain.Error) {
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	links, err := s.pageRepository.ListBacklinks(context.Background(), pagePkID)
+	if err != nil {
+		return nil, err
+	}
+
+	sourcePkIDs := []int64{}
+	kinds := map[int64][]domain.PageLinkKind{}
+	for _, link := range links {
+		if _, ok := kinds[link.SourcePagePkID]; !ok {
+			sourcePkIDs = append(sourcePkIDs, link.SourcePagePkID)
+		}
+		kinds[link.SourcePagePkID] = append(kinds[link.SourcePagePkID], link.Kind)
+	}
+
+	backlinks := []domain.PageBacklink{}
+	if len(sourcePkIDs) == 0 {
+		return backlinks, nil
+	}
+
+	sources, err := s.listViewablePages(page.OrganizationPkID, sourcePkIDs, curUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// In the order the links were created
+	for _, sourcePkID := range sourcePkIDs {
+		if source, ok := sources[sourcePkID]; ok {
+			backlinks = append(backlinks, domain.PageBacklink{Page: source, Kinds: kinds[sourcePkID]})
+		}
+	}
+	return backlinks, nil
+}
+
+// GetOrgLinkGraph returns the links between the pages of the organization, a link is only returned
+// when the user can view both of its pages. Pages without links are not returned.
+func (s *Service) GetOrgLinkGraph(orgPkID int64, curUser *domain.User) (*domain.PageLinkGraph, *domain.Error) {
+	links, err := s.pageRepository.ListOrgLinks(context.Background(), orgPkID)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &domain.PageLinkGraph{
+		Pages: []domain.Page{},
+		Links: []domain.PageLink{},
+	}
+
+	pagePkIDs := []int64{}
+	seen := map[int64]bool{}
+	for _, link := range links {
+		for _, pkID := range []int64{link.SourcePagePkID, link.TargetPagePkID} {
+			if !seen[pkID] {
+				seen[pkID] = true
+				pagePkIDs = append(pagePkIDs, pkID)
+			}
+		}
+	}
+	if len(pagePkIDs) == 0 {
+		return graph, nil
+	}
+
+	pages, err := s.listViewablePages(orgPkID, pagePkIDs, curUser)
+	if err != nil {
+		return nil, err
+	}
+
+	linked := map[int64]bool{}
+	for _, link := range links {
+		_, sourceOk := pages[link.SourcePagePkID]
+		_, targetOk := pages[link.TargetPagePkID]
+		if !sourceOk || !targetOk {
+			continue
+		}
+		graph.Links = append(graph.Links, link)
+		linked[link.SourcePagePkID] = true
+		linked[link.TargetPagePkID] = true
+	}
+	for _, pkID := range pagePkIDs {
+		if linked[pkID] {
+			graph.Pages = append(graph.Pages, pages[pkID])
+		}
+	}
+
+	return graph, nil
+}
+
+// listViewablePages returns the non archived pages of the organization among pagePkIDs the user can view, by pkid.
+func (s *Service) listViewablePages(
+	orgPkID int64,
+	pagePkIDs []int64,
+	curUser *domain.User,
+) (map[int64]domain.Page, *domain.Error) {
+	isArchived := false
+	pages, err := s.pageRepository.List(context.Background(), domain.PageListQuery{
+		OrgPkID:    &orgPkID,
+		PagePkIDs:  pagePkIDs,
+		IsArchived: &isArchived,
+		IsAll:      true,
+	}, curUser)
+	if err != nil {
+		return nil, err
+	}
+
+	viewable := make(map[int64]domain.Page, len(pages))
+	for _, page := range pages {
+		viewable[page.PkID] = page
+	}
+	return viewable, nil
+}
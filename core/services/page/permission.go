@@ -0,0 +1,217 @@
+package page
+
+import (
+	"context"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+// ExplainPagePermissions returns the permissions the user with the email has on the page together with
+// the rules they are resolved from, for the page author and the organization owners and admins to debug
+// its sharing. Emails without an account are explained as if the user signed in.
+func (s *Service) ExplainPagePermissions(
+	pagePkID int64,
+	email string,
+	curUser *domain.User,
+) (*domain.PagePermissionExplanation, *domain.Error) {
+	page, err := s.checkExplainPage(pagePkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetUserByEmail(context.Background(), email)
+	if err != nil && err.Code != domain.NotFoundCode {
+		return nil, err
+	}
+
+	explanation := &domain.PagePermissionExplanation{
+		PagePkID: page.PkID,
+		Email:    email,
+		Rules:    []domain.PagePermissionRule{},
+	}
+
+	if user != nil && page.AuthorPkID != nil && *page.AuthorPkID == user.PkID {
+		explanation.Rules = append(explanation.Rules, domain.PagePermissionRule{
+			Source:   domain.PagePermissionAuthor,
+			Role:     domain.PageEditor,
+			FromPage: page,
+		})
+	}
+
+	roleRules, err := s.explainPageRoles(*page, email, user)
+	if err != nil {
+		return nil, err
+	}
+	explanation.Rules = append(explanation.Rules, roleRules...)
+
+	generalFromPage := page
+	if page.InheritFromPage != nil {
+		generalFromPage = page.InheritFromPage
+	}
+	explanation.Rules = append(explanation.Rules, domain.PagePermissionRule{
+		Source:   domain.PagePermissionGeneralRole,
+		Role:     page.GeneralRole,
+		FromPage: generalFromPage,
+	})
+
+	var directRole, sharedRole *domain.PageRole
+	for i := range explanation.Rules {
+		rule := &explanation.Rules[i]
+		switch rule.Source {
+		case domain.PagePermissionDirectRole, domain.PagePermissionInheritedRole:
+			directRole = &rule.Role
+		case domain.PagePermissionGroupRole, domain.PagePermissionShareLink:
+			sharedRole = domain.HighestPageRole(sharedRole, &rule.Role)
+		}
+	}
+
+	subject := user
+	if subject == nil {
+		subject = &domain.User{Email: email}
+	}
+	explanation.Permissions = s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:       *page,
+		User:       subject,
+		PageRole:   directRole,
+		SharedRole: sharedRole,
+	})
+
+	markAppliedRules(explanation.Rules, domain.HighestPageRole(directRole, sharedRole))
+
+	return explanation, nil
+}
+
+// checkExplainPage checks the user is the author of the page or an owner or admin of its organization,
+// sharing the page is not enough as explanations tell about other users.
+func (s *Service) checkExplainPage(pagePkID int64, curUser *domain.User) (*domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.AuthorPkID != nil && *page.AuthorPkID == curUser.PkID {
+		return page, nil
+	}
+	if err := s.checkSharingAuditOrg(page.OrganizationPkID, curUser); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// explainPageRoles returns the rules given by the role rows of the user, the groups of the user and
+// the share links the user opened, on the page or its ancestors.
+func (s *Service) explainPageRoles(
+	page domain.Page,
+	email string,
+	user *domain.User,
+) ([]domain.PagePermissionRule, *domain.Error) {
+	roles, err := s.pageRepository.GetPageRoles(context.Background(), page.PkID)
+	if err != nil {
+		return nil, err
+	}
+
+	userGroups := make(map[int64]bool)
+	if user != nil {
+		groups, err := s.orgRepository.ListOrgGroupsByUser(context.Background(), page.OrganizationPkID, user.PkID)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			userGroups[group.PkID] = true
+		}
+	}
+
+	rules := []domain.PagePermissionRule{}
+	for _, role := range roles {
+		rule := domain.PagePermissionRule{
+			Role:     role.Role,
+			FromPage: &page,
+		}
+		if role.InheritFromPage != nil {
+			rule.FromPage = role.InheritFromPage
+		}
+
+		switch {
+		case role.Group != nil:
+			if !userGroups[role.Group.PkID] {
+				continue
+			}
+			rule.Source = domain.PagePermissionGroupRole
+			rule.Group = role.Group
+		case role.Email == email:
+			rule.Source = domain.PagePermissionDirectRole
+			if role.InheritFromPage != nil {
+				rule.Source = domain.PagePermissionInheritedRole
+			}
+		default:
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	if user == nil {
+		return rules, nil
+	}
+
+	links, err := s.pageRepository.ListOpenedShareLinks(context.Background(), user.PkID, page.PkID)
+	if err != nil {
+		return nil, err
+	}
+	linkPages := map[int64]*domain.Page{page.PkID: &page}
+	for i := range links {
+		link := links[i]
+		linkPage, ok := linkPages[link.PagePkID]
+		if !ok {
+			linkPage, err = s.pageRepository.GetByID(
+				context.Background(),
+				"",
+				&link.PagePkID,
+				domain.PageDetailOptions{},
+				nil,
+			)
+			if err != nil {
+				return nil, err
+			}
+			linkPages[link.PagePkID] = linkPage
+		}
+
+		rules = append(rules, domain.PagePermissionRule{
+			Source:    domain.PagePermissionShareLink,
+			Role:      link.Role,
+			FromPage:  linkPage,
+			ShareLink: &link,
+		})
+	}
+
+	return rules, nil
+}
+
+// markAppliedRules marks the rules the permissions are resolved from, the author rule, the rules giving
+// the highest role of the user or, when the user has no role, the general role.
+func markAppliedRules(rules []domain.PagePermissionRule, userRole *domain.PageRole) {
+	for i := range rules {
+		if rules[i].Source == domain.PagePermissionAuthor {
+			rules[i].Applied = true
+			return
+		}
+	}
+
+	for i := range rules {
+		switch rules[i].Source {
+		case domain.PagePermissionGeneralRole:
+			rules[i].Applied = userRole == nil
+		default:
+			rules[i].Applied = userRole != nil && rules[i].Role == *userRole
+		}
+	}
+}
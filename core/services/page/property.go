@@ -0,0 +1,524 @@
+package page
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"github.com/google/uuid"
+)
+
+const (
+	maxPropertyNameRunes   = 100
+	maxPropertyOptions     = 100
+	maxPropertyTextRunes   = 2000
+	maxPropertyFilterCount = 20
+)
+
+// ListPropertyDefinitions returns the properties defined on the folder, in position order.
+func (s *Service) ListPropertyDefinitions(folderPkID int64, curUser *domain.User) ([]domain.PageProperty, *domain.Error) {
+	_, permissions, err := s.pagePermissions(folderPkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	return s.pageRepository.ListProperties(context.Background(), []int64{folderPkID})
+}
+
+// CreatePropertyDefinition defines a property on the folder, the pages below the folder inherit it.
+func (s *Service) CreatePropertyDefinition(
+	folderPkID int64,
+	input domain.PagePropertyInput,
+	curUser *domain.User,
+) (*domain.PageProperty, *domain.Error) {
+	if err := s.checkPropertyFolder(folderPkID, curUser); err != nil {
+		return nil, err
+	}
+
+	input.FolderPagePkID = folderPkID
+	input.Name = strings.TrimSpace(input.Name)
+	if !validPropertyName(input.Name) || !validPropertyType(input.Type) {
+		return nil, domain.ErrInvalidPageProperty
+	}
+
+	options, err := normalizePropertyOptions(input.Type, input.Options, nil)
+	if err != nil {
+		return nil, err
+	}
+	input.Options = options
+
+	return s.pageRepository.CreateProperty(context.Background(), input)
+}
+
+// UpdatePropertyDefinition renames the property or replaces its options. Options keep their id
+// when given one, values of the options left out are cleared.
+func (s *Service) UpdatePropertyDefinition(
+	folderPkID int64,
+	propertyID string,
+	input domain.PagePropertyUpdateInput,
+	curUser *domain.User,
+) (*domain.PageProperty, *domain.Error) {
+	if err := s.checkPropertyFolder(folderPkID, curUser); err != nil {
+		return nil, err
+	}
+	property, err := s.getFolderProperty(folderPkID, propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if !validPropertyName(name) {
+			return nil, domain.ErrInvalidPageProperty
+		}
+		input.Name = &name
+	}
+
+	if input.Options != nil {
+		if !property.Type.HasOptions() {
+			return nil, domain.ErrInvalidPageProperty
+		}
+		options, err := normalizePropertyOptions(property.Type, input.Options, property.Options)
+		if err != nil {
+			return nil, err
+		}
+		input.Options = options
+	}
+
+	return s.pageRepository.UpdateProperty(context.Background(), property.PkID, input)
+}
+
+// DeletePropertyDefinition deletes the property and its values on every page.
+func (s *Service) DeletePropertyDefinition(folderPkID int64, propertyID string, curUser *domain.User) *domain.Error {
+	if err := s.checkPropertyFolder(folderPkID, curUser); err != nil {
+		return err
+	}
+	property, err := s.getFolderProperty(folderPkID, propertyID)
+	if err != nil {
+		return err
+	}
+
+	return s.pageRepository.DeleteProperty(context.Background(), property.PkID)
+}
+
+// GetPageProperties returns the properties the page inherits from the folders above it with its values,
+// from the outermost folder.
+func (s *Service) GetPageProperties(pagePkID int64, curUser *domain.User) ([]domain.PagePropertyValue, *domain.Error) {
+	page, permissions, err := s.pagePermissions(pagePkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	pages := []domain.Page{*page}
+	if err := s.attachPageProperties(context.Background(), pages); err != nil {
+		return nil, err
+	}
+	if pages[0].Properties == nil {
+		return []domain.PagePropertyValue{}, nil
+	}
+	return pages[0].Properties, nil
+}
+
+// SetPagePropertyValue sets the value of an inherited property for the page, a null value clears it.
+func (s *Service) SetPagePropertyValue(
+	pagePkID int64,
+	propertyID string,
+	value any,
+	curUser *domain.User,
+) (*domain.PagePropertyValue, *domain.Error) {
+	page, permissions, err := s.pagePermissions(pagePkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+	if !permissions.CanEdit {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	property, err := s.getPropertyByID(propertyID)
+	if err != nil {
+		return nil, err
+	}
+	if !sliceutils.Contains(pageutils.PagePathToPkIDs(page.Path), property.FolderPagePkID) {
+		return nil, domain.ErrPagePropertyNotFound
+	}
+
+	data, err := parsePropertyValue(*property, value)
+	if err != nil {
+		return nil, err
+	}
+	if data.UserPkID != nil {
+		if _, err := s.orgRepository.GetOrgMemberByUserPkID(context.Background(), page.OrganizationPkID, *data.UserPkID); err != nil {
+			if err == domain.ErrOrgMemberNotFound {
+				return nil, domain.ErrInvalidPropertyValue
+			}
+			return nil, err
+		}
+	}
+
+	return s.pageRepository.SetPropertyValue(context.Background(), pagePkID, *property, data)
+}
+
+// ResolvePropertyQuery checks the property filters and sort of a listing of the organization,
+// and types their operands after their property.
+func (s *Service) ResolvePropertyQuery(
+	orgPkID int64,
+	filters []domain.PagePropertyFilter,
+	sort *domain.PagePropertySortInput,
+) ([]domain.PagePropertyCondition, *domain.PagePropertySort, *domain.Error) {
+	if len(filters) > maxPropertyFilterCount {
+		return nil, nil, domain.ErrInvalidPropertyFilter
+	}
+
+	propertyIDs := sliceutils.Map(filters, func(filter domain.PagePropertyFilter) string {
+		return filter.PropertyID
+	})
+	if sort != nil {
+		propertyIDs = append(propertyIDs, sort.PropertyID)
+	}
+	if len(propertyIDs) == 0 {
+		return nil, nil, nil
+	}
+	for _, propertyID := range propertyIDs {
+		if uuid.Validate(propertyID) != nil {
+			return nil, nil, domain.ErrInvalidPropertyFilter
+		}
+	}
+
+	// Properties of other organizations are not found
+	properties, err := s.pageRepository.GetPropertiesByIDs(context.Background(), orgPkID, sliceutils.Uniquify(propertyIDs))
+	if err != nil {
+		return nil, nil, err
+	}
+	propertiesByID := map[string]domain.PageProperty{}
+	for _, property := range properties {
+		propertiesByID[strings.ToLower(property.ID)] = property
+	}
+
+	conditions := make([]domain.PagePropertyCondition, 0, len(filters))
+	for _, filter := range filters {
+		property, ok := propertiesByID[strings.ToLower(filter.PropertyID)]
+		if !ok {
+			return nil, nil, domain.ErrInvalidPropertyFilter
+		}
+		condition, err := parsePropertyCondition(property, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		conditions = append(conditions, *condition)
+	}
+
+	var propertySort *domain.PagePropertySort
+	if sort != nil {
+		property, ok := propertiesByID[strings.ToLower(sort.PropertyID)]
+		if !ok || property.Type == domain.PagePropertyMultiSelect {
+			return nil, nil, domain.ErrInvalidPropertyFilter
+		}
+		propertySort = &domain.PagePropertySort{Property: property, Direction: sort.Direction}
+	}
+
+	return conditions, propertySort, nil
+}
+
+// attachPageProperties sets the properties the pages inherit from the folders above them, with their values.
+func (s *Service) attachPageProperties(ctx context.Context, pages []domain.Page) *domain.Error {
+	folderPkIDs := []int64{}
+	for _, page := range pages {
+		folderPkIDs = append(folderPkIDs, pageutils.PagePathToPkIDs(page.Path)...)
+	}
+	folderPkIDs = sliceutils.Uniquify(folderPkIDs)
+
+	properties, err := s.pageRepository.ListProperties(ctx, folderPkIDs)
+	if err != nil {
+		return err
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	propertiesByFolder := map[int64][]domain.PageProperty{}
+	for _, property := range properties {
+		propertiesByFolder[property.FolderPagePkID] = append(propertiesByFolder[property.FolderPagePkID], property)
+	}
+
+	values, err := s.pageRepository.ListPropertyValues(ctx, sliceutils.Map(pages, func(page domain.Page) int64 {
+		return page.PkID
+	}))
+	if err != nil {
+		return err
+	}
+	type valueKey struct{ pagePkID, propertyPkID int64 }
+	valuesByKey := make(map[valueKey]domain.PagePropertyValue, len(values))
+	for _, value := range values {
+		valuesByKey[valueKey{value.PagePkID, value.Property.PkID}] = value
+	}
+
+	for i := range pages {
+		pageProperties := []domain.PagePropertyValue{}
+		for _, folderPkID := range pageutils.PagePathToPkIDs(pages[i].Path) {
+			for _, property := range propertiesByFolder[folderPkID] {
+				value, ok := valuesByKey[valueKey{pages[i].PkID, property.PkID}]
+				if !ok {
+					value = domain.PagePropertyValue{
+						PagePkID: pages[i].PkID,
+						Property: property,
+						Value:    domain.PagePropertyData{}.Value(property.Type),
+					}
+				}
+				pageProperties = append(pageProperties, value)
+			}
+		}
+		pages[i].Properties = pageProperties
+	}
+	return nil
+}
+
+func (s *Service) pagePermissions(
+	pagePkID int64,
+	curUser *domain.User,
+) (*domain.Page, domain.PageRolePermissions, *domain.Error) {
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, domain.PageRolePermissions{}, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+	return page, permissions, nil
+}
+
+// checkPropertyFolder checks the page is a folder whose properties the user can define.
+func (s *Service) checkPropertyFolder(folderPkID int64, curUser *domain.User) *domain.Error {
+	if curUser == nil {
+		return domain.ErrUnauthorized
+	}
+
+	folder, permissions, err := s.pagePermissions(folderPkID, curUser)
+	if err != nil {
+		return err
+	}
+	if !permissions.CanEdit {
+		return domain.ErrPermissionDenied
+	}
+	if folder.ViewType != domain.PageViewTypeFolder {
+		return domain.ErrPageNotFolder
+	}
+	return nil
+}
+
+func (s *Service) getPropertyByID(propertyID string) (*domain.PageProperty, *domain.Error) {
+	if uuid.Validate(propertyID) != nil {
+		return nil, domain.ErrPagePropertyNotFound
+	}
+	return s.pageRepository.GetPropertyByID(context.Background(), propertyID)
+}
+
+func (s *Service) getFolderProperty(folderPkID int64, propertyID string) (*domain.PageProperty, *domain.Error) {
+	property, err := s.getPropertyByID(propertyID)
+	if err != nil {
+		return nil, err
+	}
+	if property.FolderPagePkID != folderPkID {
+		return nil, domain.ErrPagePropertyNotFound
+	}
+	return property, nil
+}
+
+func validPropertyName(name string) bool {
+	return name != "" && utf8.RuneCountInString(name) <= maxPropertyNameRunes
+}
+
+func validPropertyType(propertyType domain.PagePropertyType) bool {
+	switch propertyType {
+	case domain.PagePropertyText, domain.PagePropertyNumber, domain.PagePropertyDate,
+		domain.PagePropertySelect, domain.PagePropertyMultiSelect, domain.PagePropertyUser,
+		domain.PagePropertyCheckbox:
+		return true
+	}
+	return false
+}
+
+// normalizePropertyOptions trims the options and gives an id to the new ones. Ids not among
+// the current options are replaced, clients cannot choose them.
+func normalizePropertyOptions(
+	propertyType domain.PagePropertyType,
+	options []domain.PagePropertyOption,
+	current []domain.PagePropertyOption,
+) ([]domain.PagePropertyOption, *domain.Error) {
+	if !propertyType.HasOptions() {
+		if len(options) > 0 {
+			return nil, domain.ErrInvalidPageProperty
+		}
+		return []domain.PagePropertyOption{}, nil
+	}
+	if len(options) > maxPropertyOptions {
+		return nil, domain.ErrInvalidPageProperty
+	}
+
+	currentIDs := map[string]bool{}
+	for _, option := range current {
+		currentIDs[option.ID] = true
+	}
+
+	normalized := make([]domain.PagePropertyOption, 0, len(options))
+	usedIDs := map[string]bool{}
+	usedNames := map[string]bool{}
+	for _, option := range options {
+		option.Name = strings.TrimSpace(option.Name)
+		option.Color = strings.TrimSpace(option.Color)
+		if !validPropertyName(option.Name) || usedNames[strings.ToLower(option.Name)] {
+			return nil, domain.ErrInvalidPageProperty
+		}
+		usedNames[strings.ToLower(option.Name)] = true
+
+		if !currentIDs[option.ID] || usedIDs[option.ID] {
+			option.ID = uuid.NewString()
+		}
+		usedIDs[option.ID] = true
+		normalized = append(normalized, option)
+	}
+	return normalized, nil
+}
+
+// parsePropertyValue types a value decoded from json after the property, nil clears the value.
+func parsePropertyValue(property domain.PageProperty, value any) (domain.PagePropertyData, *domain.Error) {
+	data := domain.PagePropertyData{}
+	if value == nil {
+		return data, nil
+	}
+
+	switch property.Type {
+	case domain.PagePropertyText:
+		text, ok := value.(string)
+		if !ok || utf8.RuneCountInString(text) > maxPropertyTextRunes {
+			return data, domain.ErrInvalidPropertyValue
+		}
+		data.Text = &text
+
+	case domain.PagePropertyNumber:
+		number, ok := value.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return data, domain.ErrInvalidPropertyValue
+		}
+		data.Number = &number
+
+	case domain.PagePropertyDate:
+		date, ok := value.(string)
+		if !ok {
+			return data, domain.ErrInvalidPropertyValue
+		}
+		if _, err := time.Parse(pageutils.PagePropertyDateLayout, date); err != nil {
+			return data, domain.ErrInvalidPropertyValue
+		}
+		data.Date = &date
+
+	case domain.PagePropertyCheckbox:
+		checked, ok := value.(bool)
+		if !ok {
+			return data, domain.ErrInvalidPropertyValue
+		}
+		data.Checkbox = &checked
+
+	case domain.PagePropertyUser:
+		userPkID, ok := value.(float64)
+		if !ok || userPkID != math.Trunc(userPkID) || userPkID <= 0 || userPkID > math.MaxInt64 {
+			return data, domain.ErrInvalidPropertyValue
+		}
+		pkID := int64(userPkID)
+		data.UserPkID = &pkID
+
+	case domain.PagePropertySelect:
+		optionID, ok := value.(string)
+		if !ok || !hasPropertyOption(property, optionID) {
+			return data, domain.ErrInvalidPropertyValue
+		}
+		data.OptionIDs = []string{optionID}
+
+	case domain.PagePropertyMultiSelect:
+		items, ok := value.([]any)
+		if !ok {
+			return data, domain.ErrInvalidPropertyValue
+		}
+		optionIDs := make([]string, 0, len(items))
+		for _, item := range items {
+			optionID, ok := item.(string)
+			if !ok || !hasPropertyOption(property, optionID) {
+				return data, domain.ErrInvalidPropertyValue
+			}
+			optionIDs = append(optionIDs, optionID)
+		}
+		data.OptionIDs = sliceutils.Uniquify(optionIDs)
+
+	default:
+		return data, domain.ErrInvalidPropertyValue
+	}
+
+	return data, nil
+}
+
+// parsePropertyCondition checks the operator applies to the type of the property and types the operand.
+func parsePropertyCondition(
+	property domain.PageProperty,
+	filter domain.PagePropertyFilter,
+) (*domain.PagePropertyCondition, *domain.Error) {
+	condition := &domain.PagePropertyCondition{Property: property, Operator: filter.Operator}
+
+	switch filter.Operator {
+	case domain.PagePropertyIsEmpty, domain.PagePropertyIsNotEmpty:
+		if property.Type == domain.PagePropertyCheckbox {
+			return nil, domain.ErrInvalidPropertyFilter
+		}
+		return condition, nil
+	case domain.PagePropertyEq, domain.PagePropertyNeq:
+		if property.Type == domain.PagePropertyMultiSelect {
+			return nil, domain.ErrInvalidPropertyFilter
+		}
+	case domain.PagePropertyGt, domain.PagePropertyGte, domain.PagePropertyLt, domain.PagePropertyLte:
+		if property.Type != domain.PagePropertyNumber && property.Type != domain.PagePropertyDate {
+			return nil, domain.ErrInvalidPropertyFilter
+		}
+	case domain.PagePropertyContains, domain.PagePropertyNotContains:
+		if property.Type != domain.PagePropertyText && property.Type != domain.PagePropertyMultiSelect {
+			return nil, domain.ErrInvalidPropertyFilter
+		}
+	default:
+		return nil, domain.ErrInvalidPropertyFilter
+	}
+
+	// Multi select filters compare with a single option
+	value := filter.Value
+	if property.Type == domain.PagePropertyMultiSelect {
+		value = []any{value}
+	}
+	operand, err := parsePropertyValue(property, value)
+	if err != nil || filter.Value == nil || (operand.IsEmpty() && property.Type != domain.PagePropertyCheckbox) {
+		return nil, domain.ErrInvalidPropertyFilter
+	}
+	condition.Operand = operand
+	return condition, nil
+}
+
+func hasPropertyOption(property domain.PageProperty, optionID string) bool {
+	return sliceutils.Some(property.Options, func(option domain.PagePropertyOption) bool {
+		return option.ID == optionID
+	})
+}
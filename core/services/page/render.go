@@ -0,0 +1,107 @@
+package page
+
+import (
+	"context"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/documentutils"
+	"github.com/Stuhub-io/utils/renderutils"
+	"github.com/Stuhub-io/utils/userutils"
+)
+
+// Images of a pdf are fetched while it renders, the request is not held longer.
+const pdfRenderTimeout = 2 * time.Minute
+
+// RenderPageDocument returns the document of the page the user can view rendered in the format.
+func (s *Service) RenderPageDocument(
+	pageID string,
+	format domain.DocumentFormat,
+	curUser *domain.User,
+) (string, *domain.Error) {
+	page, err := s.GetPageDetailByID(pageID, curUser)
+	if err != nil {
+		return "", err
+	}
+
+	if page.ViewType != domain.PageViewTypeDoc || page.Document == nil {
+		return "", domain.ErrPageNotDocument
+	}
+
+	rendered, rErr := renderutils.Render(page.Document.JsonContent, format)
+	if rErr != nil {
+		return "", domain.ErrInvalidDocumentContent
+	}
+
+	return rendered, nil
+}
+
+// RenderPagePDF renders the document of the page the user can download as a pdf,
+// with the cover image and author on the first page. Returns the pdf and its file name.
+func (s *Service) RenderPagePDF(pagePkID int64, curUser *domain.User) ([]byte, string, *domain.Error) {
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{
+			Document: true,
+			Author:   true,
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), page.PkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+	if !permissions.CanDownload {
+		return nil, "", domain.ErrPermissionDenied
+	}
+
+	if page.ViewType != domain.PageViewTypeDoc || page.Document == nil {
+		return nil, "", domain.ErrPageNotDocument
+	}
+
+	doc, pErr := documentutils.ParseDocument(page.Document.JsonContent)
+	if pErr != nil {
+		return nil, "", domain.ErrInvalidDocumentContent
+	}
+
+	author := ""
+	if page.Author != nil {
+		author = userutils.GetUserFullName(page.Author.FirstName, page.Author.LastName)
+		if author == "" {
+			author = page.Author.Email
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pdfRenderTimeout)
+	defer cancel()
+
+	images := map[string][]byte{}
+	content, rErr := renderutils.PDF(doc, renderutils.PDFOptions{
+		Title:      page.Name,
+		Author:     author,
+		CoverImage: page.CoverImage,
+		LoadImage: func(url string) ([]byte, bool) {
+			if image, ok := images[url]; ok {
+				return image, image != nil
+			}
+			image, ok := s.fetchExportAsset(ctx, url)
+			images[url] = image
+			return image, ok
+		},
+		FontDir: s.cfg.PDFFontDir,
+	})
+	if rErr != nil {
+		s.logger.Errorf(rErr, "[Render]: Failed to render page %d as pdf", page.PkID)
+		return nil, "", domain.ErrInternalServerError
+	}
+
+	return content, exportName(page.Name) + ".pdf", nil
+}
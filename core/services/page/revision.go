@@ -0,0 +1,204 @@
+package page
+
+import (
+	"context"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/documentutils"
+	"github.com/Stuhub-io/utils/outboxutils"
+)
+
+// Document Revision Controller.
+func (s *Service) ListDocumentRevisions(
+	query domain.DocumentRevisionListQuery,
+	curUser *domain.User,
+) ([]domain.DocumentRevision, *domain.Error) {
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&query.PagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), query.PagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	if page.ViewType != domain.PageViewTypeDoc {
+		return nil, domain.ErrPageNotDocument
+	}
+
+	return s.pageRepository.ListDocumentRevisions(context.Background(), query)
+}
+
+func (s *Service) GetDocumentRevision(
+	pagePkID int64,
+	revisionPkID int64,
+	curUser *domain.User,
+) (*domain.DocumentRevision, *domain.Error) {
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	return s.pageRepository.GetDocumentRevision(context.Background(), pagePkID, revisionPkID)
+}
+
+// Restoring writes the revision content back as the current content,
+// which is itself snapshotted as a new revision, so a restore can be undone.
+func (s *Service) RestoreDocumentRevision(
+	pagePkID int64,
+	revisionPkID int64,
+	curUser *domain.User,
+) (*domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanEdit {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	revision, err := s.pageRepository.GetDocumentRevision(context.Background(), pagePkID, revisionPkID)
+	if err != nil {
+		return nil, err
+	}
+
+	go s.pageAccessLogRepository.Upsert(
+		context.Background(),
+		page.PkID,
+		curUser.PkID,
+		domain.PageEdit,
+	)
+
+	return s.pageRepository.UpdateContent(
+		outboxutils.WithEvents(context.Background(), outboxutils.IndexPages),
+		pagePkID,
+		domain.DocumentInput{
+			JsonContent: revision.JsonContent,
+		},
+		&curUser.PkID,
+	)
+}
+
+// Diff between two revisions of the same page, toRevisionPkID nil compares with the live document.
+func (s *Service) DiffDocumentRevisions(
+	pagePkID int64,
+	fromRevisionPkID int64,
+	toRevisionPkID *int64,
+	curUser *domain.User,
+) (*DocumentRevisionDiffResponse, *domain.Error) {
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{
+			Document: toRevisionPkID == nil,
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	if page.ViewType != domain.PageViewTypeDoc {
+		return nil, domain.ErrPageNotDocument
+	}
+
+	from, err := s.pageRepository.GetDocumentRevision(context.Background(), pagePkID, fromRevisionPkID)
+	if err != nil {
+		return nil, err
+	}
+
+	var to *domain.DocumentRevision
+	toContent := ""
+	if toRevisionPkID != nil {
+		to, err = s.pageRepository.GetDocumentRevision(context.Background(), pagePkID, *toRevisionPkID)
+		if err != nil {
+			return nil, err
+		}
+		toContent = to.JsonContent
+	} else if page.Document != nil {
+		toContent = page.Document.JsonContent
+	}
+
+	fromDoc, perr := documentutils.ParseDocument(from.JsonContent)
+	if perr != nil {
+		return nil, domain.ErrInvalidDocumentContent
+	}
+	toDoc, perr := documentutils.ParseDocument(toContent)
+	if perr != nil {
+		return nil, domain.ErrInvalidDocumentContent
+	}
+
+	diff := documentutils.Diff(fromDoc, toDoc)
+
+	from.JsonContent = ""
+	if to != nil {
+		to.JsonContent = ""
+	}
+
+	return &DocumentRevisionDiffResponse{
+		From:    from,
+		To:      to,
+		Changes: diff.Changes,
+		Stats:   diff.Stats,
+	}, nil
+}
@@ -3,6 +3,7 @@ package page
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/Stuhub-io/config"
 	"github.com/Stuhub-io/core/domain"
@@ -10,6 +11,7 @@ import (
 	"github.com/Stuhub-io/logger"
 	commonutils "github.com/Stuhub-io/utils"
 	"github.com/Stuhub-io/utils/activityutils"
+	"github.com/Stuhub-io/utils/outboxutils"
 	"github.com/Stuhub-io/utils/pageutils"
 	"github.com/Stuhub-io/utils/userutils"
 )
@@ -21,7 +23,10 @@ type Service struct {
 	pageAccessLogRepository ports.PageAccessLogRepository
 	orgRepository           ports.OrganizationRepository
 	activityRepository      ports.ActivityRepository
-	mailer                  ports.Mailer
+	pageExportRepository    ports.PageExportRepository
+	userRepository          ports.UserRepository
+	hasher                  ports.Hasher
+	httpClient              *http.Client
 }
 
 type NewServiceParams struct {
@@ -31,19 +36,28 @@ type NewServiceParams struct {
 	ports.PageAccessLogRepository
 	ports.OrganizationRepository
 	ports.ActivityRepository
-	ports.Mailer
+	ports.PageExportRepository
+	ports.UserRepository
+	ports.Hasher
 }
 
 func NewService(params NewServiceParams) *Service {
-	return &Service{
+	s := &Service{
 		cfg:                     params.Config,
 		logger:                  params.Logger,
 		pageRepository:          params.PageRepository,
 		pageAccessLogRepository: params.PageAccessLogRepository,
-		mailer:                  params.Mailer,
 		orgRepository:           params.OrganizationRepository,
 		activityRepository:      params.ActivityRepository,
+		pageExportRepository:    params.PageExportRepository,
+		userRepository:          params.UserRepository,
+		hasher:                  params.Hasher,
 	}
+	s.httpClient = &http.Client{
+		Timeout:       exportAssetTimeout,
+		CheckRedirect: s.checkExportAssetRedirect,
+	}
+	return s
 }
 
 func (s *Service) GetPagesByOrgPkID(
@@ -79,7 +93,14 @@ func (s *Service) GetPagesByOrgPkID(
 	}
 
 	d, e = s.pageRepository.List(context.Background(), query, curUser)
-	return d, e
+	if e != nil || !query.WithProperties {
+		return d, e
+	}
+
+	if err := s.attachPageProperties(context.Background(), d); err != nil {
+		return nil, err
+	}
+	return d, nil
 }
 
 func (s *Service) UpdatePageByPkID(
@@ -119,7 +140,8 @@ func (s *Service) UpdatePageByPkID(
 		)
 	}
 
-	d, e = s.pageRepository.Update(context.Background(), pagePkID, updateInput)
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.IndexPages)
+	d, e = s.pageRepository.Update(ctx, pagePkID, updateInput)
 
 	// Log Activity
 	// FIXME: Move rename to separate API
@@ -151,24 +173,19 @@ func (s *Service) UpdatePageByPkID(
 
 func (s *Service) GetPageDetailByID(
 	pageID string,
-	publicTokenID string,
 	curUser *domain.User,
 ) (d *domain.Page, e *domain.Error) {
+	return s.getPageDetail(pageID, nil, curUser, false)
+}
 
-	var pagePkID *int64
-
-	// FIXME: remove public token features
-	if pageID == "" {
-		token, err := s.pageRepository.GetPublicTokenByID(context.Background(), publicTokenID)
-		if token.ArchivedAt != "" {
-			return nil, domain.NewErr("Public page is expired", domain.ResourceInvalidOrExpiredCode)
-		}
-		if err != nil {
-			return nil, domain.ErrDatabaseQuery
-		}
-		pagePkID = &token.PagePkID
-	}
-
+// getPageDetail returns the page with the permissions of the user, anonymous users opening a share
+// link can view the page.
+func (s *Service) getPageDetail(
+	pageID string,
+	pagePkID *int64,
+	curUser *domain.User,
+	viaShareLink bool,
+) (d *domain.Page, e *domain.Error) {
 	var userPkID *int64 = nil
 	if curUser != nil {
 		userPkID = &curUser.PkID
@@ -196,6 +213,10 @@ func (s *Service) GetPageDetailByID(
 		User:     curUser,
 		PageRole: curRole,
 	})
+	if viaShareLink && curUser == nil {
+		permission.CanView = true
+		permission.CanDownload = true
+	}
 
 	// Assign Current User Permission
 	d.Permissions = &permission
@@ -264,46 +285,30 @@ func (s *Service) ArchivedPageByPkID(
 		return nil, domain.ErrPermissionDenied
 	}
 
-	d, e = s.pageRepository.Archive(context.Background(), pagePkID)
-
-	var pP *domain.Page = nil
+	pPName := ""
 	if page.ParentPagePkID != nil {
-		p, pErr := s.pageRepository.GetByID(context.Background(), "", page.ParentPagePkID, domain.PageDetailOptions{}, nil)
-		if pErr != nil {
-			return d, e
+		pP, pErr := s.pageRepository.GetByID(context.Background(), "", page.ParentPagePkID, domain.PageDetailOptions{}, nil)
+		if pErr == nil {
+			pPName = pP.Name
 		}
-		pP = p
-	}
-
-	go func() {
-		commonutils.RetryFunc(3, func() error {
-			pPName := ""
-			if pP != nil {
-				pPName = pP.Name
-			}
-			metadata := commonutils.ToJsonStr(activityutils.UserRemovePageMeta{
-				OldParentPagePkID: page.ParentPagePkID,
-				OldParentPageName: &pPName,
-			})
-
-			_, err := s.activityRepository.Create(context.Background(), domain.ActivityInput{
-				ActionCode: domain.ActionUserRemovePage,
-				PagePkID:   &page.PkID,
-				OrgPkID:    &page.OrganizationPkID,
-				ActorPkID:  curUser.PkID,
-				MetaData:   &metadata,
-			})
-
-			if err != nil {
-				e := fmt.Errorf(err.Message)
-				s.logger.Error(e, "[Activity]: Failed to log activity for remove page")
-				return e
-			}
-			return nil
+	}
+
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.IndexPageTrees)
+	ctx = outboxutils.WithEvents(ctx, func(pages []domain.Page) []domain.OutboxEventInput {
+		metadata := commonutils.ToJsonStr(activityutils.UserRemovePageMeta{
+			OldParentPagePkID: page.ParentPagePkID,
+			OldParentPageName: &pPName,
 		})
-	}()
+		return []domain.OutboxEventInput{outboxutils.ActivityEvent(domain.ActivityInput{
+			ActionCode: domain.ActionUserRemovePage,
+			PagePkID:   &page.PkID,
+			OrgPkID:    &page.OrganizationPkID,
+			ActorPkID:  curUser.PkID,
+			MetaData:   &metadata,
+		})}
+	})
 
-	return d, e
+	return s.pageRepository.Archive(ctx, pagePkID)
 }
 
 func (s *Service) MovePageByPkID(
@@ -335,78 +340,64 @@ func (s *Service) MovePageByPkID(
 		return nil, domain.ErrPermissionDenied
 	}
 
-	d, e = s.pageRepository.Move(context.Background(), pagePkID, moveInput.ParentPagePkID)
-
-	parentPage, err := s.pageRepository.GetByID(context.Background(), "", moveInput.ParentPagePkID, domain.PageDetailOptions{}, nil)
-	if err != nil {
-		return d, e
+	var pName *string = nil
+	if moveInput.ParentPagePkID != nil {
+		parentPage, pErr := s.pageRepository.GetByID(context.Background(), "", moveInput.ParentPagePkID, domain.PageDetailOptions{}, nil)
+		if pErr == nil {
+			pName = &parentPage.Name
+		}
 	}
+
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.IndexPageTrees)
 	// Log Activity
-	go func() {
-		commonutils.RetryFunc(3, func() error {
-			var pName *string = nil
-			if parentPage != nil {
-				pName = &parentPage.Name
-			}
-			var oldPName *string = nil
-			oldPName = &p.Name
-
-			metadata := commonutils.ToJsonStr(activityutils.UserMovePageMeta{
-				OldParentPagePkID: p.ParentPagePkID,
-				NewParentPagePkID: d.ParentPagePkID,
-				OldParentPageName: oldPName,
-				NewParentPageName: pName,
-			})
-			_, err := s.activityRepository.Create(context.Background(), domain.ActivityInput{
-				ActionCode: domain.ActionUserMovePage,
-				PagePkID:   &d.PkID,
-				OrgPkID:    &d.OrganizationPkID,
-				ActorPkID:  curUser.PkID,
-				MetaData:   &metadata,
-			})
-
-			if err != nil {
-				e := fmt.Errorf(err.Message)
-				s.logger.Error(e, "[Activity]: Failed to log activity for move page")
-				return e
-			}
-			return nil
+	ctx = outboxutils.WithEvents(ctx, func(pages []domain.Page) []domain.OutboxEventInput {
+		moved := pages[0]
+		metadata := commonutils.ToJsonStr(activityutils.UserMovePageMeta{
+			OldParentPagePkID: p.ParentPagePkID,
+			NewParentPagePkID: moved.ParentPagePkID,
+			OldParentPageName: &p.Name,
+			NewParentPageName: pName,
 		})
-	}()
+		return []domain.OutboxEventInput{outboxutils.ActivityEvent(domain.ActivityInput{
+			ActionCode: domain.ActionUserMovePage,
+			PagePkID:   &moved.PkID,
+			OrgPkID:    &moved.OrganizationPkID,
+			ActorPkID:  curUser.PkID,
+			MetaData:   &metadata,
+		})}
+	})
 
-	return d, e
+	return s.pageRepository.Move(ctx, pagePkID, moveInput.ParentPagePkID)
 }
 
-func (s *Service) CreatePublicPageToken(
-	pageID string,
-) (d *domain.PagePublicToken, e *domain.Error) {
-	page, err := s.pageRepository.GetByID(
+func (s *Service) ReorderPageByPkID(
+	pagePkID int64,
+	reorderInput domain.PageReorderInput,
+	curUser *domain.User,
+) (*domain.Page, *domain.Error) {
+	p, err := s.pageRepository.GetByID(
 		context.Background(),
-		pageID,
-		nil,
+		"",
+		&pagePkID,
 		domain.PageDetailOptions{},
 		nil,
 	)
 	if err != nil {
-		return nil, domain.ErrDatabaseQuery
+		return nil, err
 	}
-	d, e = s.pageRepository.CreatePublicToken(context.Background(), page.PkID)
-	return d, e
-}
 
-func (s *Service) ArchiveAllPublicPageToken(pageID string) (e *domain.Error) {
-	page, err := s.pageRepository.GetByID(
-		context.Background(),
-		pageID,
-		nil,
-		domain.PageDetailOptions{},
-		nil,
-	)
-	if err != nil {
-		return domain.ErrDatabaseQuery
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *p,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanMove {
+		return nil, domain.ErrPermissionDenied
 	}
-	e = s.pageRepository.ArchiveAllPublicToken(context.Background(), page.PkID)
-	return e
+
+	return s.pageRepository.Reorder(context.Background(), pagePkID, reorderInput)
 }
 
 func (s *Service) UpdateGeneralAccess(
@@ -484,43 +475,16 @@ func (s *Service) CreateDocumentPage(
 		return nil, domain.ErrPermissionDenied
 	}
 
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.IndexPages)
+	// Log Activity
+	ctx = outboxutils.WithEvents(ctx, s.createPageActivity(parentPage, curUser))
+
 	// FIXME: Check if user is a member of the organization
-	page, err := s.pageRepository.CreateDocumentPage(context.Background(), pageInput)
+	page, err := s.pageRepository.CreateDocumentPage(ctx, pageInput)
 
 	if err != nil {
 		return nil, domain.ErrDatabaseMutation
 	}
-	// Log Activity
-	go func() {
-		commonutils.RetryFunc(3, func() error {
-			var pName *string = nil
-			if parentPage != nil {
-				pName = &parentPage.Name
-			}
-
-			metadata := commonutils.ToJsonStr(activityutils.UserCreatePageMeta{
-				ParentPagePkID: pageInput.ParentPagePkID,
-				ParentPageName: pName,
-				NewPageName:    page.Name,
-				NewPagePkID:    page.PkID,
-				NewPageID:      page.ID,
-			})
-
-			_, err := s.activityRepository.Create(context.Background(), domain.ActivityInput{
-				ActionCode: domain.ActionUserCreatePage,
-				PagePkID:   &page.PkID,
-				OrgPkID:    &page.OrganizationPkID,
-				ActorPkID:  curUser.PkID,
-				MetaData:   &metadata,
-			})
-			if err != nil {
-				e := fmt.Errorf(err.Message)
-				s.logger.Error(e, "Failed to log activity")
-				return e
-			}
-			return nil
-		})
-	}()
 
 	return page, nil
 }
@@ -563,39 +527,19 @@ func (s *Service) UpdateDocumentContentByPkID(
 		)
 	}
 
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.IndexPages)
 	// Activity Log
-	d, e = s.pageRepository.UpdateContent(context.Background(), pagePkID, content)
-	go func() {
-		commonutils.RetryFunc(3, func() error {
-			metadata := commonutils.ToJsonStr(activityutils.UserVisitePageMeta{})
-
-			_, err := s.activityRepository.Create(context.Background(), domain.ActivityInput{
-				ActionCode: domain.ActionUserCreatePage,
-				PagePkID:   &page.PkID,
-				ActorPkID:  curUser.PkID,
-				MetaData:   &metadata,
-			})
-			if err != nil {
-				e := fmt.Errorf(err.Message)
-				s.logger.Error(e, "Failed to log activity")
-				return e
-			}
-			return nil
-		})
-	}()
-
-	return d, e
-}
-
-// Generate Public Token.
-func (s *Service) GenerateDocumentPublicToken(pagePkID int64) (d string, e *domain.Error) {
-	// d, e = s.pageRepository.GeneratePublicToken(context.Background(), pagePkID)
-	return d, e
-}
+	ctx = outboxutils.WithEvents(ctx, func(pages []domain.Page) []domain.OutboxEventInput {
+		metadata := commonutils.ToJsonStr(activityutils.UserVisitePageMeta{})
+		return []domain.OutboxEventInput{outboxutils.ActivityEvent(domain.ActivityInput{
+			ActionCode: domain.ActionUserCreatePage,
+			PagePkID:   &page.PkID,
+			ActorPkID:  curUser.PkID,
+			MetaData:   &metadata,
+		})}
+	})
 
-func (s *Service) ValidateDocumentPublicToken(token string) (d bool, e *domain.Error) {
-	// d, e = s.pageRepository.ValidatePublicToken(context.Background(), token)
-	return d, e
+	return s.pageRepository.UpdateContent(ctx, pagePkID, content, &curUser.PkID)
 }
 
 // Asset Controller.
@@ -644,41 +588,14 @@ func (s *Service) CreateAssetPage(
 	// 	return nil, domain.ErrPermissionDenied
 	// }
 
-	page, err := s.pageRepository.CreateAsset(context.Background(), assetInput)
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.IndexPages)
+	// Log Activity
+	ctx = outboxutils.WithEvents(ctx, s.createPageActivity(parentPage, curUser))
+
+	page, err := s.pageRepository.CreateAsset(ctx, assetInput)
 	if err != nil {
 		return nil, domain.ErrDatabaseMutation
 	}
-	// Log Activity
-	go func() {
-		commonutils.RetryFunc(3, func() error {
-			var pName *string = nil
-			if parentPage != nil {
-				pName = &parentPage.Name
-			}
-
-			metadata := commonutils.ToJsonStr(activityutils.UserCreatePageMeta{
-				ParentPagePkID: assetInput.ParentPagePkID,
-				ParentPageName: pName,
-				NewPageName:    page.Name,
-				NewPagePkID:    page.PkID,
-				NewPageID:      page.ID,
-			})
-
-			_, err := s.activityRepository.Create(context.Background(), domain.ActivityInput{
-				ActionCode: domain.ActionUserCreatePage,
-				PagePkID:   &page.PkID,
-				OrgPkID:    &page.OrganizationPkID,
-				ActorPkID:  curUser.PkID,
-				MetaData:   &metadata,
-			})
-			if err != nil {
-				e := fmt.Errorf(err.Message)
-				s.logger.Error(e, "Failed to log activity")
-				return e
-			}
-			return nil
-		})
-	}()
 
 	go s.pageAccessLogRepository.Upsert(
 		context.Background(),
@@ -724,6 +641,32 @@ func (s *Service) AddPageRoleUser(
 		return nil, nil, domain.ErrPermissionDenied
 	}
 
+	if input.GroupID != "" {
+		if !input.Role.IsSharedRole() {
+			return nil, nil, domain.ErrBadRequest
+		}
+
+		group, err := s.orgRepository.GetOrgGroupByID(context.Background(), existingPage.OrganizationPkID, input.GroupID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if existingGroupRole, _ := s.pageRepository.GetPageGroupRole(
+			context.Background(),
+			input.PagePkID,
+			group.PkID,
+		); existingGroupRole != nil {
+			return nil, nil, domain.ErrExisitingPageRoleUser
+		}
+
+		pageRoleUser, err := s.pageRepository.CreatePageGroupRole(context.Background(), input.PagePkID, *group, input.Role)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return pageRoleUser, existingPage, nil
+	}
+
 	exisingPageRoleUser, _ := s.pageRepository.GetPageRoleByEmail(
 		context.Background(),
 		input.PagePkID,
@@ -734,29 +677,26 @@ func (s *Service) AddPageRoleUser(
 		return nil, nil, domain.ErrExisitingPageRoleUser
 	}
 
-	pageRoleUser, err := s.pageRepository.CreatePageRole(context.Background(), input)
-	if err != nil {
-		return nil, nil, domain.ErrDatabaseMutation
-	}
-
-	err = s.mailer.SendMailCustomTemplate(ports.SendSendGridMailCustomTemplatePayload{
-		ToName: userutils.GetUserFullName(
-			pageRoleUser.User.FirstName,
-			pageRoleUser.User.LastName,
-		),
-		ToAddress:        pageRoleUser.User.Email,
-		TemplateHTMLName: "share_people",
-		Data: map[string]string{
-			"sender": userutils.GetUserFullName(
-				curUser.FirstName,
-				curUser.LastName,
-			),
-			"url": fmt.Sprintf("%s/%s/%s", s.cfg.RemoteBaseURL, existingPage.Organization.Slug, existingPage.ID),
-		},
-		Subject: "Share with you",
+	// The shared user may not have an account yet, the mail goes to the shared email
+	ctx := outboxutils.WithEvents(context.Background(), func(pages []domain.Page) []domain.OutboxEventInput {
+		return []domain.OutboxEventInput{outboxutils.MailEvent(domain.OutboxMailPayload{
+			ToName:           input.Email,
+			ToAddress:        input.Email,
+			TemplateHTMLName: "share_people",
+			Data: map[string]string{
+				"sender": userutils.GetUserFullName(
+					curUser.FirstName,
+					curUser.LastName,
+				),
+				"url": fmt.Sprintf("%s/%s/%s", s.cfg.RemoteBaseURL, existingPage.Organization.Slug, existingPage.ID),
+			},
+			Subject: "Share with you",
+		})}
 	})
+
+	pageRoleUser, err := s.pageRepository.CreatePageRole(ctx, input)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, domain.ErrDatabaseMutation
 	}
 
 	return pageRoleUser, existingPage, nil
@@ -830,6 +770,18 @@ func (s *Service) UpdatePageRoleUser(
 		return err
 	}
 
+	if input.GroupID != "" {
+		if !input.Role.IsSharedRole() {
+			return domain.ErrBadRequest
+		}
+
+		group, err := s.getPageGroupRoleGroup(*exisingPage, input.GroupID)
+		if err != nil {
+			return err
+		}
+		return s.pageRepository.UpdatePageGroupRole(context.Background(), input.PagePkID, group.PkID, input.Role)
+	}
+
 	exisingPageRoleUser, _ := s.pageRepository.GetPageRoleByEmail(
 		context.Background(),
 		input.PagePkID,
@@ -868,6 +820,14 @@ func (s *Service) DeletePageRoleUser(
 		return domain.ErrPermissionDenied
 	}
 
+	if input.GroupID != "" {
+		group, err := s.getPageGroupRoleGroup(*existingPage, input.GroupID)
+		if err != nil {
+			return err
+		}
+		return s.pageRepository.DeletePageGroupRole(context.Background(), input.PagePkID, group.PkID)
+	}
+
 	exisingPageRoleUser, _ := s.pageRepository.GetPageRoleByEmail(
 		context.Background(),
 		input.PagePkID,
@@ -880,15 +840,29 @@ func (s *Service) DeletePageRoleUser(
 	return s.pageRepository.DeletePageRole(context.Background(), input)
 }
 
+// getPageGroupRoleGroup returns the group of the page organization, when it has a role on the page itself.
+func (s *Service) getPageGroupRoleGroup(page domain.Page, groupID string) (*domain.OrgGroup, *domain.Error) {
+	group, err := s.orgRepository.GetOrgGroupByID(context.Background(), page.OrganizationPkID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.pageRepository.GetPageGroupRole(context.Background(), page.PkID, group.PkID); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// GetPageRolesByUser returns the highest of the direct role of the user and the roles shared with them
+// through groups and share links.
 func (s *Service) GetPageRolesByUser(ctx context.Context, pagePkID int64, user *domain.User) *domain.PageRole {
 	if user == nil {
 		return nil
 	}
-	role, err := s.pageRepository.GetPageRoleByEmail(ctx, pagePkID, user.Email)
-	if err != nil {
-		return nil
-	}
-	return &role.Role
+
+	directRole, sharedRole := s.pageRepository.GetUserPageRoles(ctx, pagePkID, *user)
+	return domain.HighestPageRole(directRole, sharedRole)
 }
 
 func (s Service) RequestPagePermission(pageID string, email string) *domain.Error {
@@ -925,18 +899,10 @@ func (s Service) RejectPagePermissions(pagePkID int64, emails []string) *domain.
 		return err
 	}
 
-	err = s.pageRepository.UpdatePageAccessRequestStatus(context.Background(), domain.PageRoleRequestLogQuery{
-		PagePkIDs: []int64{pagePkID},
-		Emails:    emails,
-	}, domain.PRSLRejected)
-
-	if err != nil {
-		return err
-	}
-
-	for _, email := range emails {
-		go func(email string) {
-			err = s.mailer.SendMailCustomTemplate(ports.SendSendGridMailCustomTemplatePayload{
+	ctx := outboxutils.WithEvents(context.Background(), func(pages []domain.Page) []domain.OutboxEventInput {
+		events := make([]domain.OutboxEventInput, 0, len(emails))
+		for _, email := range emails {
+			events = append(events, outboxutils.MailEvent(domain.OutboxMailPayload{
 				ToName:           email,
 				ToAddress:        email,
 				TemplateHTMLName: "share_request_rejected",
@@ -944,14 +910,15 @@ func (s Service) RejectPagePermissions(pagePkID int64, emails []string) *domain.
 					"page": existingPage.Name,
 				},
 				Subject: "Access request reply",
-			})
-			if err != nil {
-				s.logger.Info(err.Message)
-			}
-		}(email)
-	}
+			}))
+		}
+		return events
+	})
 
-	return nil
+	return s.pageRepository.UpdatePageAccessRequestStatus(ctx, domain.PageRoleRequestLogQuery{
+		PagePkIDs: []int64{pagePkID},
+		Emails:    emails,
+	}, domain.PRSLRejected)
 }
 
 func (s Service) AcceptRequestPagePermission(input domain.PageRoleCreateInput, curUser *domain.User) *domain.Error {
@@ -960,37 +927,30 @@ func (s Service) AcceptRequestPagePermission(input domain.PageRoleCreateInput, c
 		return err
 	}
 
-	err = s.pageRepository.UpdatePageAccessRequestStatus(context.Background(), domain.PageRoleRequestLogQuery{
-		PagePkIDs: []int64{input.PagePkID},
-		Emails:    []string{input.Email},
-	}, domain.PRSLApproved)
-
-	if err != nil {
-		return err
-	}
-
-	err = s.mailer.SendMailCustomTemplate(ports.SendSendGridMailCustomTemplatePayload{
-		ToName: userutils.GetUserFullName(
-			curUser.FirstName,
-			curUser.LastName,
-		),
-		ToAddress:        input.Email,
-		TemplateHTMLName: "share_request_accepted",
-		Data: map[string]string{
-			"page": pageDetails.Name,
-			"sender": userutils.GetUserFullName(
+	ctx := outboxutils.WithEvents(context.Background(), func(pages []domain.Page) []domain.OutboxEventInput {
+		return []domain.OutboxEventInput{outboxutils.MailEvent(domain.OutboxMailPayload{
+			ToName: userutils.GetUserFullName(
 				curUser.FirstName,
 				curUser.LastName,
 			),
-			"url": fmt.Sprintf("%s/%s/%s", s.cfg.RemoteBaseURL, pageDetails.Organization.Slug, pageDetails.ID),
-		},
-		Subject: "Access request reply",
+			ToAddress:        input.Email,
+			TemplateHTMLName: "share_request_accepted",
+			Data: map[string]string{
+				"page": pageDetails.Name,
+				"sender": userutils.GetUserFullName(
+					curUser.FirstName,
+					curUser.LastName,
+				),
+				"url": fmt.Sprintf("%s/%s/%s", s.cfg.RemoteBaseURL, pageDetails.Organization.Slug, pageDetails.ID),
+			},
+			Subject: "Access request reply",
+		})}
 	})
-	if err != nil {
-		return err
-	}
 
-	return nil
+	return s.pageRepository.UpdatePageAccessRequestStatus(ctx, domain.PageRoleRequestLogQuery{
+		PagePkIDs: []int64{input.PagePkID},
+		Emails:    []string{input.Email},
+	}, domain.PRSLApproved)
 }
 
 func (s Service) AddPageToStarred(input domain.StarPageInput, curUser *domain.User) *domain.Error {
@@ -1067,3 +1027,31 @@ func (s Service) CreateUserActivity(input domain.ActivityInput, curUser *domain.
 	}
 	return nil
 }
+
+// createPageActivity logs the creation of the written page under parentPage.
+func (s *Service) createPageActivity(parentPage *domain.Page, curUser *domain.User) outboxutils.EventsBuilder {
+	return func(pages []domain.Page) []domain.OutboxEventInput {
+		page := pages[0]
+
+		var pName *string = nil
+		if parentPage != nil {
+			pName = &parentPage.Name
+		}
+
+		metadata := commonutils.ToJsonStr(activityutils.UserCreatePageMeta{
+			ParentPagePkID: page.ParentPagePkID,
+			ParentPageName: pName,
+			NewPageName:    page.Name,
+			NewPagePkID:    page.PkID,
+			NewPageID:      page.ID,
+		})
+
+		return []domain.OutboxEventInput{outboxutils.ActivityEvent(domain.ActivityInput{
+			ActionCode: domain.ActionUserCreatePage,
+			PagePkID:   &page.PkID,
+			OrgPkID:    &page.OrganizationPkID,
+			ActorPkID:  curUser.PkID,
+			MetaData:   &metadata,
+		})}
+	}
+}
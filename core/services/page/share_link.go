@@ -0,0 +1,150 @@
+package page
+
+import (
+	"context"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+// CreateShareLink creates a link opening the page with the role of the link, the password is stored hashed.
+func (s *Service) CreateShareLink(
+	input domain.PageShareLinkInput,
+	curUser *domain.User,
+) (*domain.PageShareLink, *domain.Error) {
+	if _, err := s.checkShareLinkPage(input.PagePkID, curUser); err != nil {
+		return nil, err
+	}
+
+	if !input.Role.IsSharedRole() {
+		return nil, domain.ErrBadRequest
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, domain.ErrBadRequest
+	}
+	if input.MaxUses != nil && *input.MaxUses < 1 {
+		return nil, domain.ErrBadRequest
+	}
+
+	if input.Password != "" {
+		salt := s.hasher.GenerateSalt()
+		hash, err := s.hasher.Hash(input.Password, salt)
+		if err != nil {
+			return nil, domain.ErrInternalServerError
+		}
+		input.PasswordHash = hash
+		input.PasswordSalt = salt
+	}
+	input.CreatedByPkID = curUser.PkID
+
+	return s.pageRepository.CreateShareLink(context.Background(), input)
+}
+
+func (s *Service) GetShareLinks(pagePkID int64, curUser *domain.User) ([]domain.PageShareLink, *domain.Error) {
+	if _, err := s.checkShareLinkPage(pagePkID, curUser); err != nil {
+		return nil, err
+	}
+
+	return s.pageRepository.ListShareLinks(context.Background(), pagePkID)
+}
+
+func (s *Service) GetShareLinkAccesses(
+	pagePkID int64,
+	linkID string,
+	curUser *domain.User,
+) ([]domain.PageShareLinkAccess, *domain.Error) {
+	link, err := s.getPageShareLink(pagePkID, linkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pageRepository.ListShareLinkAccesses(context.Background(), link.PkID)
+}
+
+// RevokeShareLink deactivates the link, users who opened it lose the role of the link.
+func (s *Service) RevokeShareLink(pagePkID int64, linkID string, curUser *domain.User) *domain.Error {
+	link, err := s.getPageShareLink(pagePkID, linkID, curUser)
+	if err != nil {
+		return err
+	}
+
+	return s.pageRepository.RevokeShareLink(context.Background(), link.PkID)
+}
+
+// OpenShareLink returns the page of the link. Signed-in users get the role of the link on the page and
+// its descendants while the link is active, anonymous users can only view the page.
+func (s *Service) OpenShareLink(linkID string, password string, curUser *domain.User) (*domain.Page, *domain.Error) {
+	link, err := s.pageRepository.GetShareLinkByID(context.Background(), linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.RevokedAt != "" {
+		return nil, domain.ErrShareLinkInactive
+	}
+
+	if link.HasPassword && !s.hasher.Compare(password, link.PasswordHash, link.PasswordSalt) {
+		return nil, domain.ErrShareLinkPassword
+	}
+
+	if link.OrgMembersOnly {
+		if curUser == nil {
+			return nil, domain.ErrUnauthorized
+		}
+		page, err := s.pageRepository.GetByID(context.Background(), "", &link.PagePkID, domain.PageDetailOptions{}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.orgRepository.GetOrgMemberByUserPkID(
+			context.Background(),
+			page.OrganizationPkID,
+			curUser.PkID,
+		); err != nil {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+
+	var userPkID *int64
+	if curUser != nil {
+		userPkID = &curUser.PkID
+	}
+	if err := s.pageRepository.UseShareLink(context.Background(), link.PkID, userPkID); err != nil {
+		return nil, err
+	}
+
+	return s.getPageDetail("", &link.PagePkID, curUser, true)
+}
+
+// checkShareLinkPage checks the user can share the page.
+func (s *Service) checkShareLinkPage(pagePkID int64, curUser *domain.User) (*domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	page, permissions, err := s.pagePermissions(pagePkID, curUser)
+	if err != nil {
+		return nil, err
+	}
+	if !permissions.CanShare {
+		return nil, domain.ErrPermissionDenied
+	}
+	return page, nil
+}
+
+func (s *Service) getPageShareLink(
+	pagePkID int64,
+	linkID string,
+	curUser *domain.User,
+) (*domain.PageShareLink, *domain.Error) {
+	if _, err := s.checkShareLinkPage(pagePkID, curUser); err != nil {
+		return nil, err
+	}
+
+	link, err := s.pageRepository.GetShareLinkByID(context.Background(), linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.PagePkID != pagePkID {
+		return nil, domain.ErrShareLinkNotFound
+	}
+	return link, nil
+}
@@ -0,0 +1,139 @@
+package page
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+var sharingAuditCSVHeader = []string{
+	"page_pkid",
+	"page_id",
+	"page_name",
+	"view_type",
+	"general_role",
+	"general_role_from_page_id",
+	"outside_email",
+	"outside_role",
+	"outside_role_from_page_id",
+	"share_link_id",
+	"share_link_role",
+	"share_link_from_page_id",
+}
+
+// GetSharingAudit lists the pages of the organization visible to people outside of it, pages whose general
+// role is not restricted, pages shared with emails that are not members of the organization and pages
+// opened by active share links not limited to members.
+func (s *Service) GetSharingAudit(orgPkID int64, curUser *domain.User) ([]domain.PageSharingAuditEntry, *domain.Error) {
+	if err := s.checkSharingAuditOrg(orgPkID, curUser); err != nil {
+		return nil, err
+	}
+
+	return s.pageRepository.ListOrgSharingAudit(context.Background(), orgPkID)
+}
+
+// ExportSharingAuditCSV returns the sharing audit of the organization as CSV with its file name, one row
+// per role given outside the organization and per share link, pages only exposed by their general role
+// get a single row.
+func (s *Service) ExportSharingAuditCSV(orgPkID int64, curUser *domain.User) ([]byte, string, *domain.Error) {
+	entries, err := s.GetSharingAudit(orgPkID, curUser)
+	if err != nil {
+		return nil, "", err
+	}
+
+	org, err := s.orgRepository.GetOrgByPkID(context.Background(), orgPkID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(sharingAuditCSVHeader); err != nil {
+		return nil, "", domain.ErrInternalServerError
+	}
+	for _, entry := range entries {
+		for _, record := range sharingAuditRecords(entry) {
+			if err := w.Write(record); err != nil {
+				return nil, "", domain.ErrInternalServerError
+			}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, "", domain.ErrInternalServerError
+	}
+
+	fileName := fmt.Sprintf("sharing-audit-%s-%s.csv", org.Slug, time.Now().Format(time.DateOnly))
+	return buf.Bytes(), fileName, nil
+}
+
+func sharingAuditRecords(entry domain.PageSharingAuditEntry) [][]string {
+	page := []string{
+		fmt.Sprint(entry.Page.PkID),
+		entry.Page.ID,
+		csvCell(entry.Page.Name),
+		entry.Page.ViewType.String(),
+		entry.GeneralRole.String(),
+		auditPageID(entry.GeneralRoleFromPage),
+	}
+
+	if len(entry.OutsideRoles) == 0 && len(entry.ShareLinks) == 0 {
+		return [][]string{append(page, "", "", "", "", "", "")}
+	}
+
+	records := make([][]string, 0, len(entry.OutsideRoles)+len(entry.ShareLinks))
+	for _, role := range entry.OutsideRoles {
+		record := append(slices.Clone(page), csvCell(role.Email), role.Role.String(), auditPageID(role.InheritFromPage))
+		records = append(records, append(record, "", "", ""))
+	}
+	for _, link := range entry.ShareLinks {
+		record := append(slices.Clone(page), "", "", "", link.Link.ID, link.Link.Role.String(), auditPageID(link.FromPage))
+		records = append(records, record)
+	}
+	return records
+}
+
+func auditPageID(page *domain.Page) string {
+	if page == nil {
+		return ""
+	}
+	return page.ID
+}
+
+// csvCell keeps user input from being read as a formula by spreadsheets.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// checkSharingAuditOrg checks the user is an owner or admin of the organization.
+func (s *Service) checkSharingAuditOrg(orgPkID int64, curUser *domain.User) *domain.Error {
+	if curUser == nil {
+		return domain.ErrUnauthorized
+	}
+
+	member, err := s.orgRepository.GetOrgMemberByUserPkID(context.Background(), orgPkID, curUser.PkID)
+	if err != nil {
+		if err == domain.ErrOrgMemberNotFound {
+			return domain.ErrPermissionDenied
+		}
+		return err
+	}
+
+	role, ok := domain.OrganizationMemberRoleFromString(member.Role)
+	if !ok {
+		return domain.ErrInvalidOrgMemberRole
+	}
+	if !role.CanAuditSharing() || (role != domain.Owner && member.ActivatedAt == "") {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package page
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+func TestSharingAuditRecords(t *testing.T) {
+	page := &domain.Page{PkID: 7, ID: "page", Name: "=SUM(A1)", ViewType: domain.PageViewTypeDoc}
+	parent := &domain.Page{PkID: 3, ID: "parent"}
+
+	tests := []struct {
+		name  string
+		entry domain.PageSharingAuditEntry
+		want  [][]string
+	}{
+		{
+			name:  "general role only",
+			entry: domain.PageSharingAuditEntry{Page: page, GeneralRole: domain.PageViewer, GeneralRoleFromPage: parent},
+			want: [][]string{
+				{"7", "page", "'=SUM(A1)", "document", "viewer", "parent", "", "", "", "", "", ""},
+			},
+		},
+		{
+			name: "outside roles and share links",
+			entry: domain.PageSharingAuditEntry{
+				Page:        page,
+				GeneralRole: domain.PageRestrict,
+				OutsideRoles: []domain.PageRoleUser{
+					{Email: "guest@example.com", Role: domain.PageEditor},
+				},
+				ShareLinks: []domain.PageSharingAuditLink{
+					{Link: &domain.PageShareLink{ID: "own-link", Role: domain.PageViewer}},
+					{Link: &domain.PageShareLink{ID: "parent-link", Role: domain.PageEditor}, FromPage: parent},
+				},
+			},
+			want: [][]string{
+				{"7", "page", "'=SUM(A1)", "document", "restricted", "", "guest@example.com", "editor", "", "", "", ""},
+				{"7", "page", "'=SUM(A1)", "document", "restricted", "", "", "", "", "own-link", "viewer", ""},
+				{"7", "page", "'=SUM(A1)", "document", "restricted", "", "", "", "", "parent-link", "editor", "parent"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sharingAuditRecords(tt.entry)
+			if !slices.EqualFunc(got, tt.want, slices.Equal[[]string]) {
+				t.Fatalf("sharingAuditRecords() = %q, want %q", got, tt.want)
+			}
+			for _, record := range got {
+				if len(record) != len(sharingAuditCSVHeader) {
+					t.Fatalf("record has %d columns, header has %d", len(record), len(sharingAuditCSVHeader))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package page
+
+import (
+	"context"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	commonutils "github.com/Stuhub-io/utils"
+	"github.com/Stuhub-io/utils/activityutils"
+	"github.com/Stuhub-io/utils/documentutils"
+	"github.com/Stuhub-io/utils/outboxutils"
+	"github.com/Stuhub-io/utils/userutils"
+)
+
+// GetTemplates lists the templates of the organization the user can view.
+func (s *Service) GetTemplates(
+	orgPkID int64,
+	curUser *domain.User,
+) ([]domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	isTemplate := true
+	isArchived := false
+	return s.pageRepository.List(context.Background(), domain.PageListQuery{
+		OrgPkID:    &orgPkID,
+		IsTemplate: &isTemplate,
+		IsArchived: &isArchived,
+		IsAll:      true,
+	}, curUser)
+}
+
+func (s *Service) SetPageTemplate(
+	pagePkID int64,
+	isTemplate bool,
+	curUser *domain.User,
+) (*domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanEdit {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	return s.pageRepository.Update(context.Background(), pagePkID, domain.PageUpdateInput{
+		IsTemplate: &isTemplate,
+	})
+}
+
+// CreatePageFromTemplate instantiates the template with its descendants the user can view,
+// replacing the template variables in page names and document contents.
+func (s *Service) CreatePageFromTemplate(
+	templatePkID int64,
+	input domain.PageDuplicateInput,
+	curUser *domain.User,
+) (*domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	template, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&templatePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !template.IsTemplate {
+		return nil, domain.ErrPageNotTemplate
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), templatePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *template,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanView {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	// A template is instantiated under its own name unless given one
+	if input.Name == nil || *input.Name == "" {
+		input.Name = &template.Name
+	}
+	input.IncludeRoles = false
+	input.Variables = templateVariables(curUser, time.Now())
+
+	return s.duplicatePage(template, input, true, curUser, func(parentPage *domain.Page) outboxutils.EventsBuilder {
+		return func(pages []domain.Page) []domain.OutboxEventInput {
+			page := pages[0]
+			var parentPageName *string
+			if parentPage != nil {
+				parentPageName = &parentPage.Name
+			}
+			metadata := commonutils.ToJsonStr(activityutils.UserCreatePageMeta{
+				ParentPagePkID:   page.ParentPagePkID,
+				ParentPageName:   parentPageName,
+				NewPageName:      page.Name,
+				NewPagePkID:      page.PkID,
+				NewPageID:        page.ID,
+				TemplatePagePkID: &template.PkID,
+			})
+
+			return []domain.OutboxEventInput{outboxutils.ActivityEvent(domain.ActivityInput{
+				ActionCode: domain.ActionUserCreatePage,
+				PagePkID:   &page.PkID,
+				OrgPkID:    &page.OrganizationPkID,
+				ActorPkID:  curUser.PkID,
+				MetaData:   &metadata,
+			})}
+		}
+	})
+}
+
+// templateVariables are the values of the {{variable}} placeholders supported in templates.
+func templateVariables(curUser *domain.User, now time.Time) map[string]string {
+	author := userutils.GetUserFullName(curUser.FirstName, curUser.LastName)
+	if author == "" {
+		author = curUser.Email
+	}
+
+	return map[string]string{
+		documentutils.TemplateVariableDate:   now.Format(time.DateOnly),
+		documentutils.TemplateVariableTime:   now.Format("15:04"),
+		documentutils.TemplateVariableAuthor: author,
+		documentutils.TemplateVariableEmail:  curUser.Email,
+	}
+}
@@ -0,0 +1,203 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	commonutils "github.com/Stuhub-io/utils"
+	"github.com/Stuhub-io/utils/activityutils"
+	"github.com/Stuhub-io/utils/outboxutils"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+// Trash lists the archived pages of the organization, pages archived along with
+// an archived ancestor are not listed on their own.
+func (s *Service) GetTrashPages(
+	orgPkID int64,
+	offset int,
+	limit int,
+	curUser *domain.User,
+) ([]domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	isArchived := true
+	return s.pageRepository.List(context.Background(), domain.PageListQuery{
+		OrgPkID:    &orgPkID,
+		IsArchived: &isArchived,
+		Offset:     offset,
+		Limit:      limit,
+		OrderBy:    domain.PageOrderByUpdatedAt,
+	}, curUser)
+}
+
+func (s *Service) RestorePageByPkID(
+	pagePkID int64,
+	curUser *domain.User,
+) (*domain.Page, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanDelete {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	// The page returns to its original parent when still available, resolved within the restore
+	var parentPageName *string
+	if parentPkIDs := pageutils.PagePathToPkIDs(page.Path); len(parentPkIDs) > 0 {
+		originalParentPkID := parentPkIDs[len(parentPkIDs)-1]
+		if p, pErr := s.pageRepository.GetByID(context.Background(), "", &originalParentPkID, domain.PageDetailOptions{}, nil); pErr == nil {
+			parentPageName = &p.Name
+		}
+	}
+
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.IndexPageTrees)
+	ctx = outboxutils.WithEvents(ctx, func(pages []domain.Page) []domain.OutboxEventInput {
+		restoredPage := pages[0]
+		meta := activityutils.UserRestorePageMeta{
+			ParentPagePkID: restoredPage.ParentPagePkID,
+		}
+		if restoredPage.ParentPagePkID != nil {
+			meta.ParentPageName = parentPageName
+		}
+		metadata := commonutils.ToJsonStr(meta)
+
+		return []domain.OutboxEventInput{outboxutils.ActivityEvent(domain.ActivityInput{
+			ActionCode: domain.ActionUserRestorePage,
+			PagePkID:   &page.PkID,
+			OrgPkID:    &page.OrganizationPkID,
+			ActorPkID:  curUser.PkID,
+			MetaData:   &metadata,
+		})}
+	})
+
+	return s.pageRepository.Restore(ctx, pagePkID)
+}
+
+func (s *Service) DeletePagePermanently(
+	pagePkID int64,
+	curUser *domain.User,
+) *domain.Error {
+	if curUser == nil {
+		return domain.ErrUnauthorized
+	}
+
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	curRole := s.GetPageRolesByUser(context.Background(), pagePkID, curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:     *page,
+		User:     curUser,
+		PageRole: curRole,
+	})
+
+	if !permissions.CanDelete {
+		return domain.ErrPermissionDenied
+	}
+
+	if page.ArchivedAt == "" {
+		return domain.ErrPageNotArchived
+	}
+	if page.ParentPagePkID != nil {
+		return domain.ErrPageNotTrashRoot
+	}
+
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.UnindexPages)
+	return s.pageRepository.Purge(ctx, []int64{pagePkID})
+}
+
+// EmptyTrash permanently deletes the trash pages of the organization the user can delete,
+// returns the purged trash pages.
+func (s *Service) EmptyTrash(
+	orgPkID int64,
+	curUser *domain.User,
+) ([]domain.Page, *domain.Error) {
+	trashPages, err := s.GetTrashPages(orgPkID, 0, 0, curUser)
+	if err != nil {
+		return nil, err
+	}
+
+	deletablePages := sliceutils.Filter(trashPages, func(page domain.Page) bool {
+		return page.Permissions != nil && page.Permissions.CanDelete
+	})
+	if len(deletablePages) == 0 {
+		return []domain.Page{}, nil
+	}
+
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.UnindexPages)
+	if err := s.pageRepository.Purge(
+		ctx,
+		sliceutils.Map(deletablePages, func(page domain.Page) int64 { return page.PkID }),
+	); err != nil {
+		return nil, err
+	}
+
+	return deletablePages, nil
+}
+
+// RunTrashPurge purges expired trash pages periodically until ctx is done.
+func (s *Service) RunTrashPurge(ctx context.Context) {
+	if s.cfg.TrashRetentionDays <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		s.PurgeExpiredTrash()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) PurgeExpiredTrash() {
+	before := time.Now().AddDate(0, 0, -s.cfg.TrashRetentionDays)
+
+	ctx := outboxutils.WithEvents(context.Background(), outboxutils.UnindexPages)
+	count, err := s.pageRepository.PurgeArchivedBefore(ctx, before)
+	if err != nil {
+		s.logger.Error(errors.New(err.Message), "[Trash]: Failed to purge expired trash pages")
+		return
+	}
+
+	if count > 0 {
+		s.logger.Infof("[Trash]: Purged %d expired trash pages", count)
+	}
+}
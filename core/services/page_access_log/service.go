@@ -54,7 +54,8 @@ func (s *Service) GetLogsByUser(
 	)
 
 	permissionsMapper := map[int64]domain.PageRolePermissions{}
-	inheritRolePagesMapper := map[int64]string{}
+	inheritRolePagesMapper := map[int64]domain.Page{}
+	sharedRolesMapper := map[int64]*domain.PageRole{}
 
 	// get permissions for not inherit pages
 	for _, page := range flatPages {
@@ -68,19 +69,21 @@ func (s *Service) GetLogsByUser(
 		)
 		if foundPageInPermission != nil {
 			pageRole = foundPageInPermission.PageRole
+			sharedRolesMapper[page.PkID] = foundPageInPermission.SharedRole
 		}
 
 		if pageRole != nil && pageRole.String() == domain.PageInherit.String() && page.Path != "" {
-			inheritRolePagesMapper[page.PkID] = page.Path
+			inheritRolePagesMapper[page.PkID] = page
 			continue
 		}
 
 		permissions := s.pageRepository.CheckPermission(
 			context.Background(),
 			domain.PageRolePermissionCheckInput{
-				User:     user,
-				Page:     page,
-				PageRole: pageRole,
+				User:       user,
+				Page:       page,
+				PageRole:   pageRole,
+				SharedRole: sharedRolesMapper[page.PkID],
 			},
 		)
 
@@ -102,8 +105,25 @@ func (s *Service) GetLogsByUser(
 		}
 	}
 
-	for pkID, path := range inheritRolePagesMapper {
-		permissionsMapper[pkID] = findInheritPermissions(pageutils.PagePathToPkIDs(path))
+	for pkID, page := range inheritRolePagesMapper {
+		permissions := findInheritPermissions(pageutils.PagePathToPkIDs(page.Path))
+
+		// a shared role applies when it gives more than the inherited direct role
+		if sharedRole := sharedRolesMapper[pkID]; sharedRole != nil && !permissions.CanEdit {
+			sharedPermissions := s.pageRepository.CheckPermission(
+				context.Background(),
+				domain.PageRolePermissionCheckInput{
+					User:       user,
+					Page:       page,
+					SharedRole: sharedRole,
+				},
+			)
+			if sharedPermissions.CanEdit || !permissions.CanView {
+				permissions = sharedPermissions
+			}
+		}
+
+		permissionsMapper[pkID] = permissions
 	}
 
 	// filter logs after checking permission
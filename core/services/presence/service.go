@@ -0,0 +1,199 @@
+package presence
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/ports"
+	"github.com/Stuhub-io/logger"
+)
+
+// A session without heartbeat for presenceTTL is considered gone.
+const presenceTTL = 30 * time.Second
+
+type Listener func(event domain.PresenceEvent)
+
+type Service struct {
+	cfg            config.Config
+	logger         logger.Logger
+	pageRepository ports.PageRepository
+	cacheStore     ports.CacheStore
+
+	mu        sync.Mutex
+	listeners []Listener
+}
+
+type NewServiceParams struct {
+	config.Config
+	logger.Logger
+	ports.PageRepository
+	ports.CacheStore
+}
+
+func NewService(params NewServiceParams) *Service {
+	return &Service{
+		cfg:            params.Config,
+		logger:         params.Logger,
+		pageRepository: params.PageRepository,
+		cacheStore:     params.CacheStore,
+		listeners:      []Listener{},
+	}
+}
+
+// Subscribe registers a listener for join/leave/update events.
+func (s *Service) Subscribe(listener Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *Service) GetPagePresences(pagePkID int64, curUser *domain.User) ([]domain.PagePresence, *domain.Error) {
+	if err := s.checkCanView(pagePkID, curUser); err != nil {
+		return nil, err
+	}
+
+	return s.activePresences(pagePkID, time.Now()), nil
+}
+
+func (s *Service) Heartbeat(
+	input domain.PresenceHeartbeatInput,
+	curUser *domain.User,
+) ([]domain.PagePresence, *domain.Error) {
+	if err := s.checkCanView(input.PagePkID, curUser); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	events := []domain.PresenceEvent{}
+
+	// Only this session's field is written, other sessions of the page are left untouched.
+	presence := s.cacheStore.GetPagePresence(input.PagePkID, curUser.PkID, input.SessionID)
+	if presence == nil {
+		presence = &domain.PagePresence{
+			SessionID:  input.SessionID,
+			PagePkID:   input.PagePkID,
+			User:       curUser,
+			Cursor:     input.Cursor,
+			JoinedAt:   now,
+			LastSeenAt: now,
+		}
+		events = append(events, domain.PresenceEvent{Type: domain.PresenceJoined, PagePkID: input.PagePkID, Presence: *presence})
+	} else {
+		cursorChanged := input.Cursor != nil && !bytes.Equal(presence.Cursor, input.Cursor)
+		presence.LastSeenAt = now
+		if cursorChanged {
+			presence.Cursor = input.Cursor
+			events = append(events, domain.PresenceEvent{Type: domain.PresenceUpdated, PagePkID: input.PagePkID, Presence: *presence})
+		}
+	}
+
+	s.cacheStore.SetPagePresence(*presence, presenceTTL*2)
+
+	s.emit(events...)
+
+	return s.activePresences(input.PagePkID, now), nil
+}
+
+func (s *Service) Leave(pagePkID int64, sessionID string, curUser *domain.User) *domain.Error {
+	if curUser == nil {
+		return domain.ErrUnauthorized
+	}
+
+	if presence := s.cacheStore.DeletePagePresence(pagePkID, curUser.PkID, sessionID); presence != nil {
+		s.emit(domain.PresenceEvent{Type: domain.PresenceLeft, PagePkID: pagePkID, Presence: *presence})
+	}
+
+	return nil
+}
+
+// Run sweeps expired sessions of all pages until ctx is done.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(presenceTTL / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep()
+		}
+	}
+}
+
+// Every instance sweeps, each expired session is removed and announced by exactly one of them.
+func (s *Service) sweep() {
+	events := []domain.PresenceEvent{}
+	for _, p := range s.cacheStore.DeleteExpiredPagePresences(time.Now().Add(-presenceTTL)) {
+		events = append(events, domain.PresenceEvent{Type: domain.PresenceLeft, PagePkID: p.PagePkID, Presence: p})
+	}
+
+	s.emit(events...)
+}
+
+// activePresences lists the cached sessions of the page that are not expired yet.
+func (s *Service) activePresences(pagePkID int64, now time.Time) []domain.PagePresence {
+	presences := []domain.PagePresence{}
+	for _, p := range s.cacheStore.GetPagePresences(pagePkID) {
+		if now.Sub(p.LastSeenAt) <= presenceTTL {
+			presences = append(presences, p)
+		}
+	}
+	slices.SortFunc(presences, func(a, b domain.PagePresence) int {
+		return a.JoinedAt.Compare(b.JoinedAt)
+	})
+	return presences
+}
+
+func (s *Service) emit(events ...domain.PresenceEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	listeners := append([]Listener{}, s.listeners...)
+	s.mu.Unlock()
+
+	for _, event := range events {
+		for _, listener := range listeners {
+			listener(event)
+		}
+	}
+}
+
+// Viewer identities are only visible to users who can view the page themselves.
+func (s *Service) checkCanView(pagePkID int64, curUser *domain.User) *domain.Error {
+	if curUser == nil {
+		return domain.ErrUnauthorized
+	}
+
+	page, err := s.pageRepository.GetByID(
+		context.Background(),
+		"",
+		&pagePkID,
+		domain.PageDetailOptions{},
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	curRole, sharedRole := s.pageRepository.GetUserPageRoles(context.Background(), pagePkID, *curUser)
+	permissions := s.pageRepository.CheckPermission(context.Background(), domain.PageRolePermissionCheckInput{
+		Page:       *page,
+		User:       curUser,
+		PageRole:   curRole,
+		SharedRole: sharedRole,
+	})
+
+	if !permissions.CanView {
+		return domain.ErrPermissionDenied
+	}
+
+	return nil
+}
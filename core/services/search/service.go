@@ -0,0 +1,86 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/ports"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+type Service struct {
+	pageRepository ports.PageRepository
+	pageSearcher   ports.PageSearcher
+}
+
+type NewServiceParams struct {
+	ports.PageRepository
+	ports.PageSearcher
+}
+
+func NewService(params NewServiceParams) *Service {
+	return &Service{
+		pageRepository: params.PageRepository,
+		pageSearcher:   params.PageSearcher,
+	}
+}
+
+// SearchPages returns the matching pages of the organization the user can view, ordered by relevance.
+// Hits are filtered after the search, so a result page may hold fewer than query.Limit pages.
+func (s *Service) SearchPages(
+	query domain.PageSearchQuery,
+	curUser *domain.User,
+) ([]domain.PageSearchResult, *domain.Error) {
+	if curUser == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return []domain.PageSearchResult{}, nil
+	}
+
+	hits, err := s.pageSearcher.Search(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return []domain.PageSearchResult{}, nil
+	}
+
+	// List resolves the user's permissions through CheckPermission and only keeps viewable pages,
+	// the database is the source of truth for pages archived or moved since they were indexed
+	isArchived := false
+	pages, err := s.pageRepository.List(context.Background(), domain.PageListQuery{
+		OrgPkID:    &query.OrgPkID,
+		PagePkIDs:  sliceutils.Map(hits, func(hit domain.PageSearchHit) int64 { return hit.PagePkID }),
+		IsArchived: &isArchived,
+		IsAll:      true,
+	}, curUser)
+	if err != nil {
+		return nil, err
+	}
+
+	viewablePages := make(map[int64]domain.Page, len(pages))
+	for _, page := range pages {
+		if page.Permissions != nil && page.Permissions.CanView {
+			viewablePages[page.PkID] = page
+		}
+	}
+
+	results := make([]domain.PageSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		page, ok := viewablePages[hit.PagePkID]
+		if !ok {
+			continue
+		}
+		results = append(results, domain.PageSearchResult{
+			Page:       page,
+			Score:      hit.Score,
+			Highlights: hit.Highlights,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/services/collaboration"
+	"github.com/Stuhub-io/internal/api/decorators"
+	"github.com/Stuhub-io/internal/api/middleware"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	liveWriteWait      = 10 * time.Second
+	livePongWait       = 60 * time.Second
+	livePingPeriod     = (livePongWait * 9) / 10
+	liveMaxMessageSize = 4 << 20
+)
+
+type CollaborationHandler struct {
+	collaborationService *collaboration.Service
+	upgrader             websocket.Upgrader
+}
+
+type NewCollaborationHandlerParams struct {
+	Router               *gin.RouterGroup
+	AuthMiddleware       *middleware.AuthMiddleware
+	CollaborationService *collaboration.Service
+	AllowedOrigins       []string
+}
+
+func UseCollaborationHandler(params NewCollaborationHandlerParams) {
+	handler := &CollaborationHandler{
+		collaborationService: params.CollaborationService,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Echo the marker, never the token, back as the selected subprotocol.
+			Subprotocols: []string{middleware.WebSocketTokenProtocol},
+			CheckOrigin:  liveOriginChecker(params.AllowedOrigins),
+		},
+	}
+	router := params.Router.Group("/page-services")
+	authMiddleware := params.AuthMiddleware
+
+	router.Use(authMiddleware.AuthenticatedWebSocket())
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/live",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.LiveDocument)),
+	)
+}
+
+func (h *CollaborationHandler) LiveDocument(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	client, err := h.collaborationService.Join(pagePkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	conn, uerr := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if uerr != nil {
+		h.collaborationService.Leave(client)
+		return
+	}
+
+	go h.writeLive(conn, client)
+	h.readLive(conn, client)
+}
+
+// Browsers send the Origin of cross site handshakes, only the configured CORS origins may connect.
+// Requests without Origin do not come from a browser page.
+func liveOriginChecker(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		return slices.ContainsFunc(allowedOrigins, func(allowed string) bool {
+			return allowed == "*" || strings.EqualFold(allowed, origin)
+		})
+	}
+}
+
+func (h *CollaborationHandler) readLive(conn *websocket.Conn, client *collaboration.Client) {
+	defer func() {
+		h.collaborationService.Leave(client)
+		conn.Close()
+	}()
+
+	conn.SetReadLimit(liveMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(livePongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(livePongWait))
+	})
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		h.collaborationService.Receive(client, message)
+	}
+}
+
+func (h *CollaborationHandler) writeLive(conn *websocket.Conn, client *collaboration.Client) {
+	ticker := time.NewTicker(livePingPeriod)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-client.Outbound():
+			_ = conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+			if !ok {
+				_ = conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -2,12 +2,19 @@ package middleware
 
 import (
 	"context"
+	"net/http"
+	"slices"
 
 	"github.com/Stuhub-io/core/ports"
 	"github.com/Stuhub-io/utils/authutils"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 )
 
+// WebSocketTokenProtocol is the Sec-WebSocket-Protocol entry followed by the access token,
+// e.g. new WebSocket(url, ["access_token", token]). Unlike the query string it is not logged.
+const WebSocketTokenProtocol = "access_token"
+
 type AuthMiddleware struct {
 	tokenMaker     ports.TokenMaker
 	userRepository ports.UserRepository
@@ -27,27 +34,49 @@ func NewAuthMiddleware(params NewAuthMiddlewareParams) *AuthMiddleware {
 
 func (a *AuthMiddleware) Authenticated() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token, err := authutils.ExtractBearerToken(c.GetHeader("Authorization"))
-		if err != nil {
-			c.Next()
-			return
-		}
+		a.authenticate(c, c.GetHeader("Authorization"))
+		c.Next()
+	}
+}
 
-		payload, err := a.tokenMaker.DecodeToken(token)
-		if err != nil {
-			c.Next()
-			return
+// AuthenticatedWebSocket also accepts the token from the Sec-WebSocket-Protocol header,
+// browsers can not set the Authorization header on WebSocket handshakes.
+func (a *AuthMiddleware) AuthenticatedWebSocket() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorization := c.GetHeader("Authorization")
+		if token := webSocketProtocolToken(c.Request); authorization == "" && token != "" {
+			authorization = "Bearer " + token
 		}
+		a.authenticate(c, authorization)
+		c.Next()
+	}
+}
 
-		user, dbErr := a.userRepository.GetUserByPkID(context.Background(), payload.UserPkID)
+func webSocketProtocolToken(r *http.Request) string {
+	protocols := websocket.Subprotocols(r)
+	idx := slices.Index(protocols, WebSocketTokenProtocol)
+	if idx == -1 || idx+1 >= len(protocols) {
+		return ""
+	}
+	return protocols[idx+1]
+}
 
-		if dbErr != nil {
-			c.Next()
-			return
-		}
+func (a *AuthMiddleware) authenticate(c *gin.Context, authorization string) {
+	token, err := authutils.ExtractBearerToken(authorization)
+	if err != nil {
+		return
+	}
 
-		c.Set(string(authutils.UserPayloadKey), user)
+	payload, err := a.tokenMaker.DecodeToken(token)
+	if err != nil {
+		return
+	}
 
-		c.Next()
+	user, dbErr := a.userRepository.GetUserByPkID(context.Background(), payload.UserPkID)
+
+	if dbErr != nil {
+		return
 	}
+
+	c.Set(string(authutils.UserPayloadKey), user)
 }
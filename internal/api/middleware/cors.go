@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/utils/pageutils"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
@@ -16,7 +17,9 @@ func CORS(cfg *config.Config) gin.HandlerFunc {
 				AllowHeaders: []string{
 					"Origin", "Host", "Content-Type", "Content-Length", "Accept-Encoding", "Accept-Language", "Accept",
 					"X-CSRF-Token", "Authorization", "X-Requested-With", "X-Access-Token", "credentials",
+					"If-Match",
 				},
+				ExposeHeaders:    []string{"ETag", pageutils.DocumentETagHeader},
 				AllowCredentials: true,
 			},
 		)(c)
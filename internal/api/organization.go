@@ -11,6 +11,7 @@ import (
 	"github.com/Stuhub-io/internal/api/request"
 	"github.com/Stuhub-io/internal/api/response"
 	"github.com/Stuhub-io/utils/organization_inviteutils"
+	"github.com/Stuhub-io/utils/organizationutils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -40,6 +41,36 @@ func UseOrganizationHandler(params NewOrganizationHandlerParams) {
 	router.POST("/invite-by-emails", decorators.CurrentUser(handler.InviteMembersByEmail))
 	router.GET(path.Join("/invite-details", ":"+organization_inviteutils.InviteIDParam), handler.GetInviteDetails)
 	router.POST("/invite-validate", decorators.CurrentUser(handler.ValidateOrgInvitation))
+
+	// admin console
+	router.PUT("/:"+organizationutils.OrgPkIDParam, decorators.CurrentUser(handler.UpdateOrganization))
+	router.GET("/:"+organizationutils.OrgPkIDParam+"/members", decorators.CurrentUser(handler.GetMembers))
+	router.PUT(
+		"/:"+organizationutils.OrgPkIDParam+"/members/:"+organizationutils.MemberPkIDParam,
+		decorators.CurrentUser(handler.UpdateMemberRole),
+	)
+	router.DELETE(
+		"/:"+organizationutils.OrgPkIDParam+"/members/:"+organizationutils.MemberPkIDParam,
+		decorators.CurrentUser(handler.RemoveMember),
+	)
+	router.POST(
+		"/:"+organizationutils.OrgPkIDParam+"/transfer-ownership",
+		decorators.CurrentUser(handler.TransferOwnership),
+	)
+
+	// groups
+	groupPath := "/:" + organizationutils.OrgPkIDParam + "/groups"
+	groupDetailPath := groupPath + "/:" + organizationutils.GroupIDParam
+	router.GET(groupPath, decorators.CurrentUser(handler.GetGroups))
+	router.POST(groupPath, decorators.CurrentUser(handler.CreateGroup))
+	router.GET(groupDetailPath, decorators.CurrentUser(handler.GetGroup))
+	router.PUT(groupDetailPath, decorators.CurrentUser(handler.UpdateGroup))
+	router.DELETE(groupDetailPath, decorators.CurrentUser(handler.DeleteGroup))
+	router.POST(groupDetailPath+"/members", decorators.CurrentUser(handler.AddGroupMembers))
+	router.DELETE(
+		groupDetailPath+"/members/:"+organizationutils.MemberPkIDParam,
+		decorators.CurrentUser(handler.RemoveGroupMember),
+	)
 }
 
 func (h *OrganizationHandler) CreateOrganization(c *gin.Context, user *domain.User) {
@@ -114,7 +145,7 @@ func (h *OrganizationHandler) InviteMembersByEmail(c *gin.Context, user *domain.
 	}
 
 	data, err := h.orgService.InviteMemberByEmails(organization.InviteMemberByEmailsDto{
-		Owner:       user,
+		Inviter:     user,
 		OrgInfo:     params.OrgInfo,
 		InviteInfos: params.Infos,
 	})
@@ -144,3 +175,132 @@ func (h *OrganizationHandler) ValidateOrgInvitation(c *gin.Context, user *domain
 
 	response.WithData(c, http.StatusOK, data, "Invitation validated successfully!")
 }
+
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+
+	var body request.UpdateOrgBody
+	if vr := request.Validate(c, &body); vr != nil {
+		response.BindError(c, vr.Error())
+		return
+	}
+
+	data, err := h.orgService.UpdateOrganization(organization.UpdateOrganizationDto{
+		Actor:   user,
+		OrgPkID: orgPkID,
+		Input: domain.OrganizationUpdateInput{
+			Name:        body.Name,
+			Description: body.Description,
+			Avatar:      body.Avatar,
+		},
+	})
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Success")
+}
+
+func (h *OrganizationHandler) GetMembers(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+
+	data, err := h.orgService.GetMembers(orgPkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Success")
+}
+
+func (h *OrganizationHandler) UpdateMemberRole(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+	memberPkID, valid := organizationutils.GetMemberPkIDParam(c)
+	if !valid {
+		response.BindError(c, "memberPkID is missing or invalid")
+		return
+	}
+
+	var body request.UpdateOrgMemberRoleBody
+	if vr := request.Validate(c, &body); vr != nil {
+		response.BindError(c, vr.Error())
+		return
+	}
+
+	data, err := h.orgService.UpdateMemberRole(organization.UpdateMemberRoleDto{
+		Actor:      user,
+		OrgPkID:    orgPkID,
+		MemberPkID: memberPkID,
+		Role:       body.Role,
+	})
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Success")
+}
+
+func (h *OrganizationHandler) RemoveMember(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+	memberPkID, valid := organizationutils.GetMemberPkIDParam(c)
+	if !valid {
+		response.BindError(c, "memberPkID is missing or invalid")
+		return
+	}
+
+	err := h.orgService.RemoveMember(organization.RemoveMemberDto{
+		Actor:      user,
+		OrgPkID:    orgPkID,
+		MemberPkID: memberPkID,
+	})
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, nil, "Member removed")
+}
+
+func (h *OrganizationHandler) TransferOwnership(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+
+	var body request.TransferOrgOwnershipBody
+	if vr := request.Validate(c, &body); vr != nil {
+		response.BindError(c, vr.Error())
+		return
+	}
+
+	data, err := h.orgService.TransferOwnership(organization.TransferOwnershipDto{
+		Owner:        user,
+		OrgPkID:      orgPkID,
+		NewOwnerPkID: body.UserPkID,
+	})
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Ownership transferred")
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/services/organization"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/organizationutils"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *OrganizationHandler) GetGroups(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+
+	data, err := h.orgService.GetGroups(orgPkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Success")
+}
+
+func (h *OrganizationHandler) GetGroup(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+	groupID, valid := organizationutils.GetGroupIDParam(c)
+	if !valid {
+		response.BindError(c, "groupID is missing or invalid")
+		return
+	}
+
+	data, err := h.orgService.GetGroup(orgPkID, groupID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Success")
+}
+
+func (h *OrganizationHandler) CreateGroup(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+
+	var body request.CreateOrgGroupBody
+	if vr := request.Validate(c, &body); vr != nil {
+		response.BindError(c, vr.Error())
+		return
+	}
+
+	data, err := h.orgService.CreateGroup(organization.CreateGroupDto{
+		Actor:   user,
+		OrgPkID: orgPkID,
+		Input: domain.OrgGroupInput{
+			Name:        body.Name,
+			Description: body.Description,
+		},
+	})
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusCreated, data, "Success")
+}
+
+func (h *OrganizationHandler) UpdateGroup(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+	groupID, valid := organizationutils.GetGroupIDParam(c)
+	if !valid {
+		response.BindError(c, "groupID is missing or invalid")
+		return
+	}
+
+	var body request.UpdateOrgGroupBody
+	if vr := request.Validate(c, &body); vr != nil {
+		response.BindError(c, vr.Error())
+		return
+	}
+
+	data, err := h.orgService.UpdateGroup(organization.UpdateGroupDto{
+		Actor:   user,
+		OrgPkID: orgPkID,
+		GroupID: groupID,
+		Input: domain.OrgGroupUpdateInput{
+			Name:        body.Name,
+			Description: body.Description,
+		},
+	})
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Success")
+}
+
+func (h *OrganizationHandler) DeleteGroup(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+	groupID, valid := organizationutils.GetGroupIDParam(c)
+	if !valid {
+		response.BindError(c, "groupID is missing or invalid")
+		return
+	}
+
+	if err := h.orgService.DeleteGroup(orgPkID, groupID, user); err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, nil, "Group deleted")
+}
+
+func (h *OrganizationHandler) AddGroupMembers(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+	groupID, valid := organizationutils.GetGroupIDParam(c)
+	if !valid {
+		response.BindError(c, "groupID is missing or invalid")
+		return
+	}
+
+	var body request.AddOrgGroupMembersBody
+	if vr := request.Validate(c, &body); vr != nil {
+		response.BindError(c, vr.Error())
+		return
+	}
+
+	data, err := h.orgService.AddGroupMembers(organization.AddGroupMembersDto{
+		Actor:       user,
+		OrgPkID:     orgPkID,
+		GroupID:     groupID,
+		MemberPkIDs: body.UserPkIDs,
+	})
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Success")
+}
+
+func (h *OrganizationHandler) RemoveGroupMember(c *gin.Context, user *domain.User) {
+	orgPkID, valid := organizationutils.GetOrgPkIDParam(c)
+	if !valid {
+		response.BindError(c, "orgPkID is missing or invalid")
+		return
+	}
+	groupID, valid := organizationutils.GetGroupIDParam(c)
+	if !valid {
+		response.BindError(c, "groupID is missing or invalid")
+		return
+	}
+	memberPkID, valid := organizationutils.GetMemberPkIDParam(c)
+	if !valid {
+		response.BindError(c, "memberPkID is missing or invalid")
+		return
+	}
+
+	data, err := h.orgService.RemoveGroupMember(organization.RemoveGroupMemberDto{
+		Actor:      user,
+		OrgPkID:    orgPkID,
+		GroupID:    groupID,
+		MemberPkID: memberPkID,
+	})
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, http.StatusOK, data, "Success")
+}
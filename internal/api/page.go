@@ -8,6 +8,7 @@ import (
 	"github.com/Stuhub-io/internal/api/request"
 	"github.com/Stuhub-io/internal/api/response"
 	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/Stuhub-io/utils/renderutils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -41,18 +42,147 @@ func UsePageHandle(params NewPageHandlerParams) {
 		decorators.CurrentUser(handler.UpdatePageContent),
 	)
 	router.PUT("/pages/:"+pageutils.PagePkIDParam+"/move", decorators.CurrentUser(handler.MovePage))
+	router.PUT(
+		"/pages/:"+pageutils.PagePkIDParam+"/reorder",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.ReorderPage)),
+	)
+
+	// document revisions
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/revisions",
+		decorators.CurrentUser(handler.ListDocumentRevisions),
+	)
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/revisions/diff",
+		decorators.CurrentUser(handler.DiffDocumentRevisions),
+	)
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/revisions/:"+pageutils.RevisionPkIDParam,
+		decorators.CurrentUser(handler.GetDocumentRevision),
+	)
+	router.POST(
+		"/pages/:"+pageutils.PagePkIDParam+"/revisions/:"+pageutils.RevisionPkIDParam+"/restore",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.RestoreDocumentRevision)),
+	)
 	router.DELETE("/pages/:"+pageutils.PagePkIDParam, decorators.CurrentUser(handler.ArchivePage))
 
-	// public page
+	// trash
+	router.GET("/pages/trash", decorators.RequiredAuth(decorators.CurrentUser(handler.GetTrashPages)))
+	router.DELETE("/pages/trash", decorators.RequiredAuth(decorators.CurrentUser(handler.EmptyTrash)))
 	router.POST(
-		"pages/id/:"+pageutils.PageIDParam+"/public-token",
-		decorators.CurrentUser(handler.CreatePagePublicToken),
+		"/pages/:"+pageutils.PagePkIDParam+"/restore",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.RestorePage)),
 	)
 	router.DELETE(
-		"pages/id/:"+pageutils.PageIDParam+"/public-token",
-		decorators.CurrentUser(handler.ArchiveAllPagePublicToken),
+		"/pages/:"+pageutils.PagePkIDParam+"/permanent",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.DeletePagePermanently)),
+	)
+
+	router.POST(
+		"/pages/:"+pageutils.PagePkIDParam+"/duplicate",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.DuplicatePage)),
+	)
+
+	// bulk
+	router.POST("/pages/bulk/move", decorators.RequiredAuth(decorators.CurrentUser(handler.BulkMovePages)))
+	router.POST("/pages/bulk/archive", decorators.RequiredAuth(decorators.CurrentUser(handler.BulkArchivePages)))
+	router.POST("/pages/bulk/restore", decorators.RequiredAuth(decorators.CurrentUser(handler.BulkRestorePages)))
+	router.POST("/pages/bulk/star", decorators.RequiredAuth(decorators.CurrentUser(handler.BulkStarPages)))
+	router.POST("/pages/bulk/unstar", decorators.RequiredAuth(decorators.CurrentUser(handler.BulkUnstarPages)))
+	router.POST(
+		"/pages/bulk/general-access",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.BulkUpdateGeneralAccess)),
+	)
+
+	// properties
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/property-definitions",
+		decorators.CurrentUser(handler.ListPropertyDefinitions),
+	)
+	router.POST(
+		"/pages/:"+pageutils.PagePkIDParam+"/property-definitions",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.CreatePropertyDefinition)),
+	)
+	router.PUT(
+		"/pages/:"+pageutils.PagePkIDParam+"/property-definitions/:"+pageutils.PropertyIDParam,
+		decorators.RequiredAuth(decorators.CurrentUser(handler.UpdatePropertyDefinition)),
+	)
+	router.DELETE(
+		"/pages/:"+pageutils.PagePkIDParam+"/property-definitions/:"+pageutils.PropertyIDParam,
+		decorators.RequiredAuth(decorators.CurrentUser(handler.DeletePropertyDefinition)),
+	)
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/properties",
+		decorators.CurrentUser(handler.GetPageProperties),
+	)
+	router.PUT(
+		"/pages/:"+pageutils.PagePkIDParam+"/properties/:"+pageutils.PropertyIDParam,
+		decorators.CurrentUser(handler.SetPagePropertyValue),
+	)
+
+	// links
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/backlinks",
+		decorators.CurrentUser(handler.GetBacklinks),
+	)
+	router.GET("/pages/links", decorators.RequiredAuth(decorators.CurrentUser(handler.GetPageLinkGraph)))
+
+	// import
+	router.POST("/pages/import", decorators.RequiredAuth(decorators.CurrentUser(handler.ImportPages)))
+
+	// export
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/export.pdf",
+		decorators.CurrentUser(handler.ExportPagePDF),
+	)
+	router.POST(
+		"/pages/:"+pageutils.PagePkIDParam+"/exports",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.RequestPageExport)),
+	)
+	router.GET(
+		"/pages/exports/:"+pageutils.ExportIDParam,
+		decorators.RequiredAuth(decorators.CurrentUser(handler.GetPageExport)),
+	)
+	router.GET(
+		"/pages/exports/:"+pageutils.ExportIDParam+"/download",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.DownloadPageExport)),
+	)
+
+	// sharing audit
+	router.GET("/pages/sharing-audit", decorators.RequiredAuth(decorators.CurrentUser(handler.GetSharingAudit)))
+
+	// templates
+	router.GET("/pages/templates", decorators.RequiredAuth(decorators.CurrentUser(handler.GetTemplates)))
+	router.PUT(
+		"/pages/:"+pageutils.PagePkIDParam+"/template",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.UpdatePageTemplate)),
+	)
+	router.POST(
+		"/pages/:"+pageutils.PagePkIDParam+"/instantiate",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.CreatePageFromTemplate)),
+	)
+
+	// share links
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/share-links",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.GetShareLinks)),
+	)
+	router.POST(
+		"/pages/:"+pageutils.PagePkIDParam+"/share-links",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.CreateShareLink)),
+	)
+	router.DELETE(
+		"/pages/:"+pageutils.PagePkIDParam+"/share-links/:"+pageutils.ShareLinkIDParam,
+		decorators.RequiredAuth(decorators.CurrentUser(handler.RevokeShareLink)),
+	)
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/share-links/:"+pageutils.ShareLinkIDParam+"/accesses",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.GetShareLinkAccesses)),
+	)
+	router.POST(
+		"/pages/share-links/:"+pageutils.ShareLinkIDParam+"/open",
+		decorators.CurrentUser(handler.OpenShareLink),
 	)
-	router.GET("pages/public-token/:"+pageutils.PublicTokenIDParam, handler.GetPageByToken)
 
 	// asssets
 	router.POST("pages/assets", decorators.CurrentUser(handler.CreateAsset))
@@ -70,7 +200,7 @@ func UsePageHandle(params NewPageHandlerParams) {
 		("/pages/:" + pageutils.PagePkIDParam + "/roles"),
 		decorators.CurrentUser(handler.GetAllRoleUsers),
 	)
-	router.PATCH(
+	router.PUT(
 		("/pages/:" + pageutils.PagePkIDParam + "/roles"),
 		decorators.CurrentUser(handler.UpdatePageRoleUser),
 	)
@@ -78,6 +208,10 @@ func UsePageHandle(params NewPageHandlerParams) {
 		("/pages/:" + pageutils.PagePkIDParam + "/roles"),
 		decorators.CurrentUser(handler.DeletePageRoleUser),
 	)
+	router.GET(
+		("/pages/:" + pageutils.PagePkIDParam + "/permissions/explain"),
+		decorators.RequiredAuth(decorators.CurrentUser(handler.ExplainPagePermissions)),
+	)
 
 	// page role requests
 	router.POST(
@@ -115,12 +249,32 @@ func (h *PageHandler) GetPage(c *gin.Context, user *domain.User) {
 		return
 	}
 
-	page, err := h.pageService.GetPageDetailByID(pageID, "", user)
+	var query request.GetPageQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	if query.Format != "" {
+		rendered, err := h.pageService.RenderPageDocument(pageID, query.Format, user)
+		if err != nil {
+			response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+			return
+		}
+		c.Data(200, renderutils.ContentType(query.Format), []byte(rendered))
+		return
+	}
+
+	page, err := h.pageService.GetPageDetailByID(pageID, user)
 	if err != nil {
 		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
 		return
 	}
 
+	pageutils.SetETagVersion(c, page.Version)
+	if page.Document != nil {
+		pageutils.SetDocumentETagHeader(c, page.Document.Version)
+	}
 	response.WithData(c, 200, page)
 }
 
@@ -137,6 +291,12 @@ func (h *PageHandler) GetPages(c *gin.Context, user *domain.User) {
 		starredByUserPkID = &user.PkID
 	}
 
+	propertyConditions, propertySort, err := h.resolvePropertyQuery(query)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
 	pages, err := h.pageService.GetPagesByOrgPkID(domain.PageListQuery{
 		OrgPkID:             &query.OrgPkID,
 		ViewTypes:           query.ViewTypes,
@@ -147,6 +307,12 @@ func (h *PageHandler) GetPages(c *gin.Context, user *domain.User) {
 		IsArchived:          query.IsArchived,
 		GeneralRole:         query.GeneralRole,
 		IsStarredByUserPkID: starredByUserPkID,
+		IsTemplate:          query.IsTemplate,
+		OrderBy:             query.OrderBy,
+		OrderDirection:      query.OrderDirection,
+		PropertyConditions:  propertyConditions,
+		PropertySort:        propertySort,
+		WithProperties:      query.WithProperties,
 	}, user)
 
 	if err != nil {
@@ -181,7 +347,9 @@ func (h *PageHandler) CreateDocument(c *gin.Context, user *domain.User) {
 			AuthorPkID:       user.PkID,
 			OrganizationPkID: body.OrgPkID,
 		},
-		Document: domain.DocumentInput(body.Document),
+		Document: domain.DocumentInput{
+			JsonContent: body.Document.JsonContent,
+		},
 	}, user)
 
 	if err != nil {
@@ -205,6 +373,12 @@ func (h *PageHandler) UpdatePage(c *gin.Context, user *domain.User) {
 		return
 	}
 
+	version, ok := pageutils.GetIfMatchVersion(c)
+	if !ok {
+		response.BindError(c, "If-Match header is invalid")
+		return
+	}
+
 	if body.Document != nil && body.Document.JsonContent == "" {
 		body.Document.JsonContent = "{}"
 	}
@@ -214,8 +388,12 @@ func (h *PageHandler) UpdatePage(c *gin.Context, user *domain.User) {
 		ViewType:   body.ViewType,
 		CoverImage: body.CoverImage,
 		Document:   body.Document,
+		Version:    version,
 	}, user)
 
+	if document != nil {
+		pageutils.SetETagVersion(c, document.Version)
+	}
 	if err != nil {
 		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
 		return
@@ -235,9 +413,19 @@ func (h *PageHandler) UpdatePageContent(c *gin.Context, user *domain.User) {
 		return
 	}
 
+	version, ok := pageutils.GetIfMatchDocumentVersion(c)
+	if !ok {
+		response.BindError(c, "If-Match header is invalid")
+		return
+	}
+
 	document, err := h.pageService.UpdateDocumentContentByPkID(pagePkID, domain.DocumentInput{
 		JsonContent: body.JsonContent,
+		Version:     version,
 	}, user)
+	if document != nil && document.Document != nil {
+		pageutils.SetDocumentETagVersion(c, document.Document.Version)
+	}
 	if err != nil {
 		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
 		return
@@ -281,6 +469,30 @@ func (h *PageHandler) MovePage(c *gin.Context, user *domain.User) {
 	response.WithData(c, 200, page)
 }
 
+func (h *PageHandler) ReorderPage(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var body request.ReorderPageBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	page, err := h.pageService.ReorderPageByPkID(pagePkID, domain.PageReorderInput{
+		BeforePagePkID: body.BeforePagePkID,
+		AfterPagePkID:  body.AfterPagePkID,
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+	response.WithData(c, 200, page)
+}
+
 // Assets
 
 func (h *PageHandler) CreateAsset(c *gin.Context, user *domain.User) {
@@ -316,39 +528,6 @@ func (h *PageHandler) CreateAsset(c *gin.Context, user *domain.User) {
 	response.WithData(c, 200, page)
 }
 
-// public Page.
-func (h *PageHandler) CreatePagePublicToken(c *gin.Context, user *domain.User) {
-	pageID, ok := pageutils.GetPageIDParam(c)
-	if !ok {
-		response.BindError(c, "pageID is missing or invalid")
-		return
-	}
-
-	token, err := h.pageService.CreatePublicPageToken(pageID)
-	if err != nil {
-		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
-		return
-	}
-
-	response.WithData(c, 200, token)
-}
-
-func (h *PageHandler) ArchiveAllPagePublicToken(c *gin.Context, user *domain.User) {
-	pageID, ok := pageutils.GetPageIDParam(c)
-	if !ok {
-		response.BindError(c, "pageID is missing or invalid")
-		return
-	}
-
-	err := h.pageService.ArchiveAllPublicPageToken(pageID)
-	if err != nil {
-		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
-		return
-	}
-
-	response.WithData(c, 200, nil)
-}
-
 func (h *PageHandler) UpdatePageGeneralAccess(c *gin.Context, user *domain.User) {
 	pagePkID, ok := pageutils.GetPagePkIDParam(c)
 	if !ok {
@@ -375,23 +554,6 @@ func (h *PageHandler) UpdatePageGeneralAccess(c *gin.Context, user *domain.User)
 	response.WithData(c, 200, page)
 }
 
-func (h *PageHandler) GetPageByToken(c *gin.Context) {
-	tokenID, ok := pageutils.GetPublicTokenIDParam(c)
-	if !ok {
-		response.BindError(c, "token is missing or invalid")
-		return
-	}
-
-	page, err := h.pageService.GetPageDetailByID("", tokenID, nil)
-
-	if err != nil {
-		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
-		return
-	}
-
-	response.WithData(c, 200, page)
-}
-
 // Page Roles.
 func (h *PageHandler) AddPageRoleUser(c *gin.Context, user *domain.User) {
 	pagePkID, ok := pageutils.GetPagePkIDParam(c)
@@ -409,6 +571,7 @@ func (h *PageHandler) AddPageRoleUser(c *gin.Context, user *domain.User) {
 	pageRole, _, err := h.pageService.AddPageRoleUser(domain.PageRoleCreateInput{
 		PagePkID: pagePkID,
 		Email:    body.Email,
+		GroupID:  body.GroupID,
 		Role:     body.Role,
 	}, user)
 
@@ -440,6 +603,28 @@ func (h *PageHandler) GetAllRoleUsers(c *gin.Context, user *domain.User) {
 	response.WithData(c, 200, roles)
 }
 
+func (h *PageHandler) ExplainPagePermissions(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var query request.ExplainPagePermissionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.BindError(c, err.Error())
+		return
+	}
+
+	explanation, err := h.pageService.ExplainPagePermissions(pagePkID, query.Email, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, explanation)
+}
+
 func (h *PageHandler) UpdatePageRoleUser(c *gin.Context, user *domain.User) {
 	pagePkID, ok := pageutils.GetPagePkIDParam(c)
 	if !ok {
@@ -457,6 +642,7 @@ func (h *PageHandler) UpdatePageRoleUser(c *gin.Context, user *domain.User) {
 		AuthorPkID: user.PkID,
 		PagePkID:   pagePkID,
 		Email:      body.Email,
+		GroupID:    body.GroupID,
 		Role:       body.Role,
 	}, user)
 
@@ -485,6 +671,7 @@ func (h *PageHandler) DeletePageRoleUser(c *gin.Context, user *domain.User) {
 		AuthorPkID: user.PkID,
 		PagePkID:   pagePkID,
 		Email:      body.Email,
+		GroupID:    body.GroupID,
 	}, user)
 
 	if err != nil {
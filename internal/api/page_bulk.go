@@ -0,0 +1,76 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Bulk.
+func (h *PageHandler) BulkMovePages(c *gin.Context, user *domain.User) {
+	var body request.BulkMovePagesBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	h.bulkUpdatePages(c, domain.PageBulkInput{
+		Action:         domain.PageBulkMove,
+		PagePkIDs:      body.PagePkIDs,
+		ParentPagePkID: body.ParentPagePkID,
+	}, user)
+}
+
+func (h *PageHandler) BulkArchivePages(c *gin.Context, user *domain.User) {
+	h.bulkUpdatePagesAction(c, domain.PageBulkArchive, user)
+}
+
+func (h *PageHandler) BulkRestorePages(c *gin.Context, user *domain.User) {
+	h.bulkUpdatePagesAction(c, domain.PageBulkRestore, user)
+}
+
+func (h *PageHandler) BulkStarPages(c *gin.Context, user *domain.User) {
+	h.bulkUpdatePagesAction(c, domain.PageBulkStar, user)
+}
+
+func (h *PageHandler) BulkUnstarPages(c *gin.Context, user *domain.User) {
+	h.bulkUpdatePagesAction(c, domain.PageBulkUnstar, user)
+}
+
+func (h *PageHandler) BulkUpdateGeneralAccess(c *gin.Context, user *domain.User) {
+	var body request.BulkUpdateGeneralAccessBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	h.bulkUpdatePages(c, domain.PageBulkInput{
+		Action:      domain.PageBulkGeneralAccess,
+		PagePkIDs:   body.PagePkIDs,
+		GeneralRole: &body.GeneralRole,
+	}, user)
+}
+
+func (h *PageHandler) bulkUpdatePagesAction(c *gin.Context, action domain.PageBulkAction, user *domain.User) {
+	var body request.BulkPagesBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	h.bulkUpdatePages(c, domain.PageBulkInput{
+		Action:    action,
+		PagePkIDs: body.PagePkIDs,
+	}, user)
+}
+
+func (h *PageHandler) bulkUpdatePages(c *gin.Context, input domain.PageBulkInput, user *domain.User) {
+	results, err := h.pageService.BulkUpdatePages(input, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, results)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *PageHandler) DuplicatePage(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var body request.DuplicatePageBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	page, err := h.pageService.DuplicatePage(pagePkID, domain.PageDuplicateInput{
+		ParentPagePkID: body.ParentPagePkID,
+		Name:           body.Name,
+		IncludeRoles:   body.IncludeRoles,
+	}, body.IncludeDescendants, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 201, page)
+}
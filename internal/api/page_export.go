@@ -0,0 +1,85 @@
+package api
+
+import (
+	"mime"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *PageHandler) RequestPageExport(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var body request.ExportPageBody
+	if c.Request.ContentLength != 0 {
+		if verr := request.Validate(c, &body); verr != nil {
+			response.BindError(c, verr.Error())
+			return
+		}
+	}
+
+	export, err := h.pageService.RequestPageExport(pagePkID, body.Format, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 202, export)
+}
+
+func (h *PageHandler) GetPageExport(c *gin.Context, user *domain.User) {
+	exportID, ok := pageutils.GetExportIDParam(c)
+	if !ok {
+		response.BindError(c, "exportID is missing or invalid")
+		return
+	}
+
+	export, err := h.pageService.GetPageExport(exportID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, export)
+}
+
+func (h *PageHandler) DownloadPageExport(c *gin.Context, user *domain.User) {
+	exportID, ok := pageutils.GetExportIDParam(c)
+	if !ok {
+		response.BindError(c, "exportID is missing or invalid")
+		return
+	}
+
+	archive, err := h.pageService.DownloadPageExport(exportID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": archive.FileName}))
+	c.Data(200, "application/zip", archive.Content)
+}
+
+func (h *PageHandler) ExportPagePDF(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	content, fileName, err := h.pageService.RenderPagePDF(pagePkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	c.Data(200, "application/pdf", content)
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"io"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/importutils"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *PageHandler) ImportPages(c *gin.Context, user *domain.User) {
+	var form request.ImportPagesForm
+	if verr := request.Validate(c, &form); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	if form.File.Size > importutils.MaxUploadSize {
+		response.WithErrorMessage(c, domain.ErrImportTooLarge.Code, domain.ErrImportTooLarge.Error, domain.ErrImportTooLarge.Message)
+		return
+	}
+
+	file, ferr := form.File.Open()
+	if ferr != nil {
+		response.BindError(c, ferr.Error())
+		return
+	}
+	defer file.Close()
+
+	content, ferr := io.ReadAll(io.LimitReader(file, importutils.MaxUploadSize))
+	if ferr != nil {
+		response.BindError(c, ferr.Error())
+		return
+	}
+
+	results, err := h.pageService.ImportPages(domain.PageImportInput{
+		OrgPkID:        form.OrgPkID,
+		ParentPagePkID: form.ParentPagePkID,
+		File: domain.PageImportFile{
+			Path:    form.File.Filename,
+			Content: content,
+		},
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, results)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *PageHandler) GetBacklinks(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	backlinks, err := h.pageService.GetBacklinks(pagePkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, backlinks)
+}
+
+func (h *PageHandler) GetPageLinkGraph(c *gin.Context, user *domain.User) {
+	var query request.GetPageLinkGraphQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	graph, err := h.pageService.GetOrgLinkGraph(query.OrgPkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, graph)
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *PageHandler) ListPropertyDefinitions(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	properties, err := h.pageService.ListPropertyDefinitions(pagePkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, properties)
+}
+
+func (h *PageHandler) CreatePropertyDefinition(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var body request.CreatePagePropertyBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	property, err := h.pageService.CreatePropertyDefinition(pagePkID, domain.PagePropertyInput{
+		Name:    body.Name,
+		Type:    body.Type,
+		Options: body.Options,
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 201, property)
+}
+
+func (h *PageHandler) UpdatePropertyDefinition(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+	propertyID, ok := pageutils.GetPropertyIDParam(c)
+	if !ok {
+		response.BindError(c, "propertyID is missing")
+		return
+	}
+
+	var body request.UpdatePagePropertyBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	property, err := h.pageService.UpdatePropertyDefinition(pagePkID, propertyID, domain.PagePropertyUpdateInput{
+		Name:    body.Name,
+		Options: body.Options,
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, property)
+}
+
+func (h *PageHandler) DeletePropertyDefinition(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+	propertyID, ok := pageutils.GetPropertyIDParam(c)
+	if !ok {
+		response.BindError(c, "propertyID is missing")
+		return
+	}
+
+	if err := h.pageService.DeletePropertyDefinition(pagePkID, propertyID, user); err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, nil)
+}
+
+func (h *PageHandler) GetPageProperties(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	properties, err := h.pageService.GetPageProperties(pagePkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, properties)
+}
+
+func (h *PageHandler) SetPagePropertyValue(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+	propertyID, ok := pageutils.GetPropertyIDParam(c)
+	if !ok {
+		response.BindError(c, "propertyID is missing")
+		return
+	}
+
+	var body request.SetPagePropertyValueBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	value, err := h.pageService.SetPagePropertyValue(pagePkID, propertyID, body.Value, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, value)
+}
+
+// resolvePropertyQuery parses the property filters and sort of a page listing.
+func (h *PageHandler) resolvePropertyQuery(
+	query request.GetPagesQuery,
+) ([]domain.PagePropertyCondition, *domain.PagePropertySort, *domain.Error) {
+	var filters []domain.PagePropertyFilter
+	if query.PropertyFilters != "" {
+		if err := json.Unmarshal([]byte(query.PropertyFilters), &filters); err != nil {
+			return nil, nil, domain.ErrInvalidPropertyFilter
+		}
+	}
+
+	var sort *domain.PagePropertySortInput
+	if query.PropertySort != "" {
+		sort = &domain.PagePropertySortInput{
+			PropertyID: query.PropertySort,
+			Direction:  query.PropertySortDirection,
+		}
+	}
+
+	if len(filters) == 0 && sort == nil {
+		return nil, nil, nil
+	}
+	return h.pageService.ResolvePropertyQuery(query.OrgPkID, filters, sort)
+}
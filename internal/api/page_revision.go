@@ -0,0 +1,105 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+// Document Revisions.
+func (h *PageHandler) ListDocumentRevisions(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var query request.ListDocumentRevisionsQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	revisions, err := h.pageService.ListDocumentRevisions(domain.DocumentRevisionListQuery{
+		PagePkID: pagePkID,
+		Offset:   int(query.PaginationRequest.Page * query.PaginationRequest.Size),
+		Limit:    int(query.PaginationRequest.Size),
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithPagination(c, 200, revisions, domain.Pagination{
+		Page: query.PaginationRequest.Page,
+		Size: int64(len(revisions)),
+	})
+}
+
+func (h *PageHandler) GetDocumentRevision(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	revisionPkID, ok := pageutils.GetRevisionPkIDParam(c)
+	if !ok {
+		response.BindError(c, "revisionPkID is missing or invalid")
+		return
+	}
+
+	revision, err := h.pageService.GetDocumentRevision(pagePkID, revisionPkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, revision)
+}
+
+func (h *PageHandler) RestoreDocumentRevision(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	revisionPkID, ok := pageutils.GetRevisionPkIDParam(c)
+	if !ok {
+		response.BindError(c, "revisionPkID is missing or invalid")
+		return
+	}
+
+	page, err := h.pageService.RestoreDocumentRevision(pagePkID, revisionPkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, page)
+}
+
+func (h *PageHandler) DiffDocumentRevisions(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var query request.DiffDocumentRevisionsQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	diff, err := h.pageService.DiffDocumentRevisions(pagePkID, query.From, query.To, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, diff)
+}
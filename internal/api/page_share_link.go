@@ -0,0 +1,121 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *PageHandler) GetShareLinks(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	links, err := h.pageService.GetShareLinks(pagePkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, links)
+}
+
+func (h *PageHandler) CreateShareLink(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var body request.CreateShareLinkBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	link, err := h.pageService.CreateShareLink(domain.PageShareLinkInput{
+		PagePkID:       pagePkID,
+		Role:           body.Role,
+		ExpiresAt:      body.ExpiresAt,
+		Password:       body.Password,
+		MaxUses:        body.MaxUses,
+		OrgMembersOnly: body.OrgMembersOnly,
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 201, link)
+}
+
+func (h *PageHandler) RevokeShareLink(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+	linkID, ok := pageutils.GetShareLinkIDParam(c)
+	if !ok {
+		response.BindError(c, "shareLinkID is missing")
+		return
+	}
+
+	if err := h.pageService.RevokeShareLink(pagePkID, linkID, user); err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithMessage(c, 200, "Share link revoked")
+}
+
+func (h *PageHandler) GetShareLinkAccesses(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+	linkID, ok := pageutils.GetShareLinkIDParam(c)
+	if !ok {
+		response.BindError(c, "shareLinkID is missing")
+		return
+	}
+
+	accesses, err := h.pageService.GetShareLinkAccesses(pagePkID, linkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, accesses)
+}
+
+// OpenShareLink works without signing in, the password is sent in the body to keep it out of urls.
+func (h *PageHandler) OpenShareLink(c *gin.Context, user *domain.User) {
+	linkID, ok := pageutils.GetShareLinkIDParam(c)
+	if !ok {
+		response.BindError(c, "shareLinkID is missing")
+		return
+	}
+
+	// Links without password can be opened without body
+	var body request.OpenShareLinkBody
+	if c.Request.ContentLength != 0 {
+		if verr := request.Validate(c, &body); verr != nil {
+			response.BindError(c, verr.Error())
+			return
+		}
+	}
+
+	page, err := h.pageService.OpenShareLink(linkID, body.Password, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, page)
+}
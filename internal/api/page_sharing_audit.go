@@ -0,0 +1,38 @@
+package api
+
+import (
+	"mime"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *PageHandler) GetSharingAudit(c *gin.Context, user *domain.User) {
+	var query request.SharingAuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.BindError(c, err.Error())
+		return
+	}
+
+	if query.Format == request.SharingAuditFormatCSV {
+		content, fileName, err := h.pageService.ExportSharingAuditCSV(query.OrgPkID, user)
+		if err != nil {
+			response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+			return
+		}
+
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+		c.Data(200, "text/csv; charset=utf-8", content)
+		return
+	}
+
+	entries, err := h.pageService.GetSharingAudit(query.OrgPkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, entries)
+}
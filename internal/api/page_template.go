@@ -0,0 +1,74 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+// Templates.
+func (h *PageHandler) GetTemplates(c *gin.Context, user *domain.User) {
+	var query request.GetTemplatesQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	pages, err := h.pageService.GetTemplates(query.OrgPkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, pages)
+}
+
+func (h *PageHandler) UpdatePageTemplate(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var body request.UpdatePageTemplateBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	page, err := h.pageService.SetPageTemplate(pagePkID, *body.IsTemplate, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	pageutils.SetETagVersion(c, page.Version)
+	response.WithData(c, 200, page)
+}
+
+func (h *PageHandler) CreatePageFromTemplate(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var body request.CreatePageFromTemplateBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	page, err := h.pageService.CreatePageFromTemplate(pagePkID, domain.PageDuplicateInput{
+		ParentPagePkID: body.ParentPagePkID,
+		Name:           body.Name,
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 201, page)
+}
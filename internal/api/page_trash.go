@@ -0,0 +1,81 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+// Trash.
+func (h *PageHandler) GetTrashPages(c *gin.Context, user *domain.User) {
+	var query request.GetTrashPagesQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	pages, err := h.pageService.GetTrashPages(
+		query.OrgPkID,
+		int(query.PaginationRequest.Page*query.PaginationRequest.Size),
+		int(query.PaginationRequest.Size),
+		user,
+	)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithPagination(c, 200, pages, domain.Pagination{
+		Page: query.PaginationRequest.Page,
+		Size: int64(len(pages)),
+	})
+}
+
+func (h *PageHandler) RestorePage(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	page, err := h.pageService.RestorePageByPkID(pagePkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, page)
+}
+
+func (h *PageHandler) DeletePagePermanently(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	if err := h.pageService.DeletePagePermanently(pagePkID, user); err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithMessage(c, 200, "Page's deleted permanently!")
+}
+
+func (h *PageHandler) EmptyTrash(c *gin.Context, user *domain.User) {
+	var query request.EmptyTrashQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	pages, err := h.pageService.EmptyTrash(query.OrgPkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, pages)
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/services/presence"
+	"github.com/Stuhub-io/internal/api/decorators"
+	"github.com/Stuhub-io/internal/api/middleware"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/Stuhub-io/utils/pageutils"
+	"github.com/gin-gonic/gin"
+)
+
+type PresenceHandler struct {
+	presenceService *presence.Service
+}
+
+type NewPresenceHandlerParams struct {
+	Router          *gin.RouterGroup
+	AuthMiddleware  *middleware.AuthMiddleware
+	PresenceService *presence.Service
+}
+
+func UsePresenceHandler(params NewPresenceHandlerParams) {
+	handler := &PresenceHandler{
+		presenceService: params.PresenceService,
+	}
+	router := params.Router.Group("/page-services")
+	authMiddleware := params.AuthMiddleware
+
+	router.Use(authMiddleware.Authenticated())
+	router.GET(
+		"/pages/:"+pageutils.PagePkIDParam+"/presence",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.GetPagePresences)),
+	)
+	router.PUT(
+		"/pages/:"+pageutils.PagePkIDParam+"/presence",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.Heartbeat)),
+	)
+	router.DELETE(
+		"/pages/:"+pageutils.PagePkIDParam+"/presence",
+		decorators.RequiredAuth(decorators.CurrentUser(handler.LeavePresence)),
+	)
+}
+
+func (h *PresenceHandler) GetPagePresences(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	presences, err := h.presenceService.GetPagePresences(pagePkID, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, presences)
+}
+
+func (h *PresenceHandler) Heartbeat(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var body request.PresenceHeartbeatBody
+	if verr := request.Validate(c, &body); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	presences, err := h.presenceService.Heartbeat(domain.PresenceHeartbeatInput{
+		PagePkID:  pagePkID,
+		SessionID: body.SessionID,
+		Cursor:    body.Cursor,
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, presences)
+}
+
+func (h *PresenceHandler) LeavePresence(c *gin.Context, user *domain.User) {
+	pagePkID, ok := pageutils.GetPagePkIDParam(c)
+	if !ok {
+		response.BindError(c, "pagePkID is missing or invalid")
+		return
+	}
+
+	var query request.LeavePresenceQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	if err := h.presenceService.Leave(pagePkID, query.SessionID, user); err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithData(c, 200, nil)
+}
@@ -20,3 +20,31 @@ type InviteMembersByEmailParams struct {
 type ValidateOrgInvitationParams struct {
 	Token string `binding:"required" json:"token"`
 }
+
+type UpdateOrgBody struct {
+	Name        *string `binding:"omitempty,min=1,max=255" json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Avatar      *string `json:"avatar,omitempty"`
+}
+
+type UpdateOrgMemberRoleBody struct {
+	Role string `binding:"required,oneof=admin member guest" json:"role"`
+}
+
+type TransferOrgOwnershipBody struct {
+	UserPkID int64 `binding:"required" json:"user_pkid"`
+}
+
+type CreateOrgGroupBody struct {
+	Name        string `binding:"required,max=100" json:"name"`
+	Description string `json:"description"`
+}
+
+type UpdateOrgGroupBody struct {
+	Name        *string `binding:"omitempty,min=1,max=100" json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type AddOrgGroupMembersBody struct {
+	UserPkIDs []int64 `binding:"required,min=1" json:"user_pkids"`
+}
@@ -1,6 +1,11 @@
 package request
 
-import "github.com/Stuhub-io/core/domain"
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+)
 
 // page.
 type CreatePageBody struct {
@@ -11,6 +16,11 @@ type CreatePageBody struct {
 	CoverImage     string              `json:"cover_image,omitempty"`
 }
 
+type GetPageQuery struct {
+	// Renders the document of the page instead of returning the page, md, html or txt.
+	Format domain.DocumentFormat `binding:"omitempty,oneof=md html txt" form:"format,omitempty" json:"format,omitempty"`
+}
+
 type CreateDocumentBody struct {
 	CreatePageBody
 	Document struct {
@@ -26,6 +36,14 @@ type GetPagesQuery struct {
 	All            bool                  `form:"all,omitempty"              json:"all,omitempty"`
 	GeneralRole    *domain.PageRole      `form:"general_role,omitempty"     json:"general_role,omitempty"`
 	IsStarred      *bool                 `form:"is_starred,omitempty"       json:"is_starred,omitempty"`
+	IsTemplate     *bool                 `form:"is_template,omitempty"      json:"is_template,omitempty"`
+	OrderBy        domain.PageOrderBy    `binding:"omitempty,oneof=position name created_at updated_at" form:"order_by,omitempty"  json:"order_by,omitempty"`
+	OrderDirection domain.OrderDirection `binding:"omitempty,oneof=asc desc"                           form:"order_direction,omitempty" json:"order_direction,omitempty"`
+	// JSON array of property filters, [{"property_id", "operator", "value"}]
+	PropertyFilters       string                `form:"property_filters,omitempty" json:"property_filters,omitempty"`
+	PropertySort          string                `form:"property_sort,omitempty" json:"property_sort,omitempty"`
+	PropertySortDirection domain.OrderDirection `binding:"omitempty,oneof=asc desc" form:"property_sort_direction,omitempty" json:"property_sort_direction,omitempty"`
+	WithProperties        bool                  `form:"with_properties,omitempty" json:"with_properties,omitempty"`
 	PaginationRequest
 }
 
@@ -43,6 +61,57 @@ type MovePageBody struct {
 	ParentPagePkID *int64 `json:"parent_page_pkid,omitempty"`
 }
 
+type ReorderPageBody struct {
+	BeforePagePkID *int64 `json:"before_page_pkid,omitempty"`
+	AfterPagePkID  *int64 `json:"after_page_pkid,omitempty"`
+}
+
+type DuplicatePageBody struct {
+	ParentPagePkID     *int64  `json:"parent_page_pkid,omitempty"`
+	Name               *string `json:"name,omitempty"`
+	IncludeDescendants bool    `json:"include_descendants,omitempty"`
+	IncludeRoles       bool    `json:"include_roles,omitempty"`
+}
+
+type GetTemplatesQuery struct {
+	OrgPkID int64 `binding:"required" form:"org_pkid" json:"org_pkid"`
+}
+
+type UpdatePageTemplateBody struct {
+	IsTemplate *bool `binding:"required" json:"is_template"`
+}
+
+type CreatePageFromTemplateBody struct {
+	ParentPagePkID *int64  `json:"parent_page_pkid,omitempty"`
+	Name           *string `json:"name,omitempty"`
+}
+
+type BulkPagesBody struct {
+	PagePkIDs []int64 `binding:"required,min=1" json:"page_pkids"`
+}
+
+type BulkMovePagesBody struct {
+	BulkPagesBody
+	ParentPagePkID *int64 `json:"parent_page_pkid,omitempty"`
+}
+
+type BulkUpdateGeneralAccessBody struct {
+	BulkPagesBody
+	GeneralRole domain.PageRole `binding:"required" json:"general_role"`
+}
+
+// ImportPagesForm is a multipart form, the file is a markdown or html file or a zip archive of them.
+type ImportPagesForm struct {
+	OrgPkID        int64                 `binding:"required" form:"org_pkid"`
+	ParentPagePkID *int64                `form:"parent_page_pkid,omitempty"`
+	File           *multipart.FileHeader `binding:"required" form:"file"`
+}
+
+type ExportPageBody struct {
+	// Format of the documents of the export, md by default.
+	Format domain.DocumentFormat `binding:"omitempty,oneof=md html" json:"format,omitempty"`
+}
+
 type UpdatePageContent struct {
 	JsonContent string `binding:"required" json:"json_content"`
 }
@@ -61,18 +130,34 @@ type UpdatePageGeneralAccessBody struct {
 	GeneralRole domain.PageRole `binding:"required" json:"general_role,omitempty"`
 }
 
+// Page roles target either an email or a group of the page organization.
 type AddPageRoleUserBody struct {
-	Role  domain.PageRole `binding:"required" json:"role,omitempty"`
-	Email string          `binding:"required" json:"email"`
+	Role    domain.PageRole `binding:"required"                                   json:"role,omitempty"`
+	Email   string          `binding:"required_without=GroupID"                   json:"email"`
+	GroupID string          `binding:"required_without=Email,excluded_with=Email" json:"group_id"`
 }
 
 type UpdatePageRoleUserBody struct {
-	Email string          `binding:"required" json:"email"`
-	Role  domain.PageRole `binding:"required" json:"role,omitempty"`
+	Email   string          `binding:"required_without=GroupID"                   json:"email"`
+	GroupID string          `binding:"required_without=Email,excluded_with=Email" json:"group_id"`
+	Role    domain.PageRole `binding:"required"                                   json:"role,omitempty"`
 }
 
 type DeletePageRoleUserBody struct {
-	Email string `binding:"required" json:"email"`
+	Email   string `binding:"required_without=GroupID"                   json:"email"`
+	GroupID string `binding:"required_without=Email,excluded_with=Email" json:"group_id"`
+}
+
+const SharingAuditFormatCSV = "csv"
+
+type SharingAuditQuery struct {
+	OrgPkID int64 `binding:"required"            form:"org_pkid"         json:"org_pkid"`
+	// Returns the report as a CSV file instead of JSON, csv.
+	Format string `binding:"omitempty,oneof=csv" form:"format,omitempty" json:"format,omitempty"`
+}
+
+type ExplainPagePermissionsQuery struct {
+	Email string `binding:"required,email" form:"email" json:"email"`
 }
 
 type AcceptRequestPageAccess struct {
@@ -87,3 +172,53 @@ type RejectRequestPageAccess struct {
 type ToggleStarPageBody struct {
 	PagePkID int64 `binding:"required" json:"page_pkid"`
 }
+
+type ListDocumentRevisionsQuery struct {
+	PaginationRequest
+}
+
+type GetTrashPagesQuery struct {
+	PaginationRequest
+	OrgPkID int64 `binding:"required" form:"org_pkid" json:"org_pkid"`
+}
+
+type EmptyTrashQuery struct {
+	OrgPkID int64 `binding:"required" form:"org_pkid" json:"org_pkid"`
+}
+
+type DiffDocumentRevisionsQuery struct {
+	From int64  `binding:"required" form:"from"          json:"from"`
+	To   *int64 `form:"to,omitempty" json:"to,omitempty"`
+}
+
+type GetPageLinkGraphQuery struct {
+	OrgPkID int64 `binding:"required" form:"org_pkid" json:"org_pkid"`
+}
+
+type CreatePagePropertyBody struct {
+	Name    string                      `binding:"required" json:"name"`
+	Type    domain.PagePropertyType     `binding:"required,oneof=text number date select multi_select user checkbox" json:"type"`
+	Options []domain.PagePropertyOption `json:"options,omitempty"`
+}
+
+type UpdatePagePropertyBody struct {
+	Name    *string                     `json:"name,omitempty"`
+	Options []domain.PagePropertyOption `json:"options,omitempty"`
+}
+
+type SetPagePropertyValueBody struct {
+	// Null clears the value
+	Value any `json:"value"`
+}
+
+type CreateShareLinkBody struct {
+	Role           domain.PageRole `binding:"required"               json:"role"`
+	ExpiresAt      *time.Time      `json:"expires_at,omitempty"`
+	Password       string          `binding:"omitempty,min=4,max=72" json:"password,omitempty"`
+	MaxUses        *int32          `binding:"omitempty,min=1"        json:"max_uses,omitempty"`
+	OrgMembersOnly bool            `json:"org_members_only"`
+}
+
+type OpenShareLinkBody struct {
+	Password string `json:"password"`
+}
@@ -0,0 +1,12 @@
+package request
+
+import "encoding/json"
+
+type PresenceHeartbeatBody struct {
+	SessionID string          `binding:"required" json:"session_id"`
+	Cursor    json.RawMessage `json:"cursor,omitempty"`
+}
+
+type LeavePresenceQuery struct {
+	SessionID string `binding:"required" form:"session_id" json:"session_id"`
+}
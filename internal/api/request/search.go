@@ -0,0 +1,11 @@
+package request
+
+import "github.com/Stuhub-io/core/domain"
+
+type SearchPagesQuery struct {
+	OrgPkID   int64                 `binding:"required"                 form:"org_pkid"             json:"org_pkid"`
+	Query     string                `binding:"required"                 form:"q"                    json:"q"`
+	ViewTypes []domain.PageViewType `form:"view_types,omitempty"        json:"view_types,omitempty"`
+	Page      int64                 `binding:"omitempty,gte=0"          form:"page,default=0"       json:"page"`
+	Size      int64                 `binding:"omitempty,gt=0,lte=100"   form:"size,default=20"      json:"size"`
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/core/services/search"
+	"github.com/Stuhub-io/internal/api/decorators"
+	"github.com/Stuhub-io/internal/api/middleware"
+	"github.com/Stuhub-io/internal/api/request"
+	"github.com/Stuhub-io/internal/api/response"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchService  *search.Service
+	AuthMiddleware *middleware.AuthMiddleware
+}
+
+type NewSearchHandlerParams struct {
+	Router         *gin.RouterGroup
+	AuthMiddleware *middleware.AuthMiddleware
+	SearchService  *search.Service
+}
+
+func UseSearchHandler(params NewSearchHandlerParams) {
+	handler := &SearchHandler{
+		searchService:  params.SearchService,
+		AuthMiddleware: params.AuthMiddleware,
+	}
+	router := params.Router.Group("/page-services")
+	authMiddleware := params.AuthMiddleware
+
+	router.Use(authMiddleware.Authenticated())
+	router.GET("/search", decorators.RequiredAuth(decorators.CurrentUser(handler.SearchPages)))
+}
+
+func (h *SearchHandler) SearchPages(c *gin.Context, user *domain.User) {
+	var query request.SearchPagesQuery
+	if verr := request.Validate(c, &query); verr != nil {
+		response.BindError(c, verr.Error())
+		return
+	}
+
+	results, err := h.searchService.SearchPages(domain.PageSearchQuery{
+		OrgPkID:   query.OrgPkID,
+		Query:     query.Query,
+		ViewTypes: query.ViewTypes,
+		Offset:    int(query.Page * query.Size),
+		Limit:     int(query.Size),
+	}, user)
+	if err != nil {
+		response.WithErrorMessage(c, err.Code, err.Error, err.Message)
+		return
+	}
+
+	response.WithPagination(c, 200, results, domain.Pagination{
+		Page: query.Page,
+		Size: int64(len(results)),
+	})
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+// Each session is one field of the page hash, written and removed on its own so
+// instances never overwrite each other's sessions.
+func presenceField(userPkID int64, sessionID string) string {
+	return fmt.Sprintf("%d:%s", userPkID, sessionID)
+}
+
+func presenceMember(pagePkID int64, field string) string {
+	return fmt.Sprintf("%d:%s", pagePkID, field)
+}
+
+func (u *CacheStore) SetPagePresence(presence domain.PagePresence, duration time.Duration) error {
+	if presence.User == nil {
+		return fmt.Errorf("page presence without user")
+	}
+
+	field := presenceField(presence.User.PkID, presence.SessionID)
+	err := u.cache.HSet(domain.PagePresenceKey(presence.PagePkID), field, presence, duration)
+	if err == nil {
+		err = u.cache.ZAdd(
+			domain.PagePresenceSessionsKey,
+			presenceMember(presence.PagePkID, field),
+			float64(presence.LastSeenAt.Unix()),
+		)
+	}
+	if err != nil {
+		fmt.Printf("caching page presence error: %v", err)
+	}
+
+	return err
+}
+
+func (u *CacheStore) GetPagePresence(pagePkID int64, userPkID int64, sessionID string) *domain.PagePresence {
+	data, err := u.cache.HGet(domain.PagePresenceKey(pagePkID), presenceField(userPkID, sessionID))
+	if err != nil {
+		return nil
+	}
+
+	var presence domain.PagePresence
+	if err := json.Unmarshal([]byte(data), &presence); err != nil {
+		return nil
+	}
+
+	return &presence
+}
+
+func (u *CacheStore) GetPagePresences(pagePkID int64) []domain.PagePresence {
+	presences := []domain.PagePresence{}
+
+	data, err := u.cache.HGetAll(domain.PagePresenceKey(pagePkID))
+	if err != nil {
+		return presences
+	}
+
+	for _, value := range data {
+		var presence domain.PagePresence
+		if err := json.Unmarshal([]byte(value), &presence); err != nil {
+			continue
+		}
+		presences = append(presences, presence)
+	}
+
+	return presences
+}
+
+// DeletePagePresence returns the removed session, or nil when another caller removed it first.
+func (u *CacheStore) DeletePagePresence(pagePkID int64, userPkID int64, sessionID string) *domain.PagePresence {
+	return u.deletePagePresence(pagePkID, presenceField(userPkID, sessionID))
+}
+
+// DeleteExpiredPagePresences removes the sessions of every page last seen before lastSeenBefore
+// and returns the ones removed by this call.
+func (u *CacheStore) DeleteExpiredPagePresences(lastSeenBefore time.Time) []domain.PagePresence {
+	removed := []domain.PagePresence{}
+
+	members, err := u.cache.ZRangeByScore(domain.PagePresenceSessionsKey, float64(lastSeenBefore.Unix()))
+	if err != nil {
+		return removed
+	}
+
+	for _, member := range members {
+		rawPagePkID, field, ok := strings.Cut(member, ":")
+		if !ok {
+			continue
+		}
+		pagePkID, err := strconv.ParseInt(rawPagePkID, 10, 64)
+		if err != nil {
+			continue
+		}
+		if presence := u.deletePagePresence(pagePkID, field); presence != nil {
+			removed = append(removed, *presence)
+		}
+	}
+
+	return removed
+}
+
+func (u *CacheStore) deletePagePresence(pagePkID int64, field string) *domain.PagePresence {
+	// The sorted set removal is atomic, whoever removes the member owns the left event.
+	ok, err := u.cache.ZRem(domain.PagePresenceSessionsKey, presenceMember(pagePkID, field))
+	if err != nil || !ok {
+		return nil
+	}
+
+	key := domain.PagePresenceKey(pagePkID)
+	data, err := u.cache.HGet(key, field)
+	if err != nil {
+		return nil
+	}
+	u.cache.HDel(key, field)
+
+	var presence domain.PagePresence
+	if err := json.Unmarshal([]byte(data), &presence); err != nil {
+		return nil
+	}
+
+	return &presence
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/Stuhub-io/logger"
@@ -71,3 +72,81 @@ func (c *RedisCache) Delete(key string) error {
 
 	return nil
 }
+
+// HSet sets one field of the hash and refreshes the expiry of the whole hash.
+func (c *RedisCache) HSet(key string, field string, value any, duration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache value for key %q field %q: %v", key, field, err)
+	}
+
+	_, err = c.client.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.HSet(context.Background(), key, field, data)
+		pipe.Expire(context.Background(), key, duration)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set value for key %q field %q: %v", key, field, err)
+	}
+
+	return nil
+}
+
+func (c *RedisCache) HGet(key string, field string) (string, error) {
+	data, err := c.client.HGet(context.Background(), key, field).Result()
+	if err == redis.Nil {
+		return "", fmt.Errorf("cache miss for key %q field %q", key, field)
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get value for key %q field %q: %v", key, field, err)
+	}
+
+	return data, nil
+}
+
+func (c *RedisCache) HGetAll(key string) (map[string]string, error) {
+	data, err := c.client.HGetAll(context.Background(), key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values for key %q: %v", key, err)
+	}
+
+	return data, nil
+}
+
+func (c *RedisCache) HDel(key string, fields ...string) error {
+	if err := c.client.HDel(context.Background(), key, fields...).Err(); err != nil {
+		return fmt.Errorf("failed to delete fields of key %q: %v", key, err)
+	}
+
+	return nil
+}
+
+func (c *RedisCache) ZAdd(key string, member string, score float64) error {
+	if err := c.client.ZAdd(context.Background(), key, redis.Z{Score: score, Member: member}).Err(); err != nil {
+		return fmt.Errorf("failed to add member to key %q: %v", key, err)
+	}
+
+	return nil
+}
+
+// ZRangeByScore lists the members of the sorted set scored up to max.
+func (c *RedisCache) ZRangeByScore(key string, max float64) ([]string, error) {
+	members, err := c.client.ZRangeByScore(context.Background(), key, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatFloat(max, 'f', -1, 64),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to range members of key %q: %v", key, err)
+	}
+
+	return members, nil
+}
+
+// ZRem reports whether this call removed the member, so only one caller wins a concurrent removal.
+func (c *RedisCache) ZRem(key string, member string) (bool, error) {
+	removed, err := c.client.ZRem(context.Background(), key, member).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove member from key %q: %v", key, err)
+	}
+
+	return removed > 0, nil
+}
@@ -18,6 +18,7 @@ type Document struct {
 	JSONContent *string   `gorm:"column:json_content;type:json" json:"json_content"`
 	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamp with time zone;not null;default:now()" json:"updated_at"`
 	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp with time zone;not null;default:now()" json:"created_at"`
+	Version     int64     `gorm:"column:version;type:bigint;not null;default:1" json:"version"`
 }
 
 // TableName Document's table name
@@ -26,6 +26,9 @@ type Page struct {
 	Path           string     `gorm:"column:path;type:text;not null" json:"path"`
 	GeneralRole    string     `gorm:"column:general_role;type:character varying(20);not null;default:viewer" json:"general_role"`
 	AuthorPkid     *int64     `gorm:"column:author_pkid;type:bigint" json:"author_pkid"`
+	Version        int64      `gorm:"column:version;type:bigint;not null;default:1" json:"version"`
+	IsTemplate     bool       `gorm:"column:is_template;type:boolean;not null" json:"is_template"`
+	Position       string     `gorm:"column:position;type:text;not null" json:"position"`
 }
 
 // TableName Page's table name
@@ -12,6 +12,7 @@ import (
 	"github.com/Stuhub-io/internal/repository/model"
 	commonutils "github.com/Stuhub-io/utils"
 	"github.com/Stuhub-io/utils/organizationutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
 	"github.com/gosimple/slug"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -40,9 +41,9 @@ func NewOrganizationRepository(params NewOrganizationRepositoryParams) ports.Org
 func (r *OrganizationRepository) GetOrgMembers(ctx context.Context, pkID int64) ([]domain.OrganizationMember, *domain.Error) {
 	var members []organizationutils.MemberWithUser
 
-	err := r.store.DB().Preload("User").Where("organization_id = ?", pkID).First(&members).Error
+	err := r.store.DB().Preload("User").Where("organization_pkid = ?", pkID).Order("created_at, pkid").Find(&members).Error
 	if err != nil {
-		return nil, domain.ErrInternalServerError
+		return nil, domain.ErrDatabaseQuery
 	}
 
 	return organizationutils.TransformOrganizationMemberModelToDomain_Many(members), nil
@@ -196,6 +197,19 @@ func (r *OrganizationRepository) CreateOrg(ctx context.Context, ownerPkID int64,
 	return organizationutils.TransformOrganizationModelToDomain_New(newOrg, ownerMember, owner), nil
 }
 
+// GetOrgs lists every organization, without members.
+func (r *OrganizationRepository) GetOrgs(ctx context.Context) ([]*domain.Organization, *domain.Error) {
+	var orgs []model.Organization
+
+	if err := r.store.DB().Order("pkid asc").Find(&orgs).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return sliceutils.Map(orgs, func(org model.Organization) *domain.Organization {
+		return organizationutils.TransformOrganizationModelToDomain_Plain(&org)
+	}), nil
+}
+
 func (r *OrganizationRepository) GetOrgsByUserPkID(ctx context.Context, userPkID int64) ([]*domain.Organization, *domain.Error) {
 	var joinedOrgs []organizationutils.OrganizationWithMembers
 
@@ -230,6 +244,45 @@ func (r *OrganizationRepository) AddMemberToOrg(ctx context.Context, orgPkID int
 	return organizationutils.TransformOrganizationMemberModelToDomain_New(newMember, user), nil
 }
 
+// InviteMemberToOrg adds the user as a pending member, unless already one, and creates the invite in
+// one transaction with the events attached to ctx, the invitation mail is only sent if both are saved.
+func (r *OrganizationRepository) InviteMemberToOrg(ctx context.Context, input domain.OrganizationMemberInviteInput) *domain.Error {
+	tx, doneTx := r.store.NewTransaction()
+
+	var members []model.OrganizationMember
+	if err := tx.DB().
+		Where("organization_pkid = ? AND user_pkid = ?", input.OrgPkID, input.UserPkID).
+		Limit(1).
+		Find(&members).Error; err != nil {
+		return doneTx(err)
+	}
+
+	if len(members) == 0 {
+		if err := tx.DB().Create(&model.OrganizationMember{
+			OrganizationPkid: input.OrgPkID,
+			UserPkid:         &input.UserPkID,
+			Role:             input.Role,
+		}).Error; err != nil {
+			return doneTx(err)
+		}
+	}
+
+	if err := tx.DB().Create(&model.OrganizationInvite{
+		ID:               input.InviteID,
+		UserPkid:         input.UserPkID,
+		OrganizationPkid: input.OrgPkID,
+		ExpiredAt:        time.Now().Add(domain.OrgInvitationExpiredTime),
+	}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	if err := stageOutboxEvents(ctx, tx.DB()); err != nil {
+		return doneTx(err)
+	}
+
+	return doneTx(nil)
+}
+
 func (r *OrganizationRepository) SetOrgMemberActivatedAt(ctx context.Context, pkID int64, activatedAt time.Time) (*domain.OrganizationMember, *domain.Error) {
 	var member model.OrganizationMember
 
@@ -240,3 +293,138 @@ func (r *OrganizationRepository) SetOrgMemberActivatedAt(ctx context.Context, pk
 
 	return organizationutils.TransformOrganizationMemberModelToDomain_New(member, nil), nil
 }
+
+func (r *OrganizationRepository) UpdateOrg(ctx context.Context, pkID int64, input domain.OrganizationUpdateInput) (*domain.Organization, *domain.Error) {
+	updates := map[string]any{"updated_at": time.Now()}
+	if input.Name != nil {
+		updates["name"] = *input.Name
+	}
+	if input.Description != nil {
+		updates["description"] = *input.Description
+	}
+	if input.Avatar != nil {
+		updates["avatar"] = *input.Avatar
+	}
+
+	if err := r.store.DB().Model(&model.Organization{}).Where("pkid = ?", pkID).Updates(updates).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	return r.GetOrgByPkID(ctx, pkID)
+}
+
+func (r *OrganizationRepository) UpdateOrgMemberRole(
+	ctx context.Context,
+	orgPkID int64,
+	userPkID int64,
+	role string,
+) (*domain.OrganizationMember, *domain.Error) {
+	err := r.store.DB().Model(&model.OrganizationMember{}).
+		Where("organization_pkid = ? AND user_pkid = ?", orgPkID, userPkID).
+		Updates(map[string]any{"role": role, "updated_at": time.Now()}).Error
+	if err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	return r.GetOrgMemberByUserPkID(ctx, orgPkID, userPkID)
+}
+
+// RemoveOrgMember removes the user from the organization, along with the roles given to the user on
+// the pages of the organization, which descendant pages inherit, their stars, opened share links, groups
+// and pending invites. The pages the user created go to the organization owner, authors have every
+// permission on their pages.
+func (r *OrganizationRepository) RemoveOrgMember(ctx context.Context, orgPkID int64, userPkID int64) *domain.Error {
+	var user model.User
+	if err := r.store.DB().Select("pkid", "email").Where("pkid = ?", userPkID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrOrgMemberNotFound
+		}
+		return domain.ErrDatabaseQuery
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	if err := tx.DB().
+		Where("organization_pkid = ? AND user_pkid = ?", orgPkID, userPkID).
+		Delete(&model.OrganizationMember{}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	ownerPkID := tx.DB().Model(&model.Organization{}).Select("owner_id").Where("pkid = ?", orgPkID)
+	if err := tx.DB().Model(&model.Page{}).
+		Where("org_pkid = ? AND author_pkid = ?", orgPkID, userPkID).
+		Update("author_pkid", ownerPkID).Error; err != nil {
+		return doneTx(err)
+	}
+
+	orgPages := tx.DB().Model(&model.Page{}).Select("pkid").Where("org_pkid = ?", orgPkID)
+
+	// Roles given before the user signed up are only bound to the email
+	if err := tx.DB().
+		Where("page_pkid IN (?) AND (user_pkid = ? OR email = ?)", orgPages, userPkID, user.Email).
+		Delete(&model.PageRole{}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	if err := tx.DB().
+		Where("page_pkid IN (?) AND user_pkid = ?", orgPages, userPkID).
+		Delete(&model.PageStar{}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	orgLinks := tx.DB().Model(&model.PageShareLink{}).Select("pkid").Where("page_pkid IN (?)", orgPages)
+	if err := tx.DB().
+		Where("link_pkid IN (?) AND user_pkid = ?", orgLinks, userPkID).
+		Delete(&model.PageShareLinkAccess{}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	orgGroups := tx.DB().Model(&model.OrgGroup{}).Select("pkid").Where("org_pkid = ?", orgPkID)
+	if err := tx.DB().
+		Where("group_pkid IN (?) AND user_pkid = ?", orgGroups, userPkID).
+		Delete(&model.OrgGroupMember{}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	if err := tx.DB().
+		Where("organization_pkid = ? AND user_pkid = ? AND is_used = false", orgPkID, userPkID).
+		Delete(&model.OrganizationInvite{}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	return doneTx(nil)
+}
+
+// TransferOrgOwnership makes the member the owner of the organization, the previous owner stays as an admin.
+func (r *OrganizationRepository) TransferOrgOwnership(ctx context.Context, orgPkID int64, newOwnerPkID int64) *domain.Error {
+	var org model.Organization
+	if err := r.store.DB().Where("pkid = ?", orgPkID).First(&org).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrOrgNotFound
+		}
+		return domain.ErrDatabaseQuery
+	}
+
+	now := time.Now()
+	tx, doneTx := r.store.NewTransaction()
+
+	if err := tx.DB().Model(&model.OrganizationMember{}).
+		Where("organization_pkid = ? AND user_pkid = ?", orgPkID, org.OwnerID).
+		Updates(map[string]any{"role": domain.Admin.String(), "updated_at": now}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	if err := tx.DB().Model(&model.OrganizationMember{}).
+		Where("organization_pkid = ? AND user_pkid = ?", orgPkID, newOwnerPkID).
+		Updates(map[string]any{"role": domain.Owner.String(), "updated_at": now}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	if err := tx.DB().Model(&model.Organization{}).
+		Where("pkid = ?", orgPkID).
+		Updates(map[string]any{"owner_id": newOwnerPkID, "updated_at": now}).Error; err != nil {
+		return doneTx(err)
+	}
+
+	return doneTx(nil)
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/organizationutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *OrganizationRepository) ListOrgGroups(ctx context.Context, orgPkID int64) ([]domain.OrgGroup, *domain.Error) {
+	var groups []organizationutils.OrgGroupWithMembers
+	if err := queryOrgGroups(r.store.DB()).
+		Where("org_groups.org_pkid = ?", orgPkID).
+		Order("lower(org_groups.name)").
+		Scan(&groups).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return sliceutils.Map(groups, func(group organizationutils.OrgGroupWithMembers) domain.OrgGroup {
+		return *organizationutils.TransformOrgGroupModelToDomain(group)
+	}), nil
+}
+
+// ListOrgGroupsByUser returns the groups of the organization the user is a member of.
+func (r *OrganizationRepository) ListOrgGroupsByUser(
+	ctx context.Context,
+	orgPkID int64,
+	userPkID int64,
+) ([]domain.OrgGroup, *domain.Error) {
+	var groups []organizationutils.OrgGroupWithMembers
+	if err := queryOrgGroups(r.store.DB()).
+		Joins("JOIN org_group_members ON org_group_members.group_pkid = org_groups.pkid").
+		Where("org_groups.org_pkid = ? AND org_group_members.user_pkid = ?", orgPkID, userPkID).
+		Order("lower(org_groups.name)").
+		Scan(&groups).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return sliceutils.Map(groups, func(group organizationutils.OrgGroupWithMembers) domain.OrgGroup {
+		return *organizationutils.TransformOrgGroupModelToDomain(group)
+	}), nil
+}
+
+// GetOrgGroupByID returns the group of the organization with its members.
+func (r *OrganizationRepository) GetOrgGroupByID(
+	ctx context.Context,
+	orgPkID int64,
+	groupID string,
+) (*domain.OrgGroup, *domain.Error) {
+	var group organizationutils.OrgGroupWithMembers
+	err := queryOrgGroups(r.store.DB()).
+		Where("org_groups.org_pkid = ? AND org_groups.id = ?", orgPkID, groupID).
+		Take(&group).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrgGroupNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	if err := r.store.DB().
+		Joins("JOIN org_group_members ON org_group_members.user_pkid = users.pkid").
+		Where("org_group_members.group_pkid = ?", group.Pkid).
+		Order("org_group_members.created_at").
+		Find(&group.Members).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return organizationutils.TransformOrgGroupModelToDomain(group), nil
+}
+
+func (r *OrganizationRepository) CreateOrgGroup(ctx context.Context, input domain.OrgGroupInput) (*domain.OrgGroup, *domain.Error) {
+	if taken, err := orgGroupNameTaken(r.store.DB(), input.OrganizationPkID, input.Name, 0); err != nil {
+		return nil, domain.ErrDatabaseQuery
+	} else if taken {
+		return nil, domain.ErrOrgGroupNameTaken
+	}
+
+	group := model.OrgGroup{
+		OrgPkid:     input.OrganizationPkID,
+		Name:        input.Name,
+		Description: input.Description,
+	}
+	if err := r.store.DB().Clauses(clause.Returning{}).Create(&group).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	return organizationutils.TransformOrgGroupModelToDomain(organizationutils.OrgGroupWithMembers{
+		OrgGroup: group,
+	}), nil
+}
+
+func (r *OrganizationRepository) UpdateOrgGroup(
+	ctx context.Context,
+	group domain.OrgGroup,
+	input domain.OrgGroupUpdateInput,
+) (*domain.OrgGroup, *domain.Error) {
+	updates := map[string]any{"updated_at": time.Now()}
+	if input.Name != nil {
+		if taken, err := orgGroupNameTaken(r.store.DB(), group.OrganizationPkID, *input.Name, group.PkID); err != nil {
+			return nil, domain.ErrDatabaseQuery
+		} else if taken {
+			return nil, domain.ErrOrgGroupNameTaken
+		}
+		updates["name"] = *input.Name
+	}
+	if input.Description != nil {
+		updates["description"] = *input.Description
+	}
+
+	if err := r.store.DB().Model(&model.OrgGroup{}).Where("pkid = ?", group.PkID).Updates(updates).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	return r.GetOrgGroupByID(ctx, group.OrganizationPkID, group.ID)
+}
+
+// DeleteOrgGroup deletes the group, its members and the roles given to it on pages.
+func (r *OrganizationRepository) DeleteOrgGroup(ctx context.Context, groupPkID int64) *domain.Error {
+	if err := r.store.DB().Where("pkid = ?", groupPkID).Delete(&model.OrgGroup{}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+// AddOrgGroupMembers adds the users to the group, users already in the group are skipped.
+func (r *OrganizationRepository) AddOrgGroupMembers(ctx context.Context, groupPkID int64, userPkIDs []int64) *domain.Error {
+	if len(userPkIDs) == 0 {
+		return nil
+	}
+
+	members := sliceutils.Map(userPkIDs, func(userPkID int64) model.OrgGroupMember {
+		return model.OrgGroupMember{
+			GroupPkid: groupPkID,
+			UserPkid:  userPkID,
+		}
+	})
+	if err := r.store.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(&members).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) RemoveOrgGroupMember(ctx context.Context, groupPkID int64, userPkID int64) *domain.Error {
+	if err := r.store.DB().
+		Where("group_pkid = ? AND user_pkid = ?", groupPkID, userPkID).
+		Delete(&model.OrgGroupMember{}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+func queryOrgGroups(tx *gorm.DB) *gorm.DB {
+	return tx.Table(model.TableNameOrgGroup).Select(
+		"org_groups.*, (SELECT COUNT(*) FROM org_group_members WHERE org_group_members.group_pkid = org_groups.pkid) AS member_count",
+	)
+}
+
+func orgGroupNameTaken(tx *gorm.DB, orgPkID int64, name string, exceptPkID int64) (bool, error) {
+	var count int64
+	err := tx.Model(&model.OrgGroup{}).
+		Where("org_pkid = ? AND lower(name) = lower(?) AND pkid <> ?", orgPkID, name, exceptPkID).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/core/domain"
+	store "github.com/Stuhub-io/internal/repository"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/outboxutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"gorm.io/gorm"
+)
+
+// Claimed events are leased by pushing next_attempt_at, an event whose dispatcher died is claimed again once the lease ends.
+const claimOutboxEventsQuery = `
+UPDATE outbox_events
+SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => @lease)
+WHERE pkid IN (
+	SELECT pkid FROM outbox_events
+	WHERE status = 'pending' AND next_attempt_at <= NOW()
+	ORDER BY next_attempt_at, pkid
+	LIMIT @limit
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *
+`
+
+type OutboxRepository struct {
+	store *store.DBStore
+	cfg   config.Config
+}
+
+type NewOutboxRepositoryParams struct {
+	Cfg   config.Config
+	Store *store.DBStore
+}
+
+func NewOutboxRepository(params NewOutboxRepositoryParams) *OutboxRepository {
+	return &OutboxRepository{
+		store: params.Store,
+		cfg:   params.Cfg,
+	}
+}
+
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxEvent, *domain.Error) {
+	var events []model.OutboxEvent
+	if err := r.store.DB().WithContext(ctx).Raw(claimOutboxEventsQuery, map[string]any{
+		"lease": lease.Seconds(),
+		"limit": limit,
+	}).Scan(&events).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	// Events are delivered in the order they were written, an unindex must not run before an earlier index
+	slices.SortFunc(events, func(a, b model.OutboxEvent) int {
+		return cmp.Compare(a.Pkid, b.Pkid)
+	})
+
+	return sliceutils.Map(events, outboxutils.TransformOutboxEventModelToDomain), nil
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, eventPkID int64) *domain.Error {
+	if err := r.store.DB().WithContext(ctx).Model(&model.OutboxEvent{}).
+		Where("pkid = ?", eventPkID).
+		Updates(map[string]any{
+			"status":       domain.OutboxDelivered.String(),
+			"delivered_at": time.Now(),
+			"last_error":   nil,
+		}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+// MarkFailed schedules the next attempt of the event, a nil nextAttemptAt moves it to the dead letters.
+func (r *OutboxRepository) MarkFailed(
+	ctx context.Context,
+	eventPkID int64,
+	lastError string,
+	nextAttemptAt *time.Time,
+) *domain.Error {
+	updates := map[string]any{
+		"last_error": lastError,
+	}
+	if nextAttemptAt != nil {
+		updates["next_attempt_at"] = *nextAttemptAt
+	} else {
+		updates["status"] = domain.OutboxDead.String()
+		updates["next_attempt_at"] = time.Now()
+	}
+
+	if err := r.store.DB().WithContext(ctx).Model(&model.OutboxEvent{}).
+		Where("pkid = ?", eventPkID).
+		Updates(updates).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+func (r *OutboxRepository) DeleteDeliveredBefore(ctx context.Context, before time.Time) (int, *domain.Error) {
+	result := r.store.DB().WithContext(ctx).
+		Where("status = ? AND delivered_at < ?", domain.OutboxDelivered.String(), before).
+		Delete(&model.OutboxEvent{})
+	if result.Error != nil {
+		return 0, domain.ErrDatabaseMutation
+	}
+	return int(result.RowsAffected), nil
+}
+
+// stageOutboxEvents writes the events attached to ctx in the transaction of the mutation,
+// they are only delivered if it commits.
+func stageOutboxEvents(ctx context.Context, tx *gorm.DB, pages ...domain.Page) error {
+	events := outboxutils.Events(ctx, pages...)
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]model.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, model.OutboxEvent{
+			Topic:   event.Topic.String(),
+			Payload: string(payload),
+			Status:  domain.OutboxPending.String(),
+		})
+	}
+
+	return tx.Create(&rows).Error
+}
@@ -155,6 +155,17 @@ func (r *PageRepository) List(
 		return nil
 	}
 
+	sharedRoles := make(map[int64]domain.PageRole)
+	if curUser != nil {
+		var err error
+		sharedRoles, err = resolveSharedRoles(r.store.DB(), curUser.PkID, sliceutils.Map(results, func(result PageResult) int64 {
+			return result.Pkid
+		}))
+		if err != nil {
+			return nil, domain.ErrDatabaseQuery
+		}
+	}
+
 	domainPages := make([]domain.Page, 0, len(results))
 
 	for _, result := range results {
@@ -201,9 +212,10 @@ func (r *PageRepository) List(
 		)
 
 		permission := r.CheckPermission(ctx, domain.PageRolePermissionCheckInput{
-			Page:     *domainPage,
-			User:     curUser,
-			PageRole: directUserRole,
+			Page:       *domainPage,
+			User:       curUser,
+			PageRole:   directUserRole,
+			SharedRole: sharedRolePtr(sharedRoles, result.Pkid),
 		})
 
 		domainPage.Permissions = &permission
@@ -220,6 +232,35 @@ func (r *PageRepository) List(
 	return domainPages, nil
 }
 
+// ListWithBody lists pages along with their document and asset, without resolving permissions.
+// Meant for internal jobs such as search indexing, user facing listings go through List.
+func (r *PageRepository) ListWithBody(
+	ctx context.Context,
+	q domain.PageListQuery,
+) ([]domain.Page, *domain.Error) {
+	var results []PageResult
+	query := buildPageQuery(preloadPageResult(r.store.DB(), PreloadPageResultParams{
+		Asset: true,
+		Doc:   true,
+	}), q)
+
+	if err := query.Find(&results).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return sliceutils.Map(results, func(result PageResult) domain.Page {
+		return *pageutils.TransformPageModelToDomain(
+			pageutils.PageModelToDomainParams{
+				Page: &result.Page,
+				PageBody: pageutils.PageBodyParams{
+					Document: pageutils.TransformDocModelToDomain(result.Doc),
+					Asset:    pageutils.TransformAssetModalToDomain(result.Asset),
+				},
+			},
+		)
+	}), nil
+}
+
 func (r *PageRepository) Update(
 	ctx context.Context,
 	pagePkID int64,
@@ -241,20 +282,60 @@ func (r *PageRepository) Update(
 		page.CoverImage = *updateInput.CoverImage
 	}
 
-	dbErr := r.store.DB().
+	if updateInput.IsTemplate != nil {
+		page.IsTemplate = *updateInput.IsTemplate
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	// Conditional update, a stale version matches no row
+	query := tx.DB().
+		Model(&page).
 		Clauses(clause.Returning{}).
-		Select("Name", "ViewType", "CoverImage").
-		Save(&page).
-		Error
-	if dbErr != nil {
+		Where("pkid = ?", pagePkID)
+	if updateInput.Version != nil {
+		query = query.Where("version = ?", *updateInput.Version)
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"name":        page.Name,
+		"view_type":   page.ViewType,
+		"cover_image": page.CoverImage,
+		"is_template": page.IsTemplate,
+		"version":     gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		doneTx(result.Error)
 		return nil, domain.ErrDatabaseMutation
 	}
 
-	return pageutils.TransformPageModelToDomain(
+	if result.RowsAffected == 0 {
+		doneTx(nil)
+		var current model.Page
+		if dbErr := r.store.DB().Where("pkid = ?", pagePkID).First(&current).Error; dbErr != nil {
+			return nil, domain.ErrDatabaseQuery
+		}
+		return pageutils.TransformPageModelToDomain(
+			pageutils.PageModelToDomainParams{
+				Page: &current,
+			},
+		), domain.ErrStaleVersion(current.Version)
+	}
+
+	updated := pageutils.TransformPageModelToDomain(
 		pageutils.PageModelToDomainParams{
 			Page: &page,
 		},
-	), nil
+	)
+	if err := stageOutboxEvents(ctx, tx.DB(), *updated); err != nil {
+		return nil, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
 func (r *PageRepository) GetByID(
@@ -294,12 +375,18 @@ func (r *PageRepository) GetByID(
 	// Get Actual General Role
 	var inheritPage *model.Page
 	if page.GeneralRole == domain.PageInherit.String() {
-		query := buildPageQuery(r.store.DB(), domain.PageListQuery{
+		var basePages []model.Page
+		if err := buildPageQuery(r.store.DB(), domain.PageListQuery{
 			OrgPkID:            page.OrgPkid,
 			ExcludeGeneralRole: []domain.PageRole{domain.PageInherit},
 			PagePkIDs:          parentPagePkIDs,
-		})
-		if err := query.First(&inheritPage).Error; err != nil {
+			IsAll:              true,
+		}).Find(&basePages).Error; err != nil {
+			return nil, domain.ErrDatabaseQuery
+		}
+
+		inheritPage = closestGeneralRolePage(parentPagePkIDs, basePages)
+		if inheritPage == nil {
 			page.GeneralRole = domain.PageRestrict.String()
 		} else {
 			page.GeneralRole = inheritPage.GeneralRole
@@ -334,41 +421,26 @@ func (r *PageRepository) Archive(
 		return nil, domain.NewErr(dbErr.Error(), domain.BadRequestCode)
 	}
 
-	now := time.Now()
-	page.ArchivedAt = &now
-	page.ParentPagePkid = nil
-
 	tx, done := r.store.NewTransaction()
 
-	descendantPath := pageutils.AppendPath(page.Path, strconv.FormatInt(page.Pkid, 10))
-
-	// Archive current page, Move page to root
-	if dbErr := tx.DB().Clauses(clause.Locking{
-		Strength: clause.LockingStrengthShare, // FIXME: Need Locking ?
-	}, clause.Returning{}).Select("ArchivedAt", "ParentPagePkid").Save(&page).Error; dbErr != nil {
-		return nil, done(dbErr)
-	}
-
-	// Archive childrens
-	if dbErr := tx.DB().Clauses(clause.Locking{
-		Strength: clause.LockingStrengthShare, // FIXME: Need Locking ?
-	}, clause.Returning{}).
-		Model(&model.Page{}).
-		Where("path LIKE ? AND archived_at IS NULL", descendantPath+"%").
-		Select("ArchivedAt").
-		Updates(model.Page{
-			ArchivedAt: &now,
-		}).Error; dbErr != nil {
+	if dbErr := archivePage(tx.DB(), &page, time.Now()); dbErr != nil {
 		return nil, done(dbErr)
 	}
 
-	done(nil)
-
-	return pageutils.TransformPageModelToDomain(
+	archived := pageutils.TransformPageModelToDomain(
 		pageutils.PageModelToDomainParams{
 			Page: &page,
 		},
-	), nil
+	)
+	if err := stageOutboxEvents(ctx, tx.DB(), *archived); err != nil {
+		return nil, done(err)
+	}
+
+	if err := done(nil); err != nil {
+		return nil, err
+	}
+
+	return archived, nil
 }
 
 func (r *PageRepository) Move(
@@ -383,53 +455,98 @@ func (r *PageRepository) Move(
 		return nil, domain.NewErr("Page not found", domain.BadRequestCode)
 	}
 
-	oldPath := page.Path
-
 	// Begin Tx
 	tx, doneTx := r.store.NewTransaction()
 
+	if dbErr := movePage(tx.DB(), &page, parentPagePkID); dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+
+	moved := pageutils.TransformPageModelToDomain(
+		pageutils.PageModelToDomainParams{
+			Page: &page,
+		},
+	)
+	if err := stageOutboxEvents(ctx, tx.DB(), *moved); err != nil {
+		return nil, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+	// Commit Tx
+
+	return moved, nil
+}
+
+// archivePage archives the page with its descendants and moves it to the root of the trash,
+// the path is kept to restore it later.
+func archivePage(tx *gorm.DB, page *model.Page, archivedAt time.Time) error {
+	page.ArchivedAt = &archivedAt
+	page.ParentPagePkid = nil
+
+	descendantPath := pageutils.AppendPath(page.Path, strconv.FormatInt(page.Pkid, 10))
+
+	// Archive current page, Move page to root
+	if err := tx.Clauses(clause.Locking{
+		Strength: clause.LockingStrengthShare, // FIXME: Need Locking ?
+	}, clause.Returning{}).Select("ArchivedAt", "ParentPagePkid").Save(page).Error; err != nil {
+		return err
+	}
+
+	// Archive childrens
+	if err := tx.Clauses(clause.Locking{
+		Strength: clause.LockingStrengthShare, // FIXME: Need Locking ?
+	}, clause.Returning{}).
+		Model(&model.Page{}).
+		Where("(path = ? OR path LIKE ?) AND archived_at IS NULL", descendantPath, descendantPath+"/%").
+		Select("ArchivedAt").
+		Updates(model.Page{
+			ArchivedAt: &archivedAt,
+		}).Error; err != nil {
+		return err
+	}
+
+	return deleteArchivedPageLinks(tx, page)
+}
+
+// movePage moves the page with its descendants under the parent, to the root when nil.
+func movePage(tx *gorm.DB, page *model.Page, parentPagePkID *int64) error {
+	oldPath := page.Path
+
 	// get new path
 	newPath := ""
 
 	if parentPagePkID != nil {
 		var parentPage model.Page
-		if dbErr := tx.DB().Where("pkid = ?", parentPagePkID).First(&parentPage).Error; dbErr != nil {
-			return nil, doneTx(dbErr)
+		if err := tx.Where("pkid = ?", parentPagePkID).First(&parentPage).Error; err != nil {
+			return err
 		}
 		newPath = pageutils.AppendPath(parentPage.Path, strconv.FormatInt(parentPage.Pkid, 10))
 	}
 
+	// Moved pages go last among their new siblings
+	if !samePkID(page.ParentPagePkid, parentPagePkID) {
+		position, err := nextSiblingPosition(tx, page.OrgPkid, parentPagePkID)
+		if err != nil {
+			return err
+		}
+		page.Position = position
+	}
+
 	// update page path
 	page.Path = newPath
 	page.ParentPagePkid = parentPagePkID
 
-	dbErr := tx.DB().Clauses(clause.Returning{}).Select("Path", "ParentPagePkid").Save(&page).Error
-
-	if dbErr != nil {
-		return nil, doneTx(dbErr)
+	if err := tx.Clauses(clause.Returning{}).Select("Path", "ParentPagePkid", "Position").Save(page).Error; err != nil {
+		return err
 	}
 
 	descendantPath := pageutils.AppendPath(page.Path, strconv.FormatInt(page.Pkid, 10))
-	descendantOldPath := pageutils.AppendPath(oldPath, page.ID)
+	descendantOldPath := pageutils.AppendPath(oldPath, strconv.FormatInt(page.Pkid, 10))
 
 	// batch update descendants
-	bErr := tx.DB().
-		Model(&model.Page{}).
-		Where("path LIKE ?", descendantOldPath+"%").
-		Update("path", gorm.Expr("replace(path, ?, ?)", descendantOldPath, descendantPath)).
-		Error
-	if bErr != nil {
-		return nil, doneTx(bErr)
-	}
-
-	doneTx(nil)
-	// Commit Tx
-
-	return pageutils.TransformPageModelToDomain(
-		pageutils.PageModelToDomainParams{
-			Page: &page,
-		},
-	), nil
+	return rewriteDescendantPaths(tx, descendantOldPath, descendantPath)
 }
 
 func (r *PageRepository) UpdateGeneralAccess(
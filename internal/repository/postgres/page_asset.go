@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Stuhub-io/core/domain"
 	"github.com/Stuhub-io/internal/repository/model"
@@ -11,24 +12,25 @@ import (
 // Asset Page.
 func (r *PageRepository) CreateAsset(ctx context.Context, assetInput domain.AssetPageInput) (*domain.Page, *domain.Error) {
 
-	initPageResult, iErr := r.initPageModel(preloadPageResult(r.store.DB(), PreloadPageResultParams{
+	author := &model.User{}
+	if err := r.store.DB().Where("pkid = ?", assetInput.AuthorPkID).First(author).Error; err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	// The position is computed with the sibling set locked
+	tx, doneTx := r.store.NewTransaction()
+
+	initPageResult, iErr := r.initPageModel(preloadPageResult(tx.DB(), PreloadPageResultParams{
 		Author: true, // Init Page with Parent Page Preload
 	}), assetInput.PageInput)
-
 	if iErr != nil {
+		doneTx(errors.New(iErr.Message))
 		return nil, iErr
 	}
 
 	newPage := initPageResult.Page
 
-	author := &model.User{}
-	if err := r.store.DB().Where("pkid = ?", newPage.AuthorPkid).First(author).Error; err != nil {
-		return nil, domain.ErrBadRequest
-	}
-
-	tx, doneTx := r.store.NewTransaction()
-
-	err := preloadPageResult(r.store.DB(), PreloadPageResultParams{
+	err := preloadPageResult(tx.DB(), PreloadPageResultParams{
 		Author: true,
 	}).Create(&newPage).Error
 
@@ -46,7 +48,7 @@ func (r *PageRepository) CreateAsset(ctx context.Context, assetInput domain.Asse
 
 	rerr := tx.DB().Create(&asset).Error
 	if rerr != nil {
-		return nil, doneTx(err)
+		return nil, doneTx(rerr)
 	}
 
 	parentFolder := initPageResult.ParentFolder
@@ -62,15 +64,22 @@ func (r *PageRepository) CreateAsset(ctx context.Context, assetInput domain.Asse
 		}
 	}
 
-	doneTx(nil)
-	// Commit Tx
-
-	return pageutils.TransformPageModelToDomain(
+	created := pageutils.TransformPageModelToDomain(
 		pageutils.PageModelToDomainParams{
 			Page: newPage,
 			PageBody: pageutils.PageBodyParams{
 				Asset: pageutils.TransformAssetModalToDomain(&asset),
 			},
 		},
-	), nil
+	)
+	if err := stageOutboxEvents(ctx, tx.DB(), *created); err != nil {
+		return nil, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+	// Commit Tx
+
+	return created, nil
 }
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	commonutils "github.com/Stuhub-io/utils"
+	"github.com/Stuhub-io/utils/pageutils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BulkUpdate applies the action to every page in a single transaction.
+// Each page runs in its own savepoint, so a failed page does not roll back the others.
+func (r *PageRepository) BulkUpdate(
+	ctx context.Context,
+	input domain.PageBulkInput,
+) ([]domain.PageBulkResult, *domain.Error) {
+	results := make([]domain.PageBulkResult, 0, len(input.PagePkIDs))
+	if len(input.PagePkIDs) == 0 {
+		return results, nil
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+	now := time.Now()
+
+	for idx, pagePkID := range input.PagePkIDs {
+		result := domain.PageBulkResult{PagePkID: pagePkID}
+
+		// Reload in the transaction, earlier pages of the batch may have moved this one
+		var page model.Page
+		if dbErr := tx.DB().Where("pkid = ?", pagePkID).First(&page).Error; dbErr != nil {
+			result.Error = domain.ErrNotFound
+			results = append(results, result)
+			continue
+		}
+
+		savepoint := fmt.Sprintf("bulk_page_%d", idx)
+		if dbErr := tx.DB().SavePoint(savepoint).Error; dbErr != nil {
+			return nil, doneTx(dbErr)
+		}
+
+		if err := bulkUpdatePage(tx.DB(), &page, input, now); err != nil {
+			if dbErr := tx.DB().RollbackTo(savepoint).Error; dbErr != nil {
+				return nil, doneTx(dbErr)
+			}
+			result.Error = err
+			results = append(results, result)
+			continue
+		}
+
+		result.Success = true
+		result.Page = pageutils.TransformPageModelToDomain(
+			pageutils.PageModelToDomainParams{
+				Page: &page,
+			},
+		)
+		results = append(results, result)
+	}
+
+	updated := []domain.Page{}
+	for _, result := range results {
+		if result.Success {
+			updated = append(updated, *result.Page)
+		}
+	}
+	if err := stageOutboxEvents(ctx, tx.DB(), updated...); err != nil {
+		return nil, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func bulkUpdatePage(
+	tx *gorm.DB,
+	page *model.Page,
+	input domain.PageBulkInput,
+	now time.Time,
+) *domain.Error {
+	var dbErr error
+
+	switch input.Action {
+	case domain.PageBulkMove:
+		if input.ParentPagePkID != nil {
+			var parent model.Page
+			if err := tx.Where("pkid = ? AND archived_at IS NULL", *input.ParentPagePkID).First(&parent).Error; err != nil {
+				return domain.NewErr("Parent Page not found", domain.BadRequestCode)
+			}
+			if parent.OrgPkid == nil || page.OrgPkid == nil || *parent.OrgPkid != *page.OrgPkid {
+				return domain.NewErr("Parent page belongs to another organization", domain.BadRequestCode)
+			}
+			if parent.Pkid == page.Pkid || slices.Contains(pageutils.PagePathToPkIDs(parent.Path), page.Pkid) {
+				return domain.NewErr("A page can not be moved into itself or its descendants", domain.BadRequestCode)
+			}
+		}
+		dbErr = movePage(tx, page, input.ParentPagePkID)
+
+	case domain.PageBulkArchive:
+		// Already archived, possibly along with an ancestor of the batch
+		if page.ArchivedAt != nil {
+			return nil
+		}
+		dbErr = archivePage(tx, page, now)
+
+	case domain.PageBulkRestore:
+		// Already restored along with an ancestor of the batch
+		if page.ArchivedAt == nil {
+			return nil
+		}
+		if page.ParentPagePkid != nil {
+			return domain.ErrPageNotTrashRoot
+		}
+		dbErr = restorePage(tx, page)
+
+	case domain.PageBulkStar:
+		dbErr = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.PageStar{
+			UserPkid: input.ActorPkID,
+			PagePkid: page.Pkid,
+			Order:    commonutils.CurTimestampAsFloat64(),
+		}).Error
+
+	case domain.PageBulkUnstar:
+		dbErr = tx.
+			Where("user_pkid = ? AND page_pkid = ?", input.ActorPkID, page.Pkid).
+			Delete(&model.PageStar{}).Error
+
+	case domain.PageBulkGeneralAccess:
+		if input.GeneralRole == nil {
+			return domain.ErrBadParamInput
+		}
+		if *input.GeneralRole == domain.PageInherit && page.ParentPagePkid == nil {
+			return domain.NewErr("A root page can not inherit its general access", domain.BadRequestCode)
+		}
+		page.GeneralRole = input.GeneralRole.String()
+		dbErr = tx.Clauses(clause.Returning{}).Select("GeneralRole").Save(page).Error
+
+	default:
+		return domain.NewErr("Unsupported bulk action", domain.BadRequestCode)
+	}
+
+	if dbErr != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
@@ -2,10 +2,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Stuhub-io/core/domain"
 	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/documentutils"
 	"github.com/Stuhub-io/utils/pageutils"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -13,28 +16,28 @@ func (r *PageRepository) CreateDocumentPage(
 	ctx context.Context,
 	pageInput domain.DocumentPageInput,
 ) (*domain.Page, *domain.Error) {
-	result, iErr := r.initPageModel(preloadPageResult(r.store.DB(), PreloadPageResultParams{
-		Author: true, // Init Page with Parent Page Preload
-	}), pageInput.PageInput)
-	newPage := result.Page
-
-	if iErr != nil {
-		return nil, iErr
-	}
-
 	if pageInput.Document.JsonContent == "" {
 		pageInput.Document.JsonContent = "{}"
 	}
 
 	author := &model.User{}
-	if err := r.store.DB().Where("pkid = ?", newPage.AuthorPkid).First(author).Error; err != nil {
+	if err := r.store.DB().Where("pkid = ?", pageInput.AuthorPkID).First(author).Error; err != nil {
 		return nil, domain.ErrBadRequest
 	}
 
-	// Begin Tx
+	// Begin Tx, the position is computed with the sibling set locked
 	tx, doneTx := r.store.NewTransaction()
 
-	err := preloadPageResult(r.store.DB(), PreloadPageResultParams{
+	result, iErr := r.initPageModel(preloadPageResult(tx.DB(), PreloadPageResultParams{
+		Author: true, // Init Page with Parent Page Preload
+	}), pageInput.PageInput)
+	if iErr != nil {
+		doneTx(errors.New(iErr.Message))
+		return nil, iErr
+	}
+	newPage := result.Page
+
+	err := preloadPageResult(tx.DB(), PreloadPageResultParams{
 		Author: true,
 	}).Create(&newPage).Error
 
@@ -47,12 +50,21 @@ func (r *PageRepository) CreateDocumentPage(
 	if pageInput.ViewType == domain.PageViewTypeDoc {
 		document = model.Document{
 			JSONContent: &pageInput.Document.JsonContent,
+			Content:     documentutils.PlainTextFromJSON(pageInput.Document.JsonContent),
 			PagePkid:    newPage.Pkid,
 		}
 
 		rerr := tx.DB().Create(&document).Error
 		if rerr != nil {
-			return nil, doneTx(err)
+			return nil, doneTx(rerr)
+		}
+		if rerr := createDocumentRevisions(tx.DB(), []model.Document{document}, func(model.Document) *int64 {
+			return newPage.AuthorPkid
+		}); rerr != nil {
+			return nil, doneTx(rerr)
+		}
+		if rerr := syncPageLinks(tx.DB(), []int64{newPage.Pkid}); rerr != nil {
+			return nil, doneTx(rerr)
 		}
 	}
 	// Inherit Parent Permission
@@ -70,22 +82,30 @@ func (r *PageRepository) CreateDocumentPage(
 		}
 	}
 
-	doneTx(nil)
-
-	return pageutils.TransformPageModelToDomain(
+	created := pageutils.TransformPageModelToDomain(
 		pageutils.PageModelToDomainParams{
 			Page: newPage,
 			PageBody: pageutils.PageBodyParams{
 				Document: pageutils.TransformDocModelToDomain(&document),
 			},
 		},
-	), nil
+	)
+	if err := stageOutboxEvents(ctx, tx.DB(), *created); err != nil {
+		return nil, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (r *PageRepository) UpdateContent(
 	ctx context.Context,
 	pagePkID int64,
 	content domain.DocumentInput,
+	authorPkID *int64,
 ) (*domain.Page, *domain.Error) {
 	var page = model.Page{}
 	if dbErr := r.store.DB().Where("pkid = ?", pagePkID).First(&page).Error; dbErr != nil {
@@ -99,17 +119,95 @@ func (r *PageRepository) UpdateContent(
 	if content.JsonContent == "" {
 		content.JsonContent = "{}"
 	}
-	doc.JSONContent = &content.JsonContent
-	if dbErr := r.store.DB().Clauses(clause.Returning{}).Select("*").Save(&doc).Error; dbErr != nil {
+	if content.Version != nil && *content.Version != doc.Version {
+		return pageutils.TransformPageModelToDomain(
+			pageutils.PageModelToDomainParams{
+				Page: &page,
+				PageBody: pageutils.PageBodyParams{
+					Document: pageutils.TransformDocModelToDomain(&doc),
+				},
+			},
+		), domain.ErrStaleVersion(doc.Version)
+	}
+
+	prior := doc
+
+	// Begin Tx
+	tx, doneTx := r.store.NewTransaction()
+
+	// Conditional update, guards against a concurrent write since the read above
+	result := tx.DB().
+		Model(&doc).
+		Clauses(clause.Returning{}).
+		Where("version = ?", doc.Version).
+		Updates(map[string]interface{}{
+			"json_content": content.JsonContent,
+			"content":      documentutils.PlainTextFromJSON(content.JsonContent),
+			"updated_at":   gorm.Expr("now()"),
+			"version":      gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		doneTx(result.Error)
 		return nil, domain.ErrDatabaseMutation
 	}
+	if result.RowsAffected == 0 {
+		doneTx(nil)
+		if dbErr := r.store.DB().Where("page_pkid = ?", pagePkID).First(&doc).Error; dbErr != nil {
+			return nil, domain.ErrDatabaseQuery
+		}
+		return pageutils.TransformPageModelToDomain(
+			pageutils.PageModelToDomainParams{
+				Page: &page,
+				PageBody: pageutils.PageBodyParams{
+					Document: pageutils.TransformDocModelToDomain(&doc),
+				},
+			},
+		), domain.ErrStaleVersion(doc.Version)
+	}
+
+	// Documents created before revisions were kept have no snapshot of the content being overwritten
+	var revisionCount int64
+	if dbErr := tx.DB().Model(&model.DocumentRevision{}).Where("document_pkid = ?", doc.Pkid).Count(&revisionCount).Error; dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+	if revisionCount == 0 {
+		priorRevision := documentRevision(prior, nil)
+		priorRevision.CreatedAt = prior.UpdatedAt
+		if dbErr := tx.DB().Create(&priorRevision).Error; dbErr != nil {
+			return nil, doneTx(dbErr)
+		}
+	}
+
+	// Snapshot every content update, so overwritten content can be restored later
+	revision := model.DocumentRevision{
+		DocumentPkid: doc.Pkid,
+		PagePkid:     doc.PagePkid,
+		AuthorPkid:   authorPkID,
+		JSONContent:  &content.JsonContent,
+		Size:         int64(len(content.JsonContent)),
+	}
+	if dbErr := tx.DB().Create(&revision).Error; dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+	if dbErr := syncPageLinks(tx.DB(), []int64{pagePkID}); dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
 
-	return pageutils.TransformPageModelToDomain(
+	updated := pageutils.TransformPageModelToDomain(
 		pageutils.PageModelToDomainParams{
 			Page: &page,
 			PageBody: pageutils.PageBodyParams{
 				Document: pageutils.TransformDocModelToDomain(&doc),
 			},
 		},
-	), nil
+	)
+	if err := stageOutboxEvents(ctx, tx.DB(), *updated); err != nil {
+		return nil, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
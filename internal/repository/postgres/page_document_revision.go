@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/pageutils"
+	"gorm.io/gorm"
+)
+
+type DocumentRevisionResult struct {
+	model.DocumentRevision
+	Author *model.User `gorm:"foreignKey:author_pkid"`
+}
+
+func (r *PageRepository) ListDocumentRevisions(
+	ctx context.Context,
+	q domain.DocumentRevisionListQuery,
+) ([]domain.DocumentRevision, *domain.Error) {
+	var results []DocumentRevisionResult
+
+	// Revision list is metadata only, content is fetched per revision
+	query := r.store.DB().
+		Preload("Author").
+		Omit("json_content").
+		Where("page_pkid = ?", q.PagePkID).
+		Order("created_at desc").
+		Order("pkid desc").
+		Offset(q.Offset)
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+
+	if err := query.Find(&results).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	revisions := make([]domain.DocumentRevision, 0, len(results))
+	for _, result := range results {
+		revisions = append(revisions, *pageutils.TransformDocumentRevisionModelToDomain(
+			pageutils.DocumentRevisionToDomainParams{
+				Model:  &result.DocumentRevision,
+				Author: result.Author,
+			},
+		))
+	}
+
+	return revisions, nil
+}
+
+func (r *PageRepository) GetDocumentRevision(
+	ctx context.Context,
+	pagePkID int64,
+	revisionPkID int64,
+) (*domain.DocumentRevision, *domain.Error) {
+	var result DocumentRevisionResult
+
+	if err := r.store.DB().
+		Preload("Author").
+		Where("pkid = ? AND page_pkid = ?", revisionPkID, pagePkID).
+		First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrDocumentRevisionNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return pageutils.TransformDocumentRevisionModelToDomain(
+		pageutils.DocumentRevisionToDomainParams{
+			Model:  &result.DocumentRevision,
+			Author: result.Author,
+		},
+	), nil
+}
+
+// createDocumentRevisions snapshots the content the documents are created with, so the first update of
+// their content can be undone.
+func createDocumentRevisions(tx *gorm.DB, docs []model.Document, authorPkID func(doc model.Document) *int64) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	revisions := make([]model.DocumentRevision, 0, len(docs))
+	for _, doc := range docs {
+		revisions = append(revisions, documentRevision(doc, authorPkID(doc)))
+	}
+	return tx.Create(&revisions).Error
+}
+
+func documentRevision(doc model.Document, authorPkID *int64) model.DocumentRevision {
+	jsonContent := "{}"
+	if doc.JSONContent != nil {
+		jsonContent = *doc.JSONContent
+	}
+	return model.DocumentRevision{
+		DocumentPkid: doc.Pkid,
+		PagePkid:     doc.PagePkid,
+		AuthorPkid:   authorPkID,
+		JSONContent:  &jsonContent,
+		Size:         int64(len(jsonContent)),
+	}
+}
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/documentutils"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DuplicatePage deep copies the page and the given descendants with their documents and assets.
+// Copies get the same role setup as newly created pages, explicit roles are copied on demand.
+func (r *PageRepository) DuplicatePage(
+	ctx context.Context,
+	pagePkID int64,
+	input domain.PageDuplicateInput,
+) (*domain.Page, *domain.Error) {
+	var source model.Page
+	if dbErr := r.store.DB().Where("pkid = ?", pagePkID).First(&source).Error; dbErr != nil {
+		return nil, domain.ErrNotFound
+	}
+	if source.ArchivedAt != nil {
+		return nil, domain.ErrPageArchived
+	}
+
+	actor := model.User{}
+	if dbErr := r.store.DB().Where("pkid = ?", input.ActorPkID).First(&actor).Error; dbErr != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	// Source pages sorted by depth, so parents are copied before their children
+	sources := []model.Page{source}
+	if len(input.DescendantPkIDs) > 0 {
+		descendantPath := pageutils.AppendPath(source.Path, strconv.FormatInt(source.Pkid, 10))
+
+		var descendants []model.Page
+		if dbErr := r.store.DB().
+			Where("pkid IN ? AND (path = ? OR path LIKE ?) AND archived_at IS NULL", input.DescendantPkIDs, descendantPath, descendantPath+"/%").
+			Find(&descendants).Error; dbErr != nil {
+			return nil, domain.ErrDatabaseQuery
+		}
+		sort.SliceStable(descendants, func(i, j int) bool {
+			return strings.Count(descendants[i].Path, "/") < strings.Count(descendants[j].Path, "/")
+		})
+		sources = append(sources, descendants...)
+	}
+
+	var parentFolder *model.Page
+	parentFolderAuthorEmail := ""
+	if input.ParentPagePkID != nil {
+		var parent PageResult
+		if dbErr := preloadPageResult(r.store.DB(), PreloadPageResultParams{
+			Author: true,
+		}).Where("pkid = ?", *input.ParentPagePkID).First(&parent).Error; dbErr != nil {
+			return nil, domain.NewErr("Parent Page not found", domain.BadRequestCode)
+		}
+		parentFolder = &parent.Page
+		if parent.Author != nil {
+			parentFolderAuthorEmail = parent.Author.Email
+		}
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	// old pkid -> copied page
+	copies := make(map[int64]*model.Page, len(sources))
+
+	for idx, src := range sources {
+		var copyParent *model.Page
+		copyParentAuthorEmail := actor.Email
+		name := src.Name
+		generalRole := src.GeneralRole
+		position := src.Position
+
+		if idx == 0 {
+			copyParent = parentFolder
+			copyParentAuthorEmail = parentFolderAuthorEmail
+			name = src.Name + " (Copy)"
+			if input.Name != nil && *input.Name != "" {
+				name = *input.Name
+			}
+			// Same default general access as a newly created page, unless roles are copied
+			if !input.IncludeRoles || generalRole == domain.PageInherit.String() {
+				generalRole = domain.PageInherit.String()
+				if copyParent == nil {
+					generalRole = domain.PageRestrict.String()
+				}
+			}
+			// The copy goes last among its siblings, descendants keep their order
+			var pErr error
+			if position, pErr = nextSiblingPosition(tx.DB(), src.OrgPkid, input.ParentPagePkID); pErr != nil {
+				return nil, doneTx(pErr)
+			}
+		} else {
+			// Skip descendants whose parent was not copied
+			if src.ParentPagePkid == nil {
+				continue
+			}
+			parentCopy, ok := copies[*src.ParentPagePkid]
+			if !ok {
+				continue
+			}
+			copyParent = parentCopy
+		}
+
+		path := ""
+		var parentPagePkID *int64
+		if copyParent != nil {
+			path = pageutils.AppendPath(copyParent.Path, strconv.FormatInt(copyParent.Pkid, 10))
+			parentPagePkID = &copyParent.Pkid
+		}
+
+		newPage := model.Page{
+			Name:           documentutils.ReplaceVariables(name, input.Variables),
+			CoverImage:     src.CoverImage,
+			OrgPkid:        src.OrgPkid,
+			AuthorPkid:     &actor.Pkid,
+			ParentPagePkid: parentPagePkID,
+			ViewType:       src.ViewType,
+			Path:           path,
+			GeneralRole:    generalRole,
+			Position:       position,
+		}
+		if dbErr := tx.DB().Create(&newPage).Error; dbErr != nil {
+			return nil, doneTx(dbErr)
+		}
+		copies[src.Pkid] = &newPage
+
+		if copyParent != nil {
+			if dbErr := inheritPageRoles(tx.DB(), InheritPageRolesParams{
+				ParentFolder:            *copyParent,
+				ParentFolderAuthorEmail: copyParentAuthorEmail,
+				NewPagePkID:             newPage.Pkid,
+				NewPageAuthorPkID:       actor.Pkid,
+				NewPageAuthorEmail:      actor.Email,
+			}); dbErr != nil {
+				return nil, doneTx(dbErr)
+			}
+		}
+	}
+
+	if dbErr := duplicatePageBodies(tx.DB(), source.Pkid, copies, input, actor.Email); dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+
+	duplicated := pageutils.TransformPageModelToDomain(
+		pageutils.PageModelToDomainParams{
+			Page: copies[source.Pkid],
+		},
+	)
+	if err := stageOutboxEvents(ctx, tx.DB(), *duplicated); err != nil {
+		return nil, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	return duplicated, nil
+}
+
+func duplicatePageBodies(
+	tx *gorm.DB,
+	rootPkID int64,
+	copies map[int64]*model.Page,
+	input domain.PageDuplicateInput,
+	actorEmail string,
+) error {
+	sourcePkIDs := make([]int64, 0, len(copies))
+	for pkID := range copies {
+		sourcePkIDs = append(sourcePkIDs, pkID)
+	}
+
+	var docs []model.Document
+	if err := tx.Where("page_pkid IN ?", sourcePkIDs).Find(&docs).Error; err != nil {
+		return err
+	}
+	if len(docs) > 0 {
+		newDocs := make([]model.Document, 0, len(docs))
+		for _, doc := range docs {
+			jsonContent := doc.JSONContent
+			if jsonContent != nil && len(input.Variables) > 0 {
+				replaced := documentutils.ReplaceDocumentVariables(*jsonContent, input.Variables)
+				jsonContent = &replaced
+			}
+			content := documentutils.ReplaceVariables(doc.Content, input.Variables)
+			if jsonContent != nil {
+				content = documentutils.PlainTextFromJSON(*jsonContent)
+			}
+			newDocs = append(newDocs, model.Document{
+				PagePkid:    copies[doc.PagePkid].Pkid,
+				Content:     content,
+				JSONContent: jsonContent,
+			})
+		}
+		if err := tx.Create(&newDocs).Error; err != nil {
+			return err
+		}
+		copyAuthors := make(map[int64]*int64, len(copies))
+		for _, page := range copies {
+			copyAuthors[page.Pkid] = page.AuthorPkid
+		}
+		if err := createDocumentRevisions(tx, newDocs, func(doc model.Document) *int64 {
+			return copyAuthors[doc.PagePkid]
+		}); err != nil {
+			return err
+		}
+		if err := syncPageLinks(tx, sliceutils.Map(newDocs, func(doc model.Document) int64 {
+			return doc.PagePkid
+		})); err != nil {
+			return err
+		}
+	}
+
+	var assets []model.Asset
+	if err := tx.Where("page_pkid IN ?", sourcePkIDs).Find(&assets).Error; err != nil {
+		return err
+	}
+	if len(assets) > 0 {
+		newAssets := make([]model.Asset, 0, len(assets))
+		for _, asset := range assets {
+			newAssets = append(newAssets, model.Asset{
+				PagePkid:   copies[asset.PagePkid].Pkid,
+				URL:        asset.URL,
+				Size:       asset.Size,
+				Extension:  asset.Extension,
+				Thumbnails: asset.Thumbnails,
+			})
+		}
+		if err := tx.Create(&newAssets).Error; err != nil {
+			return err
+		}
+	}
+
+	if !input.IncludeRoles {
+		return nil
+	}
+
+	// Inherit roles of the root refer to the source ancestors, only its explicit roles are copied
+	var roles []model.PageRole
+	if err := tx.
+		Where("page_pkid IN ? AND email != ?", sourcePkIDs, actorEmail).
+		Where("page_pkid != ? OR role != ?", rootPkID, domain.PageInherit.String()).
+		Find(&roles).Error; err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+
+	newRoles := make([]model.PageRole, 0, len(roles))
+	for _, role := range roles {
+		newRoles = append(newRoles, model.PageRole{
+			PagePkid: copies[role.PagePkid].Pkid,
+			UserPkid: role.UserPkid,
+			Email:    role.Email,
+			Role:     role.Role,
+		})
+	}
+
+	// Copied roles override the ones inherited from the new parent
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "page_pkid"}, {Name: "email"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "user_pkid"}),
+	}).Create(&newRoles).Error
+}
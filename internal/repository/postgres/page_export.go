@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Stuhub-io/config"
+	"github.com/Stuhub-io/core/domain"
+	store "github.com/Stuhub-io/internal/repository"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/pageutils"
+	"gorm.io/gorm"
+)
+
+// Claimed exports are leased by pushing lease_until, an export whose worker died is claimed again once the lease ends.
+const claimPageExportQuery = `
+UPDATE page_exports
+SET status = 'running', attempts = attempts + 1, lease_until = NOW() + make_interval(secs => @lease)
+WHERE pkid = (
+	SELECT pkid FROM page_exports
+	WHERE status IN ('pending', 'running') AND lease_until <= NOW()
+	ORDER BY lease_until, pkid
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *
+`
+
+// Every column but the archive, which is only read to be downloaded.
+var pageExportColumns = []string{
+	"pkid", "id", "page_pkid", "requester_pkid", "format", "status", "attempts", "error",
+	"file_name", "size", "page_count", "lease_until", "created_at", "completed_at", "expires_at",
+}
+
+type PageExportRepository struct {
+	store *store.DBStore
+	cfg   config.Config
+}
+
+type NewPageExportRepositoryParams struct {
+	Cfg   config.Config
+	Store *store.DBStore
+}
+
+func NewPageExportRepository(params NewPageExportRepositoryParams) *PageExportRepository {
+	return &PageExportRepository{
+		store: params.Store,
+		cfg:   params.Cfg,
+	}
+}
+
+func (r *PageExportRepository) Create(ctx context.Context, input domain.PageExportInput) (*domain.PageExport, *domain.Error) {
+	export := model.PageExport{
+		PagePkid:      input.PagePkID,
+		RequesterPkid: input.RequesterPkID,
+		Format:        string(input.Format),
+		Status:        domain.PageExportPending.String(),
+		ExpiresAt:     input.ExpiresAt,
+	}
+	if err := r.store.DB().WithContext(ctx).Create(&export).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	result := pageutils.TransformPageExportModelToDomain(export)
+	return &result, nil
+}
+
+// GetByID returns the export, expired exports are not found even before they are deleted.
+func (r *PageExportRepository) GetByID(ctx context.Context, id string) (*domain.PageExport, *domain.Error) {
+	var export model.PageExport
+	if err := r.store.DB().WithContext(ctx).
+		Select(pageExportColumns).
+		Where("id = ? AND expires_at > NOW()", id).
+		First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPageExportNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	result := pageutils.TransformPageExportModelToDomain(export)
+	return &result, nil
+}
+
+func (r *PageExportRepository) GetArchive(ctx context.Context, id string) ([]byte, *domain.Error) {
+	var export model.PageExport
+	if err := r.store.DB().WithContext(ctx).
+		Select("archive").
+		Where("id = ? AND status = ? AND expires_at > NOW()", id, domain.PageExportDone.String()).
+		First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPageExportNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+	return export.Archive, nil
+}
+
+func (r *PageExportRepository) Claim(ctx context.Context, lease time.Duration) (*domain.PageExport, *domain.Error) {
+	var exports []model.PageExport
+	if err := r.store.DB().WithContext(ctx).Raw(claimPageExportQuery, map[string]any{
+		"lease": lease.Seconds(),
+	}).Scan(&exports).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+	if len(exports) == 0 {
+		return nil, nil
+	}
+
+	result := pageutils.TransformPageExportModelToDomain(exports[0])
+	return &result, nil
+}
+
+func (r *PageExportRepository) Complete(
+	ctx context.Context,
+	exportPkID int64,
+	archive domain.PageExportArchive,
+) *domain.Error {
+	if err := r.store.DB().WithContext(ctx).Model(&model.PageExport{}).
+		Where("pkid = ?", exportPkID).
+		Updates(map[string]any{
+			"status":       domain.PageExportDone.String(),
+			"file_name":    archive.FileName,
+			"archive":      archive.Content,
+			"size":         len(archive.Content),
+			"page_count":   archive.PageCount,
+			"error":        nil,
+			"completed_at": time.Now(),
+		}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+func (r *PageExportRepository) Fail(ctx context.Context, exportPkID int64, reason string) *domain.Error {
+	if err := r.store.DB().WithContext(ctx).Model(&model.PageExport{}).
+		Where("pkid = ?", exportPkID).
+		Updates(map[string]any{
+			"status":       domain.PageExportFailed.String(),
+			"error":        reason,
+			"completed_at": time.Now(),
+		}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+func (r *PageExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int, *domain.Error) {
+	result := r.store.DB().WithContext(ctx).
+		Where("expires_at <= ?", now).
+		Delete(&model.PageExport{})
+	if result.Error != nil {
+		return 0, domain.ErrDatabaseMutation
+	}
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/pageutils"
+	"golang.org/x/exp/slices"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Group roles are not copied to descendant pages as inherit roles, the role of a group on a page
+// applies to the descendants of the page unless the group has a role on a closer page.
+
+func (r *PageRepository) CreatePageGroupRole(
+	ctx context.Context,
+	pagePkID int64,
+	group domain.OrgGroup,
+	role domain.PageRole,
+) (*domain.PageRoleUser, *domain.Error) {
+	pageRole := model.PageGroupRole{
+		PagePkid:  pagePkID,
+		GroupPkid: group.PkID,
+		Role:      role.String(),
+	}
+	if err := r.store.DB().Clauses(clause.Returning{}).Create(&pageRole).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	roleUser := pageutils.TransformPageGroupRoleModelToDomain(pageutils.PageGroupRoleWithGroup{
+		PageGroupRole: pageRole,
+	})
+	roleUser.Group = &group
+
+	return roleUser, nil
+}
+
+// GetPageGroupRole returns the role given to the group on the page itself.
+func (r *PageRepository) GetPageGroupRole(
+	ctx context.Context,
+	pagePkID, groupPkID int64,
+) (*domain.PageRoleUser, *domain.Error) {
+	var pageRole pageutils.PageGroupRoleWithGroup
+	if err := r.store.DB().Preload("Group").
+		Where("page_pkid = ? AND group_pkid = ?", pagePkID, groupPkID).
+		First(&pageRole).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return pageutils.TransformPageGroupRoleModelToDomain(pageRole), nil
+}
+
+func (r *PageRepository) UpdatePageGroupRole(
+	ctx context.Context,
+	pagePkID, groupPkID int64,
+	role domain.PageRole,
+) *domain.Error {
+	if err := r.store.DB().Model(&model.PageGroupRole{}).
+		Where("page_pkid = ? AND group_pkid = ?", pagePkID, groupPkID).
+		Updates(map[string]any{"role": role.String(), "updated_at": time.Now()}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+func (r *PageRepository) DeletePageGroupRole(ctx context.Context, pagePkID, groupPkID int64) *domain.Error {
+	if err := r.store.DB().
+		Where("page_pkid = ? AND group_pkid = ?", pagePkID, groupPkID).
+		Delete(&model.PageGroupRole{}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+// resolveGroupRoles returns the highest role the groups of the user have on each page, pages the
+// groups have no role on are left out.
+func resolveGroupRoles(tx *gorm.DB, userPkID int64, pages []model.Page) (map[int64]domain.PageRole, error) {
+	groupRoles := make(map[int64]domain.PageRole)
+
+	var roles []model.PageGroupRole
+	userGroups := tx.Model(&model.OrgGroupMember{}).Select("group_pkid").Where("user_pkid = ?", userPkID)
+	if err := tx.Where("page_pkid IN ? AND group_pkid IN (?)", pagePathPkIDs(pages), userGroups).
+		Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return groupRoles, nil
+	}
+
+	pageRoles := make(map[int64][]model.PageGroupRole, len(roles))
+	for _, role := range roles {
+		pageRoles[role.PagePkid] = append(pageRoles[role.PagePkid], role)
+	}
+
+	for _, page := range pages {
+		for _, role := range closestGroupRoles(page, pageRoles) {
+			role := domain.PageRoleFromString(role.Role)
+			if highest := domain.HighestPageRole(sharedRolePtr(groupRoles, page.Pkid), &role); highest != nil {
+				groupRoles[page.Pkid] = *highest
+			}
+		}
+	}
+
+	return groupRoles, nil
+}
+
+// closestGroupRoles returns, for each group, its role on the page or the closest ancestor of the page.
+func closestGroupRoles(page model.Page, pageRoles map[int64][]model.PageGroupRole) map[int64]model.PageGroupRole {
+	pkIDs := append(pageutils.PagePathToPkIDs(page.Path), page.Pkid)
+	slices.Reverse(pkIDs)
+
+	closest := make(map[int64]model.PageGroupRole)
+	for _, pkID := range pkIDs {
+		for _, role := range pageRoles[pkID] {
+			if _, ok := closest[role.GroupPkid]; !ok {
+				closest[role.GroupPkid] = role
+			}
+		}
+	}
+	return closest
+}
+
+func sharedRolePtr(roles map[int64]domain.PageRole, pagePkID int64) *domain.PageRole {
+	if role, ok := roles[pagePkID]; ok {
+		return &role
+	}
+	return nil
+}
+
+// getPageGroupRoles returns the roles of the groups on the page, roles given on an ancestor are
+// returned with the page they are inherited from.
+func getPageGroupRoles(tx *gorm.DB, page model.Page) ([]domain.PageRoleUser, error) {
+	pkIDs := append(pageutils.PagePathToPkIDs(page.Path), page.Pkid)
+
+	var roles []pageutils.PageGroupRoleWithGroup
+	if err := tx.Preload("Group").Preload("Page").
+		Where("page_pkid IN ?", pkIDs).
+		Order("created_at").
+		Find(&roles).Error; err != nil {
+		return nil, err
+	}
+
+	pageRoles := make(map[int64][]model.PageGroupRole, len(roles))
+	for _, role := range roles {
+		pageRoles[role.PagePkid] = append(pageRoles[role.PagePkid], role.PageGroupRole)
+	}
+
+	closest := closestGroupRoles(page, pageRoles)
+	result := make([]domain.PageRoleUser, 0, len(closest))
+	for _, role := range roles {
+		if closestRole, ok := closest[role.GroupPkid]; !ok || closestRole.Pkid != role.Pkid {
+			continue
+		}
+		if role.PagePkid != page.Pkid {
+			role.InheritFromPage = pageutils.TransformPageModelToDomain(pageutils.PageModelToDomainParams{
+				Page: role.Page,
+			})
+		}
+		result = append(result, *pageutils.TransformPageGroupRoleModelToDomain(role))
+	}
+
+	return result, nil
+}
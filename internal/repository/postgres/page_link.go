@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/documentutils"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListBacklinks returns the links to the page, oldest first.
+func (r *PageRepository) ListBacklinks(ctx context.Context, pagePkID int64) ([]domain.PageLink, *domain.Error) {
+	var links []model.PageLink
+	if err := r.store.DB().WithContext(ctx).
+		Where("target_page_pkid = ?", pagePkID).
+		Order("created_at, source_page_pkid").
+		Find(&links).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+	return sliceutils.Map(links, pageutils.TransformPageLinkModelToDomain), nil
+}
+
+// ListOrgLinks returns the links from the pages of the organization.
+func (r *PageRepository) ListOrgLinks(ctx context.Context, orgPkID int64) ([]domain.PageLink, *domain.Error) {
+	var links []model.PageLink
+	if err := r.store.DB().WithContext(ctx).
+		Table("page_links").
+		Select("page_links.*").
+		Joins("JOIN pages ON pages.pkid = page_links.source_page_pkid").
+		Where("pages.org_pkid = ?", orgPkID).
+		Order("page_links.source_page_pkid, page_links.created_at").
+		Find(&links).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+	return sliceutils.Map(links, pageutils.TransformPageLinkModelToDomain), nil
+}
+
+// SyncLinks rebuilds the links from the documents of the pages, for documents saved before links were tracked.
+func (r *PageRepository) SyncLinks(ctx context.Context, pagePkIDs []int64) *domain.Error {
+	if err := syncPageLinks(r.store.DB().WithContext(ctx), pagePkIDs); err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+// syncPageLinks replaces the links from the pages with the references of their documents,
+// references to pages of another organization, archived or missing are dropped.
+func syncPageLinks(tx *gorm.DB, sourcePkIDs []int64) error {
+	if len(sourcePkIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("source_page_pkid IN ?", sourcePkIDs).Delete(&model.PageLink{}).Error; err != nil {
+		return err
+	}
+
+	var docs []struct {
+		PagePkid    int64
+		OrgPkid     *int64
+		JSONContent *string
+	}
+	if err := tx.Table("documents").
+		Select("documents.page_pkid, pages.org_pkid, documents.json_content").
+		Joins("JOIN pages ON pages.pkid = documents.page_pkid").
+		Where("documents.page_pkid IN ? AND pages.archived_at IS NULL", sourcePkIDs).
+		Scan(&docs).Error; err != nil {
+		return err
+	}
+
+	references := map[int64][]documentutils.PageReference{}
+	pageIDs := []string{}
+	for _, doc := range docs {
+		if doc.JSONContent == nil {
+			continue
+		}
+		parsed, err := documentutils.ParseDocument(*doc.JSONContent)
+		if err != nil {
+			continue
+		}
+		references[doc.PagePkid] = documentutils.PageReferences(parsed)
+		for _, reference := range references[doc.PagePkid] {
+			pageIDs = append(pageIDs, reference.PageID)
+		}
+	}
+	if len(pageIDs) == 0 {
+		return nil
+	}
+
+	var targets []model.Page
+	if err := tx.Select("pkid", "id", "org_pkid").
+		Where("id IN ? AND archived_at IS NULL", pageIDs).
+		Find(&targets).Error; err != nil {
+		return err
+	}
+	targetsByID := map[string]model.Page{}
+	for _, target := range targets {
+		targetsByID[strings.ToLower(target.ID)] = target
+	}
+
+	links := []model.PageLink{}
+	for _, doc := range docs {
+		for _, reference := range references[doc.PagePkid] {
+			target, ok := targetsByID[reference.PageID]
+			if !ok || target.Pkid == doc.PagePkid || target.OrgPkid == nil || doc.OrgPkid == nil || *target.OrgPkid != *doc.OrgPkid {
+				continue
+			}
+			links = append(links, model.PageLink{
+				SourcePagePkid: doc.PagePkid,
+				TargetPagePkid: target.Pkid,
+				Kind:           reference.Kind,
+			})
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+// deleteArchivedPageLinks drops the links from and to the archived page and the descendants archived along with it.
+func deleteArchivedPageLinks(tx *gorm.DB, page *model.Page) error {
+	descendantPath := pageutils.AppendPath(page.Path, strconv.FormatInt(page.Pkid, 10))
+	// Compared with the stored archived_at, which is less precise than the one in memory
+	archived := tx.Model(&model.Page{}).
+		Select("pkid").
+		Where("pkid = ? OR ((path = ? OR path LIKE ?) AND archived_at = (SELECT archived_at FROM pages WHERE pkid = ?))",
+			page.Pkid, descendantPath, descendantPath+"/%", page.Pkid)
+
+	return tx.
+		Where("source_page_pkid IN (?) OR target_page_pkid IN (?)", archived, archived).
+		Delete(&model.PageLink{}).Error
+}
+
+// relinkRestoredPages rebuilds the links from the restored pages, and the links to them
+// from the documents of their organization referencing them.
+func relinkRestoredPages(tx *gorm.DB, restored []model.Page) error {
+	if len(restored) == 0 {
+		return nil
+	}
+
+	restoredPkIDs := make([]int64, 0, len(restored))
+	restoredIDs := make([]string, 0, len(restored))
+	for _, page := range restored {
+		restoredPkIDs = append(restoredPkIDs, page.Pkid)
+		restoredIDs = append(restoredIDs, regexp.QuoteMeta(page.ID))
+	}
+
+	var referencingPkIDs []int64
+	if err := tx.Table("documents").
+		Joins("JOIN pages ON pages.pkid = documents.page_pkid").
+		Where("pages.org_pkid = ? AND pages.archived_at IS NULL AND pages.pkid NOT IN ?", restored[0].OrgPkid, restoredPkIDs).
+		Where("documents.json_content::text ~* ?", strings.Join(restoredIDs, "|")).
+		Pluck("documents.page_pkid", &referencingPkIDs).Error; err != nil {
+		return err
+	}
+
+	return syncPageLinks(tx, append(restoredPkIDs, referencingPkIDs...))
+}
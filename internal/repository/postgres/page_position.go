@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/pageutils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Positions longer than this are compacted by renumbering the sibling set.
+const maxPagePositionLength = 64
+
+// Reorder moves the page next to the given sibling, within the same parent.
+func (r *PageRepository) Reorder(
+	ctx context.Context,
+	pagePkID int64,
+	input domain.PageReorderInput,
+) (*domain.Page, *domain.Error) {
+	var page model.Page
+	if dbErr := r.store.DB().Where("pkid = ?", pagePkID).First(&page).Error; dbErr != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	if input.AfterPagePkID == nil && input.BeforePagePkID == nil {
+		return nil, domain.NewErr("Either before_page_pkid or after_page_pkid is required", domain.BadRequestCode)
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	var siblings []model.Page
+	query := tx.DB().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("org_pkid = ? AND pkid != ? AND archived_at IS NULL", page.OrgPkid, page.Pkid)
+	if page.ParentPagePkid != nil {
+		query = query.Where("parent_page_pkid = ?", *page.ParentPagePkid)
+	} else {
+		query = query.Where("parent_page_pkid IS NULL")
+	}
+	if dbErr := query.Order("position asc").Order("pkid asc").Find(&siblings).Error; dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+
+	siblingIndex := func(pkID int64) int {
+		return slices.IndexFunc(siblings, func(p model.Page) bool { return p.Pkid == pkID })
+	}
+
+	// Index of the sibling the page is placed before
+	index := -1
+	if input.AfterPagePkID != nil {
+		if after := siblingIndex(*input.AfterPagePkID); after != -1 {
+			index = after + 1
+		}
+	} else if before := siblingIndex(*input.BeforePagePkID); before != -1 {
+		index = before
+	}
+	if index == -1 {
+		doneTx(nil)
+		return nil, domain.NewErr("Sibling page not found", domain.BadRequestCode)
+	}
+
+	position, err := positionAt(siblings, index)
+	if err != nil || len(position) > maxPagePositionLength {
+		// Duplicated or too long positions, renumber the siblings leaving the page's slot free
+		if dbErr := renumberSiblingPositions(tx.DB(), siblings, index); dbErr != nil {
+			return nil, doneTx(dbErr)
+		}
+		position = pageutils.SequencePosition(index)
+	}
+
+	page.Position = position
+	if dbErr := tx.DB().
+		Clauses(clause.Returning{}).
+		Select("Position").
+		Save(&page).Error; dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	return pageutils.TransformPageModelToDomain(
+		pageutils.PageModelToDomainParams{
+			Page: &page,
+		},
+	), nil
+}
+
+// positionAt returns a position between the siblings at index-1 and index.
+func positionAt(siblings []model.Page, index int) (string, error) {
+	lower, upper := "", ""
+	if index > 0 {
+		lower = siblings[index-1].Position
+	}
+	if index == len(siblings) {
+		return pageutils.PositionAfter(lower)
+	}
+	upper = siblings[index].Position
+	return pageutils.PositionBetween(lower, upper)
+}
+
+func renumberSiblingPositions(tx *gorm.DB, siblings []model.Page, skipIndex int) error {
+	for i, sibling := range siblings {
+		slot := i
+		if i >= skipIndex {
+			slot = i + 1
+		}
+		if err := tx.Model(&model.Page{}).
+			Where("pkid = ?", sibling.Pkid).
+			Update("position", pageutils.SequencePosition(slot)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
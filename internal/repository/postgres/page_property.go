@@ -0,0 +1,476 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ListProperties returns the properties defined on the folders, by folder then position.
+func (r *PageRepository) ListProperties(
+	ctx context.Context,
+	folderPagePkIDs []int64,
+) ([]domain.PageProperty, *domain.Error) {
+	if len(folderPagePkIDs) == 0 {
+		return []domain.PageProperty{}, nil
+	}
+
+	var properties []model.PageProperty
+	if err := r.store.DB().WithContext(ctx).
+		Where("folder_page_pkid IN ?", folderPagePkIDs).
+		Order(buildOrderByValuesClause("folder_page_pkid", folderPagePkIDs)).
+		Order("position, pkid").
+		Find(&properties).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+	return sliceutils.Map(properties, pageutils.TransformPagePropertyModelToDomain), nil
+}
+
+func (r *PageRepository) GetPropertyByID(ctx context.Context, propertyID string) (*domain.PageProperty, *domain.Error) {
+	var property model.PageProperty
+	if err := r.store.DB().WithContext(ctx).Where("id = ?", propertyID).First(&property).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPagePropertyNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	result := pageutils.TransformPagePropertyModelToDomain(property)
+	return &result, nil
+}
+
+// GetPropertiesByIDs returns the properties of the organization found among the ids, in no particular order.
+func (r *PageRepository) GetPropertiesByIDs(
+	ctx context.Context,
+	orgPkID int64,
+	propertyIDs []string,
+) ([]domain.PageProperty, *domain.Error) {
+	if len(propertyIDs) == 0 {
+		return []domain.PageProperty{}, nil
+	}
+
+	var properties []model.PageProperty
+	if err := r.store.DB().WithContext(ctx).
+		Select("page_properties.*").
+		Joins("JOIN pages ON pages.pkid = page_properties.folder_page_pkid").
+		Where("page_properties.id IN ? AND pages.org_pkid = ?", propertyIDs, orgPkID).
+		Find(&properties).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+	return sliceutils.Map(properties, pageutils.TransformPagePropertyModelToDomain), nil
+}
+
+// CreateProperty adds the property after the other properties of the folder.
+func (r *PageRepository) CreateProperty(
+	ctx context.Context,
+	input domain.PagePropertyInput,
+) (*domain.PageProperty, *domain.Error) {
+	options, err := json.Marshal(input.Options)
+	if err != nil {
+		return nil, domain.ErrInvalidPageProperty
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	if taken, dbErr := propertyNameTaken(tx.DB(), input.FolderPagePkID, input.Name, 0); dbErr != nil {
+		return nil, doneTx(dbErr)
+	} else if taken {
+		doneTx(nil)
+		return nil, domain.ErrPagePropertyNameTaken
+	}
+
+	var position int32
+	if dbErr := tx.DB().Model(&model.PageProperty{}).
+		Select("COALESCE(MAX(position) + 1, 0)").
+		Where("folder_page_pkid = ?", input.FolderPagePkID).
+		Scan(&position).Error; dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+
+	property := model.PageProperty{
+		FolderPagePkid: input.FolderPagePkID,
+		Name:           input.Name,
+		Type:           input.Type.String(),
+		Options:        string(options),
+		Position:       position,
+	}
+	if dbErr := tx.DB().Clauses(clause.Returning{}).Create(&property).Error; dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	result := pageutils.TransformPagePropertyModelToDomain(property)
+	return &result, nil
+}
+
+// UpdateProperty renames the property or replaces its options, the values of removed options are cleared.
+func (r *PageRepository) UpdateProperty(
+	ctx context.Context,
+	propertyPkID int64,
+	input domain.PagePropertyUpdateInput,
+) (*domain.PageProperty, *domain.Error) {
+	var property model.PageProperty
+	if err := r.store.DB().WithContext(ctx).Where("pkid = ?", propertyPkID).First(&property).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPagePropertyNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	if input.Options != nil {
+		options, err := json.Marshal(input.Options)
+		if err != nil {
+			return nil, domain.ErrInvalidPageProperty
+		}
+		property.Options = string(options)
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	if input.Name != nil {
+		if taken, dbErr := propertyNameTaken(tx.DB(), property.FolderPagePkid, *input.Name, property.Pkid); dbErr != nil {
+			return nil, doneTx(dbErr)
+		} else if taken {
+			doneTx(nil)
+			return nil, domain.ErrPagePropertyNameTaken
+		}
+		property.Name = *input.Name
+	}
+
+	if input.Options != nil {
+		optionIDs := sliceutils.Map(input.Options, func(option domain.PagePropertyOption) string {
+			return option.ID
+		})
+		if dbErr := clearRemovedPropertyOptions(tx.DB(), property, optionIDs); dbErr != nil {
+			return nil, doneTx(dbErr)
+		}
+	}
+
+	property.UpdatedAt = time.Now()
+	if dbErr := tx.DB().Clauses(clause.Returning{}).
+		Select("Name", "Options", "UpdatedAt").
+		Save(&property).Error; dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	result := pageutils.TransformPagePropertyModelToDomain(property)
+	return &result, nil
+}
+
+// DeleteProperty deletes the property along with its values.
+func (r *PageRepository) DeleteProperty(ctx context.Context, propertyPkID int64) *domain.Error {
+	if err := r.store.DB().WithContext(ctx).
+		Where("pkid = ?", propertyPkID).
+		Delete(&model.PageProperty{}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+// ListPropertyValues returns the stored values of the pages, for any property they have a value for.
+func (r *PageRepository) ListPropertyValues(
+	ctx context.Context,
+	pagePkIDs []int64,
+) ([]domain.PagePropertyValue, *domain.Error) {
+	if len(pagePkIDs) == 0 {
+		return []domain.PagePropertyValue{}, nil
+	}
+
+	var values []model.PagePropertyValue
+	if err := r.store.DB().WithContext(ctx).
+		Where("page_pkid IN ?", pagePkIDs).
+		Find(&values).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	propertyPkIDs := sliceutils.Uniquify(sliceutils.Map(values, func(value model.PagePropertyValue) int64 {
+		return value.PropertyPkid
+	}))
+	var properties []model.PageProperty
+	if len(propertyPkIDs) > 0 {
+		if err := r.store.DB().WithContext(ctx).Where("pkid IN ?", propertyPkIDs).Find(&properties).Error; err != nil {
+			return nil, domain.ErrDatabaseQuery
+		}
+	}
+	propertiesByPkID := make(map[int64]domain.PageProperty, len(properties))
+	for _, property := range properties {
+		propertiesByPkID[property.Pkid] = pageutils.TransformPagePropertyModelToDomain(property)
+	}
+
+	results := make([]domain.PagePropertyValue, 0, len(values))
+	for _, value := range values {
+		property, ok := propertiesByPkID[value.PropertyPkid]
+		if !ok {
+			continue
+		}
+		results = append(results, transformPagePropertyValue(value, property))
+	}
+	return results, nil
+}
+
+// SetPropertyValue stores the value of the property for the page, an empty value clears it.
+func (r *PageRepository) SetPropertyValue(
+	ctx context.Context,
+	pagePkID int64,
+	property domain.PageProperty,
+	data domain.PagePropertyData,
+) (*domain.PagePropertyValue, *domain.Error) {
+	db := r.store.DB().WithContext(ctx)
+
+	if data.IsEmpty() {
+		if err := db.
+			Where("page_pkid = ? AND property_pkid = ?", pagePkID, property.PkID).
+			Delete(&model.PagePropertyValue{}).Error; err != nil {
+			return nil, domain.ErrDatabaseMutation
+		}
+		return &domain.PagePropertyValue{
+			PagePkID: pagePkID,
+			Property: property,
+			Value:    data.Value(property.Type),
+		}, nil
+	}
+
+	value := model.PagePropertyValue{
+		PagePkid:     pagePkID,
+		PropertyPkid: property.PkID,
+		NumberValue:  data.Number,
+		BoolValue:    data.Checkbox,
+		UserPkid:     data.UserPkID,
+		TextValue:    data.Text,
+		UpdatedAt:    time.Now(),
+	}
+	if data.Date != nil {
+		date, err := time.Parse(pageutils.PagePropertyDateLayout, *data.Date)
+		if err != nil {
+			return nil, domain.ErrInvalidPropertyValue
+		}
+		value.DateValue = &date
+	}
+	switch property.Type {
+	case domain.PagePropertySelect:
+		value.TextValue = &data.OptionIDs[0]
+	case domain.PagePropertyMultiSelect:
+		optionIDs, err := json.Marshal(data.OptionIDs)
+		if err != nil {
+			return nil, domain.ErrInvalidPropertyValue
+		}
+		encoded := string(optionIDs)
+		value.OptionIds = &encoded
+	}
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "page_pkid"}, {Name: "property_pkid"}},
+		UpdateAll: true,
+	}).Create(&value).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	result := transformPagePropertyValue(value, property)
+	return &result, nil
+}
+
+func transformPagePropertyValue(value model.PagePropertyValue, property domain.PageProperty) domain.PagePropertyValue {
+	return domain.PagePropertyValue{
+		PagePkID:  value.PagePkid,
+		Property:  property,
+		Value:     pageutils.TransformPagePropertyValueModelToData(value, property.Type).Value(property.Type),
+		UpdatedAt: value.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func propertyNameTaken(tx *gorm.DB, folderPagePkID int64, name string, exceptPkID int64) (bool, error) {
+	var count int64
+	err := tx.Model(&model.PageProperty{}).
+		Where("folder_page_pkid = ? AND lower(name) = lower(?) AND pkid <> ?", folderPagePkID, name, exceptPkID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// clearRemovedPropertyOptions drops the options not in optionIDs from the values of the property,
+// values left without option are deleted.
+func clearRemovedPropertyOptions(tx *gorm.DB, property model.PageProperty, optionIDs []string) error {
+	switch domain.PagePropertyType(property.Type) {
+	case domain.PagePropertySelect:
+		query := tx.Where("property_pkid = ?", property.Pkid)
+		if len(optionIDs) > 0 {
+			query = query.Where("text_value NOT IN ?", optionIDs)
+		}
+		return query.Delete(&model.PagePropertyValue{}).Error
+
+	case domain.PagePropertyMultiSelect:
+		kept := "'[]'::jsonb"
+		args := []any{}
+		if len(optionIDs) > 0 {
+			kept = "(SELECT COALESCE(jsonb_agg(option_id), '[]'::jsonb) FROM jsonb_array_elements_text(option_ids) AS option_id WHERE option_id IN ?)"
+			args = append(args, optionIDs)
+		}
+		if err := tx.Model(&model.PagePropertyValue{}).
+			Where("property_pkid = ?", property.Pkid).
+			Update("option_ids", gorm.Expr(kept, args...)).Error; err != nil {
+			return err
+		}
+		return tx.
+			Where("property_pkid = ? AND (option_ids IS NULL OR option_ids = '[]'::jsonb)", property.Pkid).
+			Delete(&model.PagePropertyValue{}).Error
+	}
+	return nil
+}
+
+// propertyAppliesClause matches the pages below the folder of the property. Pages moved out
+// of the folder keep their values, they no longer match until moved back.
+func propertyAppliesClause(property domain.PageProperty) string {
+	return "('/' || pages.path || '/') LIKE '%/" + strconv.FormatInt(property.FolderPagePkID, 10) + "/%'"
+}
+
+// propertyValueExistsClause matches the pages having a value of the property satisfying the condition.
+func propertyValueExistsClause(property domain.PageProperty, condition string) string {
+	exists := "EXISTS (SELECT 1 FROM page_property_values ppv WHERE ppv.page_pkid = pages.pkid AND ppv.property_pkid = " +
+		strconv.FormatInt(property.PkID, 10)
+	if condition != "" {
+		exists += " AND " + condition
+	}
+	return exists + ")"
+}
+
+// filterPagePropertyCondition keeps the pages the property applies to matching the condition,
+// negative operators also match the pages without a value.
+func filterPagePropertyCondition(query *gorm.DB, c domain.PagePropertyCondition) *gorm.DB {
+	operand := c.Operand
+	column, value := propertyValueColumn(c.Property.Type, operand)
+
+	negate := false
+	condition := ""
+	args := []any{}
+	switch c.Operator {
+	case domain.PagePropertyIsEmpty:
+		negate = true
+	case domain.PagePropertyIsNotEmpty:
+	case domain.PagePropertyEq, domain.PagePropertyNeq:
+		negate = c.Operator == domain.PagePropertyNeq
+		switch c.Property.Type {
+		case domain.PagePropertyCheckbox:
+			// Unchecked boxes have no value
+			condition = "ppv.bool_value"
+			if operand.Checkbox == nil || !*operand.Checkbox {
+				negate = !negate
+			}
+		case domain.PagePropertyText:
+			condition = "lower(ppv.text_value) = lower(?)"
+			args = append(args, value)
+		default:
+			condition = column + " = ?"
+			args = append(args, value)
+		}
+	case domain.PagePropertyContains, domain.PagePropertyNotContains:
+		negate = c.Operator == domain.PagePropertyNotContains
+		if c.Property.Type == domain.PagePropertyMultiSelect {
+			optionIDs, _ := json.Marshal(operand.OptionIDs)
+			condition = "ppv.option_ids @> ?::jsonb"
+			args = append(args, string(optionIDs))
+		} else {
+			condition = "ppv.text_value ILIKE ?"
+			args = append(args, "%"+likeEscaper.Replace(*operand.Text)+"%")
+		}
+	case domain.PagePropertyGt:
+		condition = column + " > ?"
+		args = append(args, value)
+	case domain.PagePropertyGte:
+		condition = column + " >= ?"
+		args = append(args, value)
+	case domain.PagePropertyLt:
+		condition = column + " < ?"
+		args = append(args, value)
+	case domain.PagePropertyLte:
+		condition = column + " <= ?"
+		args = append(args, value)
+	}
+
+	exists := propertyValueExistsClause(c.Property, condition)
+	if negate {
+		exists = "NOT " + exists
+	}
+	return query.Where(propertyAppliesClause(c.Property)+" AND "+exists, args...)
+}
+
+// propertyValueColumn returns the column storing values of the type and the operand compared to it.
+func propertyValueColumn(propertyType domain.PagePropertyType, operand domain.PagePropertyData) (string, any) {
+	switch propertyType {
+	case domain.PagePropertyNumber:
+		if operand.Number != nil {
+			return "ppv.number_value", *operand.Number
+		}
+		return "ppv.number_value", nil
+	case domain.PagePropertyDate:
+		if operand.Date != nil {
+			return "ppv.date_value", *operand.Date
+		}
+		return "ppv.date_value", nil
+	case domain.PagePropertyUser:
+		if operand.UserPkID != nil {
+			return "ppv.user_pkid", *operand.UserPkID
+		}
+		return "ppv.user_pkid", nil
+	case domain.PagePropertySelect:
+		if len(operand.OptionIDs) > 0 {
+			return "ppv.text_value", operand.OptionIDs[0]
+		}
+		return "ppv.text_value", nil
+	}
+	if operand.Text != nil {
+		return "ppv.text_value", *operand.Text
+	}
+	return "ppv.text_value", nil
+}
+
+// propertySortExpression returns the value the pages are sorted on, null for pages without value.
+// Selects sort in the order of their options, users by name.
+func propertySortExpression(property domain.PageProperty) string {
+	value := "ppv.text_value"
+	from := "page_property_values ppv"
+	switch property.Type {
+	case domain.PagePropertyText:
+		value = "lower(ppv.text_value)"
+	case domain.PagePropertyNumber:
+		value = "ppv.number_value"
+	case domain.PagePropertyDate:
+		value = "ppv.date_value"
+	case domain.PagePropertyCheckbox:
+		value = "ppv.bool_value"
+	case domain.PagePropertyUser:
+		value = "lower(COALESCE(NULLIF(TRIM(CONCAT(u.first_name, ' ', u.last_name)), ''), u.email))"
+		from += " JOIN users u ON u.pkid = ppv.user_pkid"
+	case domain.PagePropertySelect:
+		value = "o.ordinality"
+		from += " JOIN page_properties pp ON pp.pkid = ppv.property_pkid" +
+			" CROSS JOIN LATERAL jsonb_array_elements(pp.options) WITH ORDINALITY AS o(option, ordinality)"
+	}
+
+	expression := "(SELECT " + value + " FROM " + from +
+		" WHERE ppv.page_pkid = pages.pkid AND ppv.property_pkid = " + strconv.FormatInt(property.PkID, 10)
+	if property.Type == domain.PagePropertySelect {
+		expression += " AND o.option->>'id' = ppv.text_value"
+	}
+	expression += " LIMIT 1)"
+
+	// Pages the property does not apply to sort with the ones without value
+	return "CASE WHEN " + propertyAppliesClause(property) + " THEN " + expression + " END"
+}
@@ -84,6 +84,14 @@ func (r *PageRepository) CreatePageRole(
 		}
 	}
 
+	if err := stageOutboxEvents(ctx, tx.DB(), *pageutils.TransformPageModelToDomain(
+		pageutils.PageModelToDomainParams{
+			Page: page,
+		},
+	)); err != nil {
+		return nil, done(err)
+	}
+
 	return pageutils.TransformPageRoleModelToDomain(
 		pageutils.PageRoleWithUser{
 			PageRole: pageRole,
@@ -136,6 +144,24 @@ func (r *PageRepository) GetPageRoleByEmail(
 	), nil
 }
 
+// GetUserPageRoles returns the direct role of the user on the page and the highest role shared with them
+// through groups and share links, inherit roles resolved. Roles the user does not have are nil.
+func (r *PageRepository) GetUserPageRoles(
+	ctx context.Context,
+	pagePkID int64,
+	user domain.User,
+) (directRole *domain.PageRole, sharedRole *domain.PageRole) {
+	if role, err := r.GetPageRoleByEmail(ctx, pagePkID, user.Email); err == nil {
+		directRole = &role.Role
+	}
+
+	if sharedRoles, err := r.GetSharedRolesByUser(ctx, user.PkID, []int64{pagePkID}); err == nil {
+		sharedRole = sharedRolePtr(sharedRoles, pagePkID)
+	}
+
+	return directRole, sharedRole
+}
+
 func (r *PageRepository) GetPageRoles(
 	ctx context.Context,
 	pagePkID int64,
@@ -211,7 +237,12 @@ func (r *PageRepository) GetPageRoles(
 		return *pageutils.TransformPageRoleModelToDomain(transformRole)
 	})
 
-	return resultRoles, nil
+	groupRoles, dbErr := getPageGroupRoles(r.store.DB(), *page)
+	if dbErr != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return append(resultRoles, groupRoles...), nil
 }
 
 func (r *PageRepository) UpdatePageRole(
@@ -291,6 +322,13 @@ func (r *PageRepository) GetPagesRole(
 		return nil, err
 	}
 
+	sharedRoles, dbErr := resolveSharedRoles(r.store.DB(), user.PkID, sliceutils.Map(pages, func(page domain.Page) int64 {
+		return page.PkID
+	}))
+	if dbErr != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
 	resultRoles := sliceutils.Map(
 		pageRoles,
 		func(role PageRoleResult) domain.PageRolePermissionCheckInput {
@@ -302,11 +340,27 @@ func (r *PageRepository) GetPagesRole(
 						Page: role.Page,
 					},
 				),
-				PageRole: &pageRole,
+				PageRole:   &pageRole,
+				SharedRole: sharedRolePtr(sharedRoles, role.PagePkid),
 			}
 		},
 	)
 
+	// Pages the user only has a role on through groups or share links
+	for _, page := range pages {
+		sharedRole := sharedRolePtr(sharedRoles, page.PkID)
+		if sharedRole == nil || sliceutils.Find(pageRoles, func(role PageRoleResult) bool {
+			return role.PagePkid == page.PkID
+		}) != nil {
+			continue
+		}
+		resultRoles = append(resultRoles, domain.PageRolePermissionCheckInput{
+			User:       user,
+			Page:       page,
+			SharedRole: sharedRole,
+		})
+	}
+
 	return resultRoles, nil
 }
 
@@ -329,7 +383,8 @@ func (r *PageRepository) CheckPermission(
 ) (permissions domain.PageRolePermissions) {
 	page := input.Page
 	user := input.User
-	pageRoleUser := input.PageRole
+	// The highest of the direct role and the roles shared with the user applies
+	pageRoleUser := domain.HighestPageRole(input.PageRole, input.SharedRole)
 
 	// General role
 	if user == nil {
@@ -443,11 +498,19 @@ func (r *PageRepository) ListPageAccessRequestByPagePkID(ctx context.Context, q
 }
 
 func (r *PageRepository) UpdatePageAccessRequestStatus(ctx context.Context, q domain.PageRoleRequestLogQuery, status domain.PageRoleRequestLogStatus) *domain.Error {
-	query := buildPageAccessRequestQuery(r.store.DB().Model(&PageRoleRequestLogResults{}), q)
+	tx, doneTx := r.store.NewTransaction()
+
+	query := buildPageAccessRequestQuery(tx.DB().Model(&PageRoleRequestLogResults{}), q)
 	if err := query.Update("status", status.String()).Error; err != nil {
+		doneTx(err)
 		return domain.ErrDatabaseMutation
 	}
-	return nil
+
+	if err := stageOutboxEvents(ctx, tx.DB()); err != nil {
+		return doneTx(err)
+	}
+
+	return doneTx(nil)
 }
 
 func buildPageAccessRequestQuery(tx *gorm.DB, q domain.PageRoleRequestLogQuery) *gorm.DB {
@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"github.com/Stuhub-io/utils/userutils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *PageRepository) CreateShareLink(
+	ctx context.Context,
+	input domain.PageShareLinkInput,
+) (*domain.PageShareLink, *domain.Error) {
+	link := model.PageShareLink{
+		PagePkid:       input.PagePkID,
+		CreatedByPkid:  &input.CreatedByPkID,
+		Role:           input.Role.String(),
+		ExpiresAt:      input.ExpiresAt,
+		MaxUses:        input.MaxUses,
+		OrgMembersOnly: input.OrgMembersOnly,
+	}
+	if input.PasswordHash != "" {
+		link.PasswordHash = &input.PasswordHash
+		link.PasswordSalt = &input.PasswordSalt
+	}
+
+	if err := r.store.DB().Clauses(clause.Returning{}).Create(&link).Error; err != nil {
+		return nil, domain.ErrDatabaseMutation
+	}
+
+	return pageutils.TransformPageShareLinkModelToDomain(link, time.Now()), nil
+}
+
+// ListShareLinks returns the links of the page, revoked ones included, newest first.
+func (r *PageRepository) ListShareLinks(ctx context.Context, pagePkID int64) ([]domain.PageShareLink, *domain.Error) {
+	var links []model.PageShareLink
+	if err := r.store.DB().Where("page_pkid = ?", pagePkID).Order("created_at DESC").Find(&links).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	now := time.Now()
+	return sliceutils.Map(links, func(link model.PageShareLink) domain.PageShareLink {
+		return *pageutils.TransformPageShareLinkModelToDomain(link, now)
+	}), nil
+}
+
+func (r *PageRepository) GetShareLinkByID(ctx context.Context, linkID string) (*domain.PageShareLink, *domain.Error) {
+	var link model.PageShareLink
+	if err := r.store.DB().Where("id = ?", linkID).First(&link).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrShareLinkNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return pageutils.TransformPageShareLinkModelToDomain(link, time.Now()), nil
+}
+
+// RevokeShareLink deactivates the link, users who opened it lose the role of the link.
+func (r *PageRepository) RevokeShareLink(ctx context.Context, linkPkID int64) *domain.Error {
+	now := time.Now()
+	if err := r.store.DB().Model(&model.PageShareLink{}).
+		Where("pkid = ? AND revoked_at IS NULL", linkPkID).
+		Updates(map[string]any{"revoked_at": now, "updated_at": now}).Error; err != nil {
+		return domain.ErrDatabaseMutation
+	}
+	return nil
+}
+
+// UseShareLink records an opening of the active link. Every anonymous opening and the first opening of
+// each signed-in user counts as a use, the link cannot be used past its maximum uses.
+func (r *PageRepository) UseShareLink(ctx context.Context, linkPkID int64, userPkID *int64) *domain.Error {
+	now := time.Now()
+	tx, doneTx := r.store.NewTransaction()
+
+	var link model.PageShareLink
+	if err := tx.DB().
+		Where("pkid = ? AND revoked_at IS NULL", linkPkID).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		First(&link).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			doneTx(nil)
+			return domain.ErrShareLinkInactive
+		}
+		return doneTx(err)
+	}
+
+	if userPkID != nil {
+		result := tx.DB().Model(&model.PageShareLinkAccess{}).
+			Where("link_pkid = ? AND user_pkid = ?", linkPkID, *userPkID).
+			Updates(map[string]any{"access_count": gorm.Expr("access_count + 1"), "last_accessed_at": now})
+		if result.Error != nil {
+			return doneTx(result.Error)
+		}
+		if result.RowsAffected > 0 {
+			if err := tx.DB().Model(&model.PageShareLink{}).
+				Where("pkid = ?", linkPkID).
+				Update("last_used_at", now).Error; err != nil {
+				return doneTx(err)
+			}
+			return doneTx(nil)
+		}
+	}
+
+	result := tx.DB().Model(&model.PageShareLink{}).
+		Where("pkid = ? AND (max_uses IS NULL OR use_count < max_uses)", linkPkID).
+		Updates(map[string]any{"use_count": gorm.Expr("use_count + 1"), "last_used_at": now})
+	if result.Error != nil {
+		return doneTx(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		doneTx(nil)
+		return domain.ErrShareLinkInactive
+	}
+
+	if userPkID != nil {
+		access := model.PageShareLinkAccess{
+			LinkPkid:        linkPkID,
+			UserPkid:        *userPkID,
+			AccessCount:     1,
+			FirstAccessedAt: now,
+			LastAccessedAt:  now,
+		}
+		if err := tx.DB().Create(&access).Error; err != nil {
+			return doneTx(err)
+		}
+	}
+
+	return doneTx(nil)
+}
+
+type PageShareLinkAccessResult struct {
+	model.PageShareLinkAccess
+	User *model.User `gorm:"foreignKey:user_pkid"`
+}
+
+// ListShareLinkAccesses returns the signed-in users who opened the link, latest first.
+func (r *PageRepository) ListShareLinkAccesses(
+	ctx context.Context,
+	linkPkID int64,
+) ([]domain.PageShareLinkAccess, *domain.Error) {
+	var accesses []PageShareLinkAccessResult
+	if err := r.store.DB().Preload("User").
+		Where("link_pkid = ?", linkPkID).
+		Order("last_accessed_at DESC").
+		Find(&accesses).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	return sliceutils.Map(accesses, func(access PageShareLinkAccessResult) domain.PageShareLinkAccess {
+		return domain.PageShareLinkAccess{
+			User:            userutils.TransformUserModelToDomain(access.User),
+			AccessCount:     access.AccessCount,
+			FirstAccessedAt: access.FirstAccessedAt.String(),
+			LastAccessedAt:  access.LastAccessedAt.String(),
+		}
+	}), nil
+}
+
+// ListOpenedShareLinks returns the active links on the page or its ancestors that the user opened.
+func (r *PageRepository) ListOpenedShareLinks(
+	ctx context.Context,
+	userPkID int64,
+	pagePkID int64,
+) ([]domain.PageShareLink, *domain.Error) {
+	var page model.Page
+	if err := r.store.DB().Select("pkid", "path").Where("pkid = ?", pagePkID).First(&page).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	var links []model.PageShareLink
+	openedLinks := r.store.DB().Model(&model.PageShareLinkAccess{}).Select("link_pkid").Where("user_pkid = ?", userPkID)
+	if err := r.store.DB().
+		Where("page_pkid IN ? AND pkid IN (?) AND revoked_at IS NULL", pagePathPkIDs([]model.Page{page}), openedLinks).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Order("created_at").
+		Find(&links).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	now := time.Now()
+	return sliceutils.Map(links, func(link model.PageShareLink) domain.PageShareLink {
+		return *pageutils.TransformPageShareLinkModelToDomain(link, now)
+	}), nil
+}
+
+func (r *PageRepository) GetSharedRolesByUser(
+	ctx context.Context,
+	userPkID int64,
+	pagePkIDs []int64,
+) (map[int64]domain.PageRole, *domain.Error) {
+	sharedRoles, err := resolveSharedRoles(r.store.DB(), userPkID, pagePkIDs)
+	if err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+	return sharedRoles, nil
+}
+
+// resolveSharedRoles returns the highest role given to the user on each page through groups and the
+// share links the user opened, pages without such role are left out.
+func resolveSharedRoles(tx *gorm.DB, userPkID int64, pagePkIDs []int64) (map[int64]domain.PageRole, error) {
+	if len(pagePkIDs) == 0 {
+		return map[int64]domain.PageRole{}, nil
+	}
+
+	var pages []model.Page
+	if err := tx.Model(&model.Page{}).Select("pkid", "path").Where("pkid IN ?", pagePkIDs).Find(&pages).Error; err != nil {
+		return nil, err
+	}
+
+	sharedRoles, err := resolveGroupRoles(tx, userPkID, pages)
+	if err != nil {
+		return nil, err
+	}
+
+	linkRoles, err := resolveLinkRoles(tx, userPkID, pages)
+	if err != nil {
+		return nil, err
+	}
+
+	for pagePkID, role := range linkRoles {
+		role := role
+		sharedRoles[pagePkID] = *domain.HighestPageRole(sharedRolePtr(sharedRoles, pagePkID), &role)
+	}
+
+	return sharedRoles, nil
+}
+
+// resolveLinkRoles returns the highest role of the active links the user opened on each page or
+// its ancestors. Users keep the role of a link past its maximum uses.
+func resolveLinkRoles(tx *gorm.DB, userPkID int64, pages []model.Page) (map[int64]domain.PageRole, error) {
+	linkRoles := make(map[int64]domain.PageRole)
+
+	var links []model.PageShareLink
+	openedLinks := tx.Model(&model.PageShareLinkAccess{}).Select("link_pkid").Where("user_pkid = ?", userPkID)
+	if err := tx.Where("page_pkid IN ? AND pkid IN (?) AND revoked_at IS NULL", pagePathPkIDs(pages), openedLinks).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return linkRoles, nil
+	}
+
+	pageLinkRoles := make(map[int64][]domain.PageRole, len(links))
+	for _, link := range links {
+		pageLinkRoles[link.PagePkid] = append(pageLinkRoles[link.PagePkid], domain.PageRoleFromString(link.Role))
+	}
+
+	for _, page := range pages {
+		for _, pkID := range append(pageutils.PagePathToPkIDs(page.Path), page.Pkid) {
+			for _, role := range pageLinkRoles[pkID] {
+				role := role
+				if highest := domain.HighestPageRole(sharedRolePtr(linkRoles, page.Pkid), &role); highest != nil {
+					linkRoles[page.Pkid] = *highest
+				}
+			}
+		}
+	}
+
+	return linkRoles, nil
+}
+
+// pagePathPkIDs returns the pkids of the pages and their ancestors.
+func pagePathPkIDs(pages []model.Page) []int64 {
+	pkIDs := make([]int64, 0, len(pages))
+	for _, page := range pages {
+		pkIDs = append(pkIDs, page.Pkid)
+		pkIDs = append(pkIDs, pageutils.PagePathToPkIDs(page.Path)...)
+	}
+	return sliceutils.Uniquify(pkIDs)
+}
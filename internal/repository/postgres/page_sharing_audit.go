@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"golang.org/x/exp/slices"
+)
+
+// ListOrgSharingAudit returns the pages of the organization whose general role is not restricted, that
+// have roles given to emails outside the organization members or that active share links not limited to
+// members open. Inherit roles and links on ancestors are resolved through the path of the page, like for
+// permission checks.
+func (r *PageRepository) ListOrgSharingAudit(
+	ctx context.Context,
+	orgPkID int64,
+) ([]domain.PageSharingAuditEntry, *domain.Error) {
+	var pages []model.Page
+	if err := r.store.DB().
+		Where("org_pkid = ? AND archived_at IS NULL", orgPkID).
+		Order("pkid").
+		Find(&pages).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	orgPages := r.store.DB().Model(&model.Page{}).Select("pkid").Where("org_pkid = ? AND archived_at IS NULL", orgPkID)
+	memberEmails := r.store.DB().Table(model.TableNameOrganizationMember).
+		Select("lower(users.email)").
+		Joins("JOIN users ON users.pkid = organization_member.user_pkid").
+		Where("organization_member.organization_pkid = ?", orgPkID)
+
+	var roles []pageutils.PageRoleWithUser
+	if err := r.store.DB().Preload("User").
+		Where("page_pkid IN (?) AND lower(email) NOT IN (?)", orgPages, memberEmails).
+		Order("created_at").
+		Find(&roles).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	// Links used up by max_uses still open the page for the users who opened them already
+	now := time.Now()
+	var links []model.PageShareLink
+	if err := r.store.DB().
+		Where("page_pkid IN (?) AND org_members_only = false AND revoked_at IS NULL", orgPages).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("created_at").
+		Find(&links).Error; err != nil {
+		return nil, domain.ErrDatabaseQuery
+	}
+
+	pageLinks := make(map[int64][]model.PageShareLink)
+	for _, link := range links {
+		pageLinks[link.PagePkid] = append(pageLinks[link.PagePkid], link)
+	}
+
+	pageMap := make(map[int64]*model.Page, len(pages))
+	for i := range pages {
+		pageMap[pages[i].Pkid] = &pages[i]
+	}
+
+	emailRoles := make(map[int64]map[string]pageutils.PageRoleWithUser)
+	for _, role := range roles {
+		if emailRoles[role.PagePkid] == nil {
+			emailRoles[role.PagePkid] = make(map[string]pageutils.PageRoleWithUser)
+		}
+		emailRoles[role.PagePkid][strings.ToLower(role.Email)] = role
+	}
+
+	pageRoles := make(map[int64][]domain.PageRoleUser, len(emailRoles))
+	for _, role := range roles {
+		if role.Role == domain.PageInherit.String() {
+			role.Role = domain.PageViewer.String() // Default Role If Not Found inherit
+			for _, pkID := range reversedPagePath(pageMap[role.PagePkid]) {
+				baseRole, ok := emailRoles[pkID][strings.ToLower(role.Email)]
+				if !ok || baseRole.Role == domain.PageInherit.String() {
+					continue
+				}
+				role.Role = baseRole.Role
+				role.InheritFromPage = transformAuditPage(pageMap[pkID])
+				break
+			}
+		}
+		pageRoles[role.PagePkid] = append(pageRoles[role.PagePkid], *pageutils.TransformPageRoleModelToDomain(role))
+	}
+
+	entries := make([]domain.PageSharingAuditEntry, 0)
+	for i := range pages {
+		page := &pages[i]
+		entry := domain.PageSharingAuditEntry{
+			Page:         transformAuditPage(page),
+			GeneralRole:  domain.PageRoleFromString(page.GeneralRole),
+			OutsideRoles: pageRoles[page.Pkid],
+			ShareLinks:   auditShareLinks(pageLinks[page.Pkid], nil, now),
+		}
+		for _, pkID := range reversedPagePath(page) {
+			if parent, ok := pageMap[pkID]; ok {
+				entry.ShareLinks = append(entry.ShareLinks, auditShareLinks(pageLinks[pkID], parent, now)...)
+			}
+		}
+
+		if entry.GeneralRole == domain.PageInherit {
+			entry.GeneralRole = domain.PageRestrict
+			for _, pkID := range reversedPagePath(page) {
+				parent, ok := pageMap[pkID]
+				if !ok || parent.GeneralRole == domain.PageInherit.String() {
+					continue
+				}
+				entry.GeneralRole = domain.PageRoleFromString(parent.GeneralRole)
+				entry.GeneralRoleFromPage = transformAuditPage(parent)
+				break
+			}
+		}
+
+		if entry.IsPublic() || len(entry.OutsideRoles) > 0 || len(entry.ShareLinks) > 0 {
+			if entry.OutsideRoles == nil {
+				entry.OutsideRoles = []domain.PageRoleUser{}
+			}
+			if entry.ShareLinks == nil {
+				entry.ShareLinks = []domain.PageSharingAuditLink{}
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// reversedPagePath returns the pkids of the ancestors of the page, closest first.
+func reversedPagePath(page *model.Page) []int64 {
+	if page == nil {
+		return nil
+	}
+	pkIDs := pageutils.PagePathToPkIDs(page.Path)
+	slices.Reverse(pkIDs)
+	return pkIDs
+}
+
+// auditShareLinks returns the links of fromPage, nil for links on the audited page itself.
+func auditShareLinks(links []model.PageShareLink, fromPage *model.Page, now time.Time) []domain.PageSharingAuditLink {
+	return sliceutils.Map(links, func(link model.PageShareLink) domain.PageSharingAuditLink {
+		auditLink := domain.PageSharingAuditLink{
+			Link: pageutils.TransformPageShareLinkModelToDomain(link, now),
+		}
+		if fromPage != nil {
+			auditLink.FromPage = transformAuditPage(fromPage)
+		}
+		return auditLink
+	})
+}
+
+func transformAuditPage(page *model.Page) *domain.Page {
+	return pageutils.TransformPageModelToDomain(pageutils.PageModelToDomainParams{
+		Page: page,
+	})
+}
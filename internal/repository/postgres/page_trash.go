@@ -0,0 +1,254 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Restore brings back an archived page with the descendants archived along with it,
+// to its original parent when still available, otherwise to the root.
+func (r *PageRepository) Restore(
+	ctx context.Context,
+	pagePkID int64,
+) (*domain.Page, *domain.Error) {
+	var page model.Page
+	if dbErr := r.store.DB().Where("pkid = ?", pagePkID).First(&page).Error; dbErr != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	if page.ArchivedAt == nil {
+		return nil, domain.ErrPageNotArchived
+	}
+	if page.ParentPagePkid != nil {
+		// Archived along with an ancestor, restore the ancestor instead.
+		return nil, domain.ErrPageNotTrashRoot
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	if dbErr := restorePage(tx.DB(), &page); dbErr != nil {
+		return nil, doneTx(dbErr)
+	}
+
+	restored := pageutils.TransformPageModelToDomain(
+		pageutils.PageModelToDomainParams{
+			Page: &page,
+		},
+	)
+	if err := stageOutboxEvents(ctx, tx.DB(), *restored); err != nil {
+		return nil, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// restorePage restores the trash root page with the descendants archived along with it.
+func restorePage(tx *gorm.DB, page *model.Page) error {
+	oldDescendantPath := pageutils.AppendPath(page.Path, strconv.FormatInt(page.Pkid, 10))
+
+	var restored []model.Page
+	if dbErr := tx.
+		Select("pkid", "id", "org_pkid").
+		Where("(path = ? OR path LIKE ?) AND archived_at = ?", oldDescendantPath, oldDescendantPath+"/%", page.ArchivedAt).
+		Find(&restored).Error; dbErr != nil {
+		return dbErr
+	}
+	restored = append(restored, *page)
+
+	// Restore the descendants archived at the same time, the ones archived before stay in trash.
+	if dbErr := tx.
+		Model(&model.Page{}).
+		Where("(path = ? OR path LIKE ?) AND archived_at = ?", oldDescendantPath, oldDescendantPath+"/%", page.ArchivedAt).
+		Update("archived_at", nil).Error; dbErr != nil {
+		return dbErr
+	}
+
+	var parentPagePkID *int64
+	if parentPkIDs := pageutils.PagePathToPkIDs(page.Path); len(parentPkIDs) > 0 {
+		originalParentPkID := parentPkIDs[len(parentPkIDs)-1]
+
+		var parent model.Page
+		dbErr := tx.
+			Where("pkid = ? AND archived_at IS NULL", originalParentPkID).
+			First(&parent).Error
+		if dbErr == nil {
+			parentPagePkID = &parent.Pkid
+		} else if dbErr != gorm.ErrRecordNotFound {
+			return dbErr
+		}
+	}
+
+	if parentPagePkID == nil && page.Path != "" {
+		// Parent is gone, move the subtree to the root.
+		newDescendantPath := strconv.FormatInt(page.Pkid, 10)
+		if dbErr := rewriteDescendantPaths(tx, oldDescendantPath, newDescendantPath); dbErr != nil {
+			return dbErr
+		}
+		position, dbErr := nextSiblingPosition(tx, page.OrgPkid, nil)
+		if dbErr != nil {
+			return dbErr
+		}
+		page.Path = ""
+		page.Position = position
+	}
+
+	page.ArchivedAt = nil
+	page.ParentPagePkid = parentPagePkID
+
+	if dbErr := tx.
+		Clauses(clause.Returning{}).
+		Select("ArchivedAt", "ParentPagePkid", "Path", "Position").
+		Save(page).Error; dbErr != nil {
+		return dbErr
+	}
+
+	// Links were dropped when the pages were archived
+	return relinkRestoredPages(tx, restored)
+}
+
+// Purge permanently deletes archived pages, with the descendants archived along with them.
+func (r *PageRepository) Purge(
+	ctx context.Context,
+	pagePkIDs []int64,
+) *domain.Error {
+	if len(pagePkIDs) == 0 {
+		return nil
+	}
+
+	var pages []model.Page
+	if dbErr := r.store.DB().
+		Where("pkid IN ? AND archived_at IS NOT NULL", pagePkIDs).
+		Find(&pages).Error; dbErr != nil {
+		return domain.ErrDatabaseQuery
+	}
+
+	if len(pages) != len(pagePkIDs) {
+		return domain.ErrPageNotArchived
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	purged, dbErr := purgePages(tx.DB(), pages)
+	if dbErr != nil {
+		return doneTx(dbErr)
+	}
+
+	if err := stageOutboxEvents(ctx, tx.DB(), transformPurgedPages(purged)...); err != nil {
+		return doneTx(err)
+	}
+
+	return doneTx(nil)
+}
+
+// PurgeArchivedBefore permanently deletes trash entries archived before the given time.
+func (r *PageRepository) PurgeArchivedBefore(
+	ctx context.Context,
+	before time.Time,
+) (int, *domain.Error) {
+	var pages []model.Page
+	if dbErr := r.store.DB().
+		Where("archived_at IS NOT NULL AND archived_at < ? AND parent_page_pkid IS NULL", before).
+		Find(&pages).Error; dbErr != nil {
+		return 0, domain.ErrDatabaseQuery
+	}
+
+	if len(pages) == 0 {
+		return 0, nil
+	}
+
+	tx, doneTx := r.store.NewTransaction()
+
+	purged, dbErr := purgePages(tx.DB(), pages)
+	if dbErr != nil {
+		return 0, doneTx(dbErr)
+	}
+
+	if err := stageOutboxEvents(ctx, tx.DB(), transformPurgedPages(purged)...); err != nil {
+		return 0, doneTx(err)
+	}
+
+	if err := doneTx(nil); err != nil {
+		return 0, err
+	}
+
+	return len(pages), nil
+}
+
+// purgePages deletes the trash roots with their descendants archived along with them, returns the deleted pages.
+func purgePages(tx *gorm.DB, roots []model.Page) ([]model.Page, error) {
+	purged := []model.Page{}
+	for _, root := range roots {
+		descendantPath := pageutils.AppendPath(root.Path, strconv.FormatInt(root.Pkid, 10))
+
+		var subtree []model.Page
+		if err := tx.
+			Where(
+				"pkid = ? OR ((path = ? OR path LIKE ?) AND archived_at = ?)",
+				root.Pkid, descendantPath, descendantPath+"/%", root.ArchivedAt,
+			).
+			Find(&subtree).Error; err != nil {
+			return nil, err
+		}
+		purged = append(purged, subtree...)
+	}
+	pkIDs := sliceutils.Map(purged, func(page model.Page) int64 { return page.Pkid })
+
+	pageBodies := []interface{}{
+		&model.DocumentRevision{},
+		&model.Document{},
+		&model.Asset{},
+		&model.PageRole{},
+		&model.PageStar{},
+		&model.PageAccessLog{},
+		&model.PagePermissionRequestLog{},
+		&model.PageShareLink{},
+	}
+	for _, body := range pageBodies {
+		if err := tx.Where("page_pkid IN ?", pkIDs).Delete(body).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	// Detach pages outside the purged set still referencing a purged parent
+	if err := tx.Model(&model.Page{}).
+		Where("parent_page_pkid IN ? AND pkid NOT IN ?", pkIDs, pkIDs).
+		Update("parent_page_pkid", nil).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("pkid IN ?", pkIDs).Delete(&model.Page{}).Error; err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+func transformPurgedPages(purged []model.Page) []domain.Page {
+	return sliceutils.Map(purged, func(page model.Page) domain.Page {
+		return *pageutils.TransformPageModelToDomain(
+			pageutils.PageModelToDomainParams{
+				Page: &page,
+			},
+		)
+	})
+}
+
+// rewriteDescendantPaths replaces the oldPath prefix of the subtree paths with newPath.
+func rewriteDescendantPaths(tx *gorm.DB, oldPath string, newPath string) error {
+	return tx.Model(&model.Page{}).
+		Where("path = ? OR path LIKE ?", oldPath, oldPath+"/%").
+		Update("path", gorm.Expr("?::text || substr(path, ?)", newPath, len(oldPath)+1)).
+		Error
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/Stuhub-io/core/domain"
 	"github.com/Stuhub-io/internal/repository/model"
 	"github.com/Stuhub-io/utils/pageutils"
+	sliceutils "github.com/Stuhub-io/utils/slice"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PageResult struct {
@@ -48,6 +50,11 @@ func (r *PageRepository) initPageModel(
 		GeneralRole = domain.PageRestrict.String()
 	}
 
+	position, err := nextSiblingPosition(tx, &pageInput.OrganizationPkID, pageInput.ParentPagePkID)
+	if err != nil {
+		return initPageModelResults{}, domain.ErrDatabaseQuery
+	}
+
 	newPage := model.Page{
 		Name:           pageInput.Name,
 		CoverImage:     pageInput.CoverImage,
@@ -57,6 +64,8 @@ func (r *PageRepository) initPageModel(
 		ViewType:       pageInput.ViewType.String(),
 		Path:           path,
 		GeneralRole:    GeneralRole,
+		IsTemplate:     pageInput.IsTemplate,
+		Position:       position,
 	}
 	return initPageModelResults{
 		Page:         &newPage,
@@ -132,11 +141,36 @@ func buildPageQuery(
 		query = query.Where("pages.view_type IN ?", q.ViewTypes)
 	}
 
+	if q.IsTemplate != nil {
+		query = query.Where("pages.is_template = ?", *q.IsTemplate)
+	}
+
+	// A non-nil empty list matches no page
+	if q.PagePkIDs != nil {
+		query = query.Where("pages.pkid IN ?", q.PagePkIDs)
+	}
+
+	if len(q.ExcludeGeneralRole) > 0 {
+		query = query.Where("pages.general_role NOT IN ?", sliceutils.Map(q.ExcludeGeneralRole, func(role domain.PageRole) string {
+			return role.String()
+		}))
+	}
+
 	if q.PathBeginWith != "" {
 		query = query.Where("pages.path LIKE ?", q.PathBeginWith+"%")
 	}
 
-	query = query.Order("pages.updated_at desc").Offset(q.Offset)
+	for _, condition := range q.PropertyConditions {
+		query = filterPagePropertyCondition(query, condition)
+	}
+
+	if q.AfterPkID != nil {
+		query = query.Where("pages.pkid > ?", *q.AfterPkID).Order("pages.pkid asc")
+	} else {
+		query = orderPageQuery(query, q)
+	}
+
+	query = query.Offset(q.Offset)
 	if q.Limit > 0 {
 		query = query.Limit(q.Limit)
 	}
@@ -144,6 +178,103 @@ func buildPageQuery(
 	return query
 }
 
+func orderPageQuery(query *gorm.DB, q domain.PageListQuery) *gorm.DB {
+	orderBy := q.OrderBy
+	if orderBy == "" {
+		// Sibling sets keep their manual order, listings across parents show recent pages first
+		orderBy = domain.PageOrderByUpdatedAt
+		if !q.IsAll {
+			orderBy = domain.PageOrderByPosition
+		}
+	}
+
+	direction := q.OrderDirection
+	if direction != domain.OrderAsc && direction != domain.OrderDesc {
+		direction = domain.OrderAsc
+		if orderBy == domain.PageOrderByCreatedAt || orderBy == domain.PageOrderByUpdatedAt {
+			direction = domain.OrderDesc
+		}
+	}
+
+	if q.PropertySort != nil {
+		propertyDirection := q.PropertySort.Direction
+		if propertyDirection != domain.OrderDesc {
+			propertyDirection = domain.OrderAsc
+		}
+		query = query.Order(propertySortExpression(q.PropertySort.Property) + " " + string(propertyDirection) + " NULLS LAST")
+	}
+
+	column := "pages.updated_at"
+	switch orderBy {
+	case domain.PageOrderByPosition:
+		column = "pages.position"
+	case domain.PageOrderByName:
+		column = "lower(pages.name)"
+	case domain.PageOrderByCreatedAt:
+		column = "pages.created_at"
+	}
+
+	return query.Order(column + " " + string(direction)).Order("pages.pkid " + string(direction))
+}
+
+// nextSiblingPosition returns the position after the last page of the sibling set. The set is renumbered
+// when the position would grow too long, after pages were moved to its end.
+func nextSiblingPosition(tx *gorm.DB, orgPkID *int64, parentPagePkID *int64) (string, error) {
+	if err := lockSiblingSet(tx, orgPkID, parentPagePkID); err != nil {
+		return "", err
+	}
+
+	siblingSet := func() *gorm.DB {
+		query := tx.Model(&model.Page{}).Where("org_pkid = ?", orgPkID)
+		if parentPagePkID != nil {
+			return query.Where("parent_page_pkid = ?", *parentPagePkID)
+		}
+		return query.Where("parent_page_pkid IS NULL")
+	}
+
+	var last model.Page
+	if err := siblingSet().Select("position").Order("position desc").Limit(1).Find(&last).Error; err != nil {
+		return "", err
+	}
+
+	position, err := pageutils.PositionAfter(last.Position)
+	if err == nil && len(position) <= maxPagePositionLength {
+		return position, nil
+	}
+
+	var siblings []model.Page
+	if err := siblingSet().Select("pkid").Order("position asc").Order("pkid asc").Find(&siblings).Error; err != nil {
+		return "", err
+	}
+	if err := renumberSiblingPositions(tx, siblings, len(siblings)); err != nil {
+		return "", err
+	}
+	return pageutils.SequencePosition(len(siblings)), nil
+}
+
+// lockSiblingSet locks the parent page, or the organization for root pages, until the transaction ends,
+// so concurrent writers to the same sibling set do not compute the same position.
+func lockSiblingSet(tx *gorm.DB, orgPkID *int64, parentPagePkID *int64) error {
+	locking := tx.Clauses(clause.Locking{Strength: "UPDATE"})
+	if parentPagePkID != nil {
+		return locking.Select("pkid").Where("pkid = ?", *parentPagePkID).Find(&model.Page{}).Error
+	}
+	return locking.Select("pkid").Where("pkid = ?", orgPkID).Find(&model.Organization{}).Error
+}
+
+// closestGeneralRolePage returns the page of the path closest to the page the path belongs to, out of
+// the ancestors whose general role is not inherit, nil if there is none.
+func closestGeneralRolePage(parentPkIDs []int64, basePages []model.Page) *model.Page {
+	for i := len(parentPkIDs) - 1; i >= 0; i-- {
+		for j := range basePages {
+			if basePages[j].Pkid == parentPkIDs[i] {
+				return &basePages[j]
+			}
+		}
+	}
+	return nil
+}
+
 func buildOrderByValuesClause(columnName string, ids []int64) string {
 	caseStatements := make([]string, len(ids))
 	for i, value := range ids {
@@ -151,3 +282,10 @@ func buildOrderByValuesClause(columnName string, ids []int64) string {
 	}
 	return fmt.Sprintf("CASE %s %s ELSE %d END", columnName, strings.Join(caseStatements, " "), len(ids))
 }
+
+func samePkID(a *int64, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
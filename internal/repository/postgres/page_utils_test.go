@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+)
+
+func TestClosestGeneralRolePage(t *testing.T) {
+	root := model.Page{Pkid: 1, GeneralRole: domain.PageViewer.String()}
+	folder := model.Page{Pkid: 2, Path: "1", GeneralRole: domain.PageRestrict.String()}
+	editorFolder := model.Page{Pkid: 3, Path: "1/2", GeneralRole: domain.PageEditor.String()}
+
+	tests := []struct {
+		name        string
+		parentPkIDs []int64
+		basePages   []model.Page
+		want        int64
+	}{
+		{
+			name:        "restricted middle folder wins over the public root",
+			parentPkIDs: []int64{1, 2},
+			basePages:   []model.Page{root, folder},
+			want:        2,
+		},
+		{
+			name:        "order of the rows does not matter",
+			parentPkIDs: []int64{1, 2},
+			basePages:   []model.Page{folder, root},
+			want:        2,
+		},
+		{
+			name:        "inherit ancestors are skipped",
+			parentPkIDs: []int64{1, 2, 4},
+			basePages:   []model.Page{root, folder},
+			want:        2,
+		},
+		{
+			name:        "closest of several ancestors",
+			parentPkIDs: []int64{1, 2, 3},
+			basePages:   []model.Page{root, editorFolder, folder},
+			want:        3,
+		},
+		{
+			name:        "root only",
+			parentPkIDs: []int64{1, 5},
+			basePages:   []model.Page{root},
+			want:        1,
+		},
+		{
+			name:        "no ancestor",
+			parentPkIDs: []int64{1, 2},
+			basePages:   nil,
+			want:        0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := closestGeneralRolePage(tt.parentPkIDs, tt.basePages)
+			if tt.want == 0 {
+				if got != nil {
+					t.Fatalf("closestGeneralRolePage() = %d, want nil", got.Pkid)
+				}
+				return
+			}
+			if got == nil || got.Pkid != tt.want {
+				t.Fatalf("closestGeneralRolePage() = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,53 +4,553 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/logger"
+	sliceutils "github.com/Stuhub-io/utils/slice"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 )
 
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	// Page size of the pkid listings, they page through the whole index with search_after
+	pagePkIDsBatchSize = 1000
+)
+
+// Pages are read and written through the alias, reindexing builds a new versioned index
+// and swaps the alias to it once complete.
+const (
+	pageIndexAlias  = "pages"
+	pageIndexPrefix = "pages_"
+)
+
+// Names match across accents and case, content is indexed with the same analyzer.
+const pageIndexSettings = `{
+	"settings": {
+		"analysis": {
+			"analyzer": {
+				"folding": {
+					"tokenizer": "standard",
+					"filter": ["lowercase", "asciifolding"]
+				}
+			}
+		}
+	},
+	"mappings": {
+		"properties": {
+			"pkid": { "type": "long" },
+			"id": { "type": "keyword" },
+			"org_pkid": { "type": "long" },
+			"parent_page_pkid": { "type": "long" },
+			"name": {
+				"type": "text",
+				"analyzer": "folding",
+				"fields": {
+					"prefix": { "type": "search_as_you_type", "analyzer": "folding" }
+				}
+			},
+			"content": { "type": "text", "analyzer": "folding" },
+			"view_type": { "type": "keyword" },
+			"asset_extension": { "type": "keyword" },
+			"path": { "type": "keyword" },
+			"is_archived": { "type": "boolean" },
+			"updated_at": { "type": "keyword" },
+			"indexed_at": { "type": "date" }
+		}
+	}
+}`
+
 type PageIndexer struct {
 	client *elasticsearch.Client
+	logger logger.Logger
 	index  string
 }
 
-func NewPageIndexer(client *elasticsearch.Client) *PageIndexer {
+type NewPageIndexerParams struct {
+	Client *elasticsearch.Client
+	Logger logger.Logger
+}
+
+func NewPageIndexer(params NewPageIndexerParams) *PageIndexer {
 	return &PageIndexer{
-		client: client,
-		index:  "page",
+		client: params.Client,
+		logger: params.Logger,
+		index:  pageIndexAlias,
 	}
 }
 
-func (i *PageIndexer) Index(ctx context.Context) error {
-	document := struct {
-		Title   string `json:"title"`
-		Content string `json:"content"`
-	}{
-		Title:   "nice",
-		Content: "asd",
+// WithIndex returns an indexer writing to the given index instead of the alias.
+func (i *PageIndexer) WithIndex(index string) *PageIndexer {
+	return &PageIndexer{
+		client: i.client,
+		logger: i.logger,
+		index:  index,
+	}
+}
+
+type pageDocument struct {
+	PkID           int64  `json:"pkid"`
+	ID             string `json:"id"`
+	OrgPkID        int64  `json:"org_pkid"`
+	ParentPagePkID *int64 `json:"parent_page_pkid"`
+	Name           string `json:"name"`
+	Content        string `json:"content"`
+	ViewType       string `json:"view_type"`
+	AssetExtension string `json:"asset_extension,omitempty"`
+	Path           string `json:"path"`
+	IsArchived     bool   `json:"is_archived"`
+	UpdatedAt      string `json:"updated_at"`
+	IndexedAt      string `json:"indexed_at"`
+}
+
+// EnsureIndex creates a page index behind the alias when there is none yet.
+func (i *PageIndexer) EnsureIndex(ctx context.Context) error {
+	res, err := esapi.IndicesExistsAliasRequest{Name: []string{pageIndexAlias}}.Do(ctx, i.client)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return nil
 	}
-	data, err := json.Marshal(document)
+
+	index, err := i.CreateIndex(ctx)
 	if err != nil {
-		log.Fatalf("Error marshaling the document: %s", err)
 		return err
 	}
+	_, err = i.SwapAlias(ctx, index)
+	return err
+}
+
+// CreateIndex creates a new versioned page index with the current mapping, returns its name.
+func (i *PageIndexer) CreateIndex(ctx context.Context) (string, error) {
+	index := pageIndexPrefix + time.Now().UTC().Format("20060102150405")
+
+	res, err := esapi.IndicesCreateRequest{
+		Index: index,
+		Body:  strings.NewReader(pageIndexSettings),
+	}.Do(ctx, i.client)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("create index %s: %s", index, res.String())
+	}
+
+	i.logger.Infof("elasticsearch index %s created", index)
+	return index, nil
+}
+
+// SwapAlias atomically points the alias to the index, returns the indices it pointed to before.
+func (i *PageIndexer) SwapAlias(ctx context.Context, index string) ([]string, error) {
+	res, err := esapi.IndicesGetAliasRequest{Name: []string{pageIndexAlias}}.Do(ctx, i.client)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Keyed by the indices the alias points to, not found when there is no alias yet
+	oldIndices := []string{}
+	if res.StatusCode != http.StatusNotFound {
+		if res.IsError() {
+			return nil, fmt.Errorf("get alias %s: %s", pageIndexAlias, res.String())
+		}
+		var aliases map[string]any
+		if err := json.NewDecoder(res.Body).Decode(&aliases); err != nil {
+			return nil, err
+		}
+		for oldIndex := range aliases {
+			if oldIndex != index {
+				oldIndices = append(oldIndices, oldIndex)
+			}
+		}
+	}
+
+	actions := []map[string]any{}
+	for _, oldIndex := range oldIndices {
+		actions = append(actions, map[string]any{
+			"remove": map[string]any{"index": oldIndex, "alias": pageIndexAlias},
+		})
+	}
+	actions = append(actions, map[string]any{
+		"add": map[string]any{"index": index, "alias": pageIndexAlias},
+	})
+
+	data, err := json.Marshal(map[string]any{"actions": actions})
+	if err != nil {
+		return nil, err
+	}
+
+	updateRes, err := esapi.IndicesUpdateAliasesRequest{Body: bytes.NewReader(data)}.Do(ctx, i.client)
+	if err != nil {
+		return nil, err
+	}
+	defer updateRes.Body.Close()
+
+	if updateRes.IsError() {
+		return nil, fmt.Errorf("swap alias %s to %s: %s", pageIndexAlias, index, updateRes.String())
+	}
+
+	return oldIndices, nil
+}
+
+func (i *PageIndexer) DeleteIndices(ctx context.Context, indices []string) error {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	res, err := esapi.IndicesDeleteRequest{Index: indices}.Do(ctx, i.client)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("delete indices %v: %s", indices, res.String())
+	}
+	return nil
+}
+
+// ChangedSince returns the pages written to the index since the given time.
+func (i *PageIndexer) ChangedSince(ctx context.Context, since time.Time) ([]int64, error) {
+	return i.listPagePkIDs(ctx, map[string]any{
+		"range": map[string]any{
+			"indexed_at": map[string]any{"gte": since.UTC().Format(time.RFC3339Nano)},
+		},
+	})
+}
+
+// ListPagePkIDs returns the pkids of every page in the index.
+func (i *PageIndexer) ListPagePkIDs(ctx context.Context) ([]int64, error) {
+	return i.listPagePkIDs(ctx, map[string]any{"match_all": map[string]any{}})
+}
+
+// listPagePkIDs returns the pkids of the pages matching the query, sorted by pkid and read in batches,
+// a single search stops at the index max_result_window.
+func (i *PageIndexer) listPagePkIDs(ctx context.Context, query map[string]any) ([]int64, error) {
+	pagePkIDs := []int64{}
+	var searchAfter []any
+
+	for {
+		body := map[string]any{
+			"size":    pagePkIDsBatchSize,
+			"_source": false,
+			"query":   query,
+			"sort":    []map[string]any{{"pkid": "asc"}},
+		}
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := esapi.SearchRequest{
+			Index: []string{i.index},
+			Body:  bytes.NewReader(data),
+		}.Do(ctx, i.client)
+		if err != nil {
+			return nil, err
+		}
+
+		var result searchResponse
+		if res.IsError() {
+			err = fmt.Errorf("list page pkids: %s", res.String())
+		} else {
+			err = json.NewDecoder(res.Body).Decode(&result)
+		}
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range result.Hits.Hits {
+			if pagePkID, err := strconv.ParseInt(hit.ID, 10, 64); err == nil {
+				pagePkIDs = append(pagePkIDs, pagePkID)
+			}
+		}
+		if len(result.Hits.Hits) < pagePkIDsBatchSize {
+			return pagePkIDs, nil
+		}
+		searchAfter = result.Hits.Hits[len(result.Hits.Hits)-1].Sort
+	}
+}
 
-	req := esapi.IndexRequest{
-		Index:      i.index,
-		DocumentID: "1",
-		Body:       bytes.NewReader(data),
-		Refresh:    "true",
+// DeleteOrgPagesBefore removes the organization's pages last written before the given time.
+func (i *PageIndexer) DeleteOrgPagesBefore(ctx context.Context, orgPkID int64, before time.Time) error {
+	data, err := json.Marshal(map[string]any{
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []map[string]any{
+					{"term": map[string]any{"org_pkid": orgPkID}},
+					{"range": map[string]any{
+						"indexed_at": map[string]any{"lt": before.UTC().Format(time.RFC3339Nano)},
+					}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
 	}
 
-	resp, err := req.Do(context.Background(), i.client)
+	refresh := true
+	res, err := esapi.DeleteByQueryRequest{
+		Index:   []string{i.index},
+		Body:    bytes.NewReader(data),
+		Refresh: &refresh,
+	}.Do(ctx, i.client)
 	if err != nil {
-		log.Fatalf("Error getting response: %s", err)
 		return err
 	}
-	defer resp.Body.Close()
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("delete stale pages of org %d: %s", orgPkID, res.String())
+	}
+	return nil
+}
+
+func (i *PageIndexer) IndexPages(ctx context.Context, pages []domain.PageSearchDocument) *domain.Error {
+	if len(pages) == 0 {
+		return nil
+	}
+
+	indexedAt := time.Now().UTC().Format(time.RFC3339Nano)
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, page := range pages {
+		action := map[string]any{
+			"index": map[string]any{"_index": i.index, "_id": strconv.FormatInt(page.PkID, 10)},
+		}
+		if err := encoder.Encode(action); err != nil {
+			i.logger.Error(err, "[Search]: Failed to encode bulk action")
+			return domain.ErrSearchIndex
+		}
+		if err := encoder.Encode(pageDocument{
+			PkID:           page.PkID,
+			ID:             page.ID,
+			OrgPkID:        page.OrgPkID,
+			ParentPagePkID: page.ParentPagePkID,
+			Name:           page.Name,
+			Content:        page.Content,
+			ViewType:       page.ViewType.String(),
+			AssetExtension: page.AssetExtension,
+			Path:           page.Path,
+			IsArchived:     page.IsArchived,
+			UpdatedAt:      page.UpdatedAt,
+			IndexedAt:      indexedAt,
+		}); err != nil {
+			i.logger.Error(err, "[Search]: Failed to encode page document")
+			return domain.ErrSearchIndex
+		}
+	}
+
+	return i.bulk(ctx, &body)
+}
+
+func (i *PageIndexer) DeletePages(ctx context.Context, pagePkIDs []int64) *domain.Error {
+	if len(pagePkIDs) == 0 {
+		return nil
+	}
 
-	log.Printf("Indexed document %s to index %s\n", resp.String(), i.index)
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, pagePkID := range pagePkIDs {
+		action := map[string]any{
+			"delete": map[string]any{"_index": i.index, "_id": strconv.FormatInt(pagePkID, 10)},
+		}
+		if err := encoder.Encode(action); err != nil {
+			i.logger.Error(err, "[Search]: Failed to encode bulk action")
+			return domain.ErrSearchIndex
+		}
+	}
 
+	return i.bulk(ctx, &body)
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+func (i *PageIndexer) bulk(ctx context.Context, body io.Reader) *domain.Error {
+	res, err := esapi.BulkRequest{Body: body}.Do(ctx, i.client)
+	if err != nil {
+		i.logger.Error(err, "[Search]: Failed to send bulk request")
+		return domain.ErrSearchIndex
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		i.logger.Error(errors.New(res.String()), "[Search]: Bulk request failed")
+		return domain.ErrSearchIndex
+	}
+
+	var result bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		i.logger.Error(err, "[Search]: Failed to decode bulk response")
+		return domain.ErrSearchIndex
+	}
+	if !result.Errors {
+		return nil
+	}
+
+	failed := 0
+	for _, item := range result.Items {
+		for _, op := range item {
+			// Deleting a page that was never indexed is fine
+			if op.Error == nil || op.Status == http.StatusNotFound {
+				continue
+			}
+			failed++
+			i.logger.Errorf(errors.New(op.Error.Reason), "[Search]: Failed to index page %s", op.ID)
+		}
+	}
+	if failed > 0 {
+		return domain.ErrSearchIndex
+	}
 	return nil
 }
+
+type searchResponse struct {
+	Hits struct {
+		Hits []struct {
+			ID        string              `json:"_id"`
+			Score     float64             `json:"_score"`
+			Highlight map[string][]string `json:"highlight"`
+			Sort      []any               `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (i *PageIndexer) Search(ctx context.Context, query domain.PageSearchQuery) ([]domain.PageSearchHit, *domain.Error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	filters := []map[string]any{
+		{"term": map[string]any{"org_pkid": query.OrgPkID}},
+		{"term": map[string]any{"is_archived": false}},
+	}
+	if len(query.ViewTypes) > 0 {
+		filters = append(filters, map[string]any{
+			"terms": map[string]any{
+				"view_type": sliceutils.Map(query.ViewTypes, func(viewType domain.PageViewType) string {
+					return viewType.String()
+				}),
+			},
+		})
+	}
+
+	body := map[string]any{
+		"from": max(query.Offset, 0),
+		"size": limit,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": filters,
+				"should": []map[string]any{
+					{
+						"multi_match": map[string]any{
+							"query":     query.Query,
+							"fields":    []string{"name^3", "content"},
+							"fuzziness": "AUTO",
+						},
+					},
+					{
+						// Typing ahead on names
+						"multi_match": map[string]any{
+							"query":  query.Query,
+							"type":   "bool_prefix",
+							"fields": []string{"name.prefix^2", "name.prefix._2gram", "name.prefix._3gram"},
+						},
+					},
+					{
+						"term": map[string]any{
+							"asset_extension": strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query.Query), ".")),
+						},
+					},
+				},
+				"minimum_should_match": 1,
+			},
+		},
+		"highlight": map[string]any{
+			// Fragments are rendered as HTML, the text around the tags is escaped
+			"encoder":   "html",
+			"pre_tags":  []string{domain.PageSearchHighlightPreTag},
+			"post_tags": []string{domain.PageSearchHighlightPostTag},
+			"fields": map[string]any{
+				"name":    map[string]any{"number_of_fragments": 0},
+				"content": map[string]any{"fragment_size": 150, "number_of_fragments": 3},
+			},
+		},
+		"_source": false,
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		i.logger.Error(err, "[Search]: Failed to encode search query")
+		return nil, domain.ErrSearchQuery
+	}
+
+	res, err := esapi.SearchRequest{
+		Index: []string{i.index},
+		Body:  bytes.NewReader(data),
+	}.Do(ctx, i.client)
+	if err != nil {
+		i.logger.Error(err, "[Search]: Failed to send search request")
+		return nil, domain.ErrSearchQuery
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		i.logger.Error(errors.New(res.String()), "[Search]: Search request failed")
+		return nil, domain.ErrSearchQuery
+	}
+
+	var result searchResponse
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		i.logger.Error(err, "[Search]: Failed to decode search response")
+		return nil, domain.ErrSearchQuery
+	}
+
+	hits := make([]domain.PageSearchHit, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		pagePkID, err := strconv.ParseInt(hit.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		hits = append(hits, domain.PageSearchHit{
+			PagePkID: pagePkID,
+			Score:    hit.Score,
+			Highlights: domain.PageSearchHighlight{
+				Name:    hit.Highlight["name"],
+				Content: hit.Highlight["content"],
+			},
+		})
+	}
+
+	return hits, nil
+}
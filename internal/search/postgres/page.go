@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/Stuhub-io/core/domain"
+	store "github.com/Stuhub-io/internal/repository"
+	"github.com/Stuhub-io/logger"
+	sliceutils "github.com/Stuhub-io/utils/slice"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// Ranked matches are limited first, so headlines are only built for the returned rows.
+// page_search is the unaccent aware text search configuration the tsvector columns are built with.
+// Headlines are HTML, the text is escaped like html.EscapeString before the <mark> tags are added.
+const searchPagesQuery = `
+WITH search AS (SELECT to_tsquery('page_search', @tsquery) AS query)
+SELECT
+	ranked.pkid,
+	ranked.score,
+	ts_headline('page_search', ranked.escaped_name, search.query,
+		'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS name_highlight,
+	ts_headline('page_search', ranked.escaped_content, search.query,
+		'StartSel=<mark>, StopSel=</mark>, MaxFragments=3, MinWords=10, MaxWords=25, FragmentDelimiter=" ... "') AS content_highlight
+FROM (
+	SELECT
+		pages.pkid,
+		replace(replace(replace(replace(replace(pages.name,
+			'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;') AS escaped_name,
+		replace(replace(replace(replace(replace(COALESCE(documents.content, ''),
+			'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;') AS escaped_content,
+		ts_rank(pages.name_tsv, search.query) * 3
+			+ COALESCE(ts_rank(documents.content_tsv, search.query), 0)
+			+ CASE WHEN lower(trim(LEADING '.' FROM assets.extension)) = @extension THEN 1 ELSE 0 END AS score
+	FROM pages
+	CROSS JOIN search
+	LEFT JOIN documents ON documents.page_pkid = pages.pkid
+	LEFT JOIN assets ON assets.page_pkid = pages.pkid
+	WHERE pages.org_pkid = @org_pkid
+		AND pages.archived_at IS NULL
+		AND (@view_types_all OR pages.view_type IN @view_types)
+		AND (
+			pages.name_tsv @@ search.query
+			OR documents.content_tsv @@ search.query
+			OR lower(trim(LEADING '.' FROM assets.extension)) = @extension
+		)
+	ORDER BY score DESC, pages.pkid DESC
+	LIMIT @limit OFFSET @offset
+) AS ranked
+CROSS JOIN search
+ORDER BY ranked.score DESC, ranked.pkid DESC
+`
+
+// PageSearcher searches pages with Postgres full-text search, for deployments without Elasticsearch.
+type PageSearcher struct {
+	store  *store.DBStore
+	logger logger.Logger
+}
+
+type NewPageSearcherParams struct {
+	Store  *store.DBStore
+	Logger logger.Logger
+}
+
+func NewPageSearcher(params NewPageSearcherParams) *PageSearcher {
+	return &PageSearcher{
+		store:  params.Store,
+		logger: params.Logger,
+	}
+}
+
+// IndexPages is a no-op, the search vectors are generated columns kept current by Postgres.
+func (s *PageSearcher) IndexPages(ctx context.Context, pages []domain.PageSearchDocument) *domain.Error {
+	return nil
+}
+
+// DeletePages is a no-op, purged pages take their search vectors with them.
+func (s *PageSearcher) DeletePages(ctx context.Context, pagePkIDs []int64) *domain.Error {
+	return nil
+}
+
+type searchPageRow struct {
+	Pkid             int64
+	Score            float64
+	NameHighlight    string
+	ContentHighlight string
+}
+
+func (s *PageSearcher) Search(ctx context.Context, query domain.PageSearchQuery) ([]domain.PageSearchHit, *domain.Error) {
+	tsQuery := prefixTSQuery(query.Query)
+	extension := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query.Query), "."))
+	if tsQuery == "" && extension == "" {
+		return []domain.PageSearchHit{}, nil
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	viewTypes := sliceutils.Map(query.ViewTypes, func(viewType domain.PageViewType) string {
+		return viewType.String()
+	})
+
+	var rows []searchPageRow
+	if err := s.store.DB().WithContext(ctx).Raw(searchPagesQuery, map[string]any{
+		"tsquery":        tsQuery,
+		"extension":      extension,
+		"org_pkid":       query.OrgPkID,
+		"view_types_all": len(query.ViewTypes) == 0,
+		"view_types":     viewTypes,
+		"limit":          limit,
+		"offset":         max(query.Offset, 0),
+	}).Scan(&rows).Error; err != nil {
+		s.logger.Error(err, "[Search]: Failed to search pages")
+		return nil, domain.ErrSearchQuery
+	}
+
+	return sliceutils.Map(rows, func(row searchPageRow) domain.PageSearchHit {
+		hit := domain.PageSearchHit{
+			PagePkID: row.Pkid,
+			Score:    row.Score,
+		}
+		// Headlines fall back to the start of the text when nothing matched
+		if strings.Contains(row.NameHighlight, domain.PageSearchHighlightPreTag) {
+			hit.Highlights.Name = []string{row.NameHighlight}
+		}
+		if strings.Contains(row.ContentHighlight, domain.PageSearchHighlightPreTag) {
+			hit.Highlights.Content = strings.Split(row.ContentHighlight, " ... ")
+		}
+		return hit
+	}), nil
+}
+
+// prefixTSQuery turns free text into a tsquery matching every word as a prefix, "proj bri" => "proj:* & bri:*".
+// Only letters and digits are kept, so user input can not inject tsquery operators.
+func prefixTSQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(sliceutils.Map(words, func(word string) string {
+		return word + ":*"
+	}), " & ")
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_document_revisions_page_pkid_created_at";
+
+DROP TABLE IF EXISTS "document_revisions";
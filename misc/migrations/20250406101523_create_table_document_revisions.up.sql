@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "document_revisions" (
+    "pkid" BIGSERIAL PRIMARY KEY,
+    "document_pkid" BIGINT NOT NULL,
+    "page_pkid" BIGINT NOT NULL,
+    "author_pkid" BIGINT,
+    "json_content" JSON,
+    "size" BIGINT NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_document_revisions_document
+        FOREIGN KEY (document_pkid)
+        REFERENCES "documents" (pkid) ON DELETE CASCADE,
+
+    CONSTRAINT fk_document_revisions_page
+        FOREIGN KEY (page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE,
+
+    CONSTRAINT fk_document_revisions_author
+        FOREIGN KEY (author_pkid)
+        REFERENCES "users" (pkid) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS "idx_document_revisions_page_pkid_created_at" ON "document_revisions" (page_pkid, created_at DESC);
@@ -0,0 +1,5 @@
+ALTER TABLE pages
+DROP COLUMN IF EXISTS version;
+
+ALTER TABLE documents
+DROP COLUMN IF EXISTS version;
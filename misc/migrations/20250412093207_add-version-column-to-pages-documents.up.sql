@@ -0,0 +1,5 @@
+ALTER TABLE pages
+ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE documents
+ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
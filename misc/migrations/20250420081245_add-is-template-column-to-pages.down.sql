@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pages_org_template;
+
+ALTER TABLE pages
+DROP COLUMN IF EXISTS is_template;
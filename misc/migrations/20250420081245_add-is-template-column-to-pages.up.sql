@@ -0,0 +1,4 @@
+ALTER TABLE pages
+ADD COLUMN IF NOT EXISTS is_template BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_pages_org_template ON pages (org_pkid) WHERE is_template;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pages_parent_position;
+
+ALTER TABLE pages
+DROP COLUMN IF EXISTS position;
@@ -0,0 +1,18 @@
+ALTER TABLE pages
+ADD COLUMN IF NOT EXISTS position TEXT COLLATE "C" NOT NULL DEFAULT '';
+
+-- Keep the current sidebar order, most recently updated first
+UPDATE pages
+SET position = ranked.position
+FROM (
+    SELECT pkid,
+        lpad(
+            (row_number() OVER (PARTITION BY org_pkid, parent_page_pkid ORDER BY updated_at DESC, pkid))::text,
+            10,
+            '0'
+        ) || '1' AS position
+    FROM pages
+) AS ranked
+WHERE pages.pkid = ranked.pkid;
+
+CREATE INDEX IF NOT EXISTS idx_pages_parent_position ON pages (org_pkid, parent_page_pkid, position);
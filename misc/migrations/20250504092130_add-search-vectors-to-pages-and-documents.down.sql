@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_documents_content_tsv;
+DROP INDEX IF EXISTS idx_pages_name_tsv;
+
+ALTER TABLE documents DROP COLUMN IF EXISTS content_tsv;
+ALTER TABLE pages DROP COLUMN IF EXISTS name_tsv;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS page_search;
@@ -0,0 +1,23 @@
+-- Accent-insensitive text search, accented words are indexed and queried without their accents
+CREATE TEXT SEARCH CONFIGURATION page_search (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION page_search
+    ALTER MAPPING FOR word, hword, hword_part WITH unaccent, simple;
+
+-- Documents created so far never had their plain text content filled
+UPDATE documents
+SET content = COALESCE((
+    SELECT string_agg(node #>> '{}', ' ')
+    FROM jsonb_path_query(documents.json_content::jsonb, 'strict $.**.text') AS node
+), '')
+WHERE content = '' AND json_content IS NOT NULL;
+
+ALTER TABLE pages
+ADD COLUMN IF NOT EXISTS name_tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('page_search', COALESCE(name, ''))) STORED;
+
+ALTER TABLE documents
+ADD COLUMN IF NOT EXISTS content_tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('page_search', COALESCE(content, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_pages_name_tsv ON pages USING GIN (name_tsv);
+CREATE INDEX IF NOT EXISTS idx_documents_content_tsv ON documents USING GIN (content_tsv);
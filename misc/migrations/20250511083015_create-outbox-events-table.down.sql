@@ -0,0 +1,5 @@
+DROP VIEW IF EXISTS outbox_dead_letters;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    pkid BIGSERIAL PRIMARY KEY,
+    topic VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+-- The dispatcher only scans due pending events
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at, pkid) WHERE status = 'pending';
+
+-- Events that exhausted their attempts, requeue with
+-- UPDATE outbox_events SET status = 'pending', attempts = 0, next_attempt_at = NOW() WHERE pkid = ...
+CREATE OR REPLACE VIEW outbox_dead_letters AS
+SELECT pkid, topic, payload, attempts, last_error, created_at, next_attempt_at AS failed_at
+FROM outbox_events
+WHERE status = 'dead';
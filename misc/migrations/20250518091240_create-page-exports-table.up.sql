@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS page_exports (
+    pkid BIGSERIAL PRIMARY KEY,
+    "id" UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    page_pkid BIGINT NOT NULL,
+    requester_pkid BIGINT NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    file_name TEXT,
+    archive BYTEA,
+    size BIGINT NOT NULL DEFAULT 0,
+    page_count INTEGER NOT NULL DEFAULT 0,
+    lease_until TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_page_exports_page
+        FOREIGN KEY (page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE,
+    CONSTRAINT fk_page_exports_requester
+        FOREIGN KEY (requester_pkid)
+        REFERENCES "users" (pkid) ON DELETE CASCADE
+);
+
+-- The export worker only scans unfinished exports
+CREATE INDEX IF NOT EXISTS idx_page_exports_unfinished ON page_exports (lease_until, pkid) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_page_exports_expires_at ON page_exports (expires_at);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS page_links (
+    source_page_pkid BIGINT NOT NULL,
+    target_page_pkid BIGINT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source_page_pkid, target_page_pkid, kind),
+    CONSTRAINT fk_page_links_source
+        FOREIGN KEY (source_page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE,
+    CONSTRAINT fk_page_links_target
+        FOREIGN KEY (target_page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE
+);
+
+-- Backlinks are looked up by target
+CREATE INDEX IF NOT EXISTS idx_page_links_target ON page_links (target_page_pkid);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS page_property_values;
+DROP TABLE IF EXISTS page_properties;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS page_properties (
+    pkid BIGSERIAL PRIMARY KEY,
+    "id" UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    folder_page_pkid BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    options JSONB NOT NULL DEFAULT '[]'::JSONB,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_page_properties_folder
+        FOREIGN KEY (folder_page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE
+);
+
+-- Property names are unique per folder regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS idx_page_properties_folder_name ON page_properties (folder_page_pkid, lower(name));
+
+CREATE TABLE IF NOT EXISTS page_property_values (
+    page_pkid BIGINT NOT NULL,
+    property_pkid BIGINT NOT NULL,
+    text_value TEXT,
+    number_value DOUBLE PRECISION,
+    date_value DATE,
+    bool_value BOOLEAN,
+    user_pkid BIGINT,
+    option_ids JSONB,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (page_pkid, property_pkid),
+    CONSTRAINT fk_page_property_values_page
+        FOREIGN KEY (page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE,
+    CONSTRAINT fk_page_property_values_property
+        FOREIGN KEY (property_pkid)
+        REFERENCES "page_properties" (pkid) ON DELETE CASCADE,
+    CONSTRAINT fk_page_property_values_user
+        FOREIGN KEY (user_pkid)
+        REFERENCES "users" (pkid) ON DELETE SET NULL
+);
+
+-- Listings filter and sort pages on the values of a property
+CREATE INDEX IF NOT EXISTS idx_page_property_values_property ON page_property_values (property_pkid);
@@ -0,0 +1,3 @@
+ALTER TABLE organization_member DROP CONSTRAINT IF EXISTS organization_member_role_check;
+ALTER TABLE organization_member
+    ADD CONSTRAINT organization_member_role_check CHECK (role IN ('owner', 'admin', 'member', 'guest'));
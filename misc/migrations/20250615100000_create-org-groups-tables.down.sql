@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS page_group_roles;
+DROP TABLE IF EXISTS org_group_members;
+DROP TABLE IF EXISTS org_groups;
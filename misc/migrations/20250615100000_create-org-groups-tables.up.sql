@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS org_groups (
+    pkid BIGSERIAL PRIMARY KEY,
+    "id" UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    org_pkid BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_org_groups_org
+        FOREIGN KEY (org_pkid)
+        REFERENCES "organizations" (pkid) ON DELETE CASCADE
+);
+
+-- Group names are unique per organization regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS idx_org_groups_org_name ON org_groups (org_pkid, lower(name));
+
+CREATE TABLE IF NOT EXISTS org_group_members (
+    group_pkid BIGINT NOT NULL,
+    user_pkid BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_pkid, user_pkid),
+    CONSTRAINT fk_org_group_members_group
+        FOREIGN KEY (group_pkid)
+        REFERENCES "org_groups" (pkid) ON DELETE CASCADE,
+    CONSTRAINT fk_org_group_members_user
+        FOREIGN KEY (user_pkid)
+        REFERENCES "users" (pkid) ON DELETE CASCADE
+);
+
+-- Permission checks look up the groups of a user
+CREATE INDEX IF NOT EXISTS idx_org_group_members_user ON org_group_members (user_pkid);
+
+CREATE TABLE IF NOT EXISTS page_group_roles (
+    pkid BIGSERIAL PRIMARY KEY,
+    page_pkid BIGINT NOT NULL,
+    group_pkid BIGINT NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_page_group_roles_page
+        FOREIGN KEY (page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE,
+    CONSTRAINT fk_page_group_roles_group
+        FOREIGN KEY (group_pkid)
+        REFERENCES "org_groups" (pkid) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_page_group_roles_page_group ON page_group_roles (page_pkid, group_pkid);
+CREATE INDEX IF NOT EXISTS idx_page_group_roles_group ON page_group_roles (group_pkid);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS "public_token" (
+    "pkid" bigserial PRIMARY KEY,
+    "id" UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    "page_pkid" BIGINT NOT NULL,
+    "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    "archived_at" TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT fk_public_token_page
+        FOREIGN KEY (page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE
+);
+
+-- Active links anyone can open without a password become public tokens again
+INSERT INTO "public_token" (id, page_pkid, created_at)
+SELECT id, page_pkid, created_at
+FROM page_share_links
+WHERE revoked_at IS NULL
+    AND (expires_at IS NULL OR expires_at > NOW())
+    AND password_hash IS NULL
+    AND NOT org_members_only
+ON CONFLICT (id) DO NOTHING;
+
+DROP TABLE IF EXISTS page_share_link_accesses;
+DROP TABLE IF EXISTS page_share_links;
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS page_share_links (
+    pkid BIGSERIAL PRIMARY KEY,
+    "id" UUID DEFAULT uuid_generate_v4() UNIQUE NOT NULL,
+    page_pkid BIGINT NOT NULL,
+    created_by_pkid BIGINT,
+    role VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    password_hash VARCHAR(255),
+    password_salt VARCHAR(255),
+    max_uses INTEGER,
+    use_count INTEGER NOT NULL DEFAULT 0,
+    org_members_only BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_page_share_links_page
+        FOREIGN KEY (page_pkid)
+        REFERENCES "pages" (pkid) ON DELETE CASCADE,
+    CONSTRAINT fk_page_share_links_created_by
+        FOREIGN KEY (created_by_pkid)
+        REFERENCES "users" (pkid) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_page_share_links_page ON page_share_links (page_pkid);
+
+-- Users who opened a link, anonymous openings are only counted on the link
+CREATE TABLE IF NOT EXISTS page_share_link_accesses (
+    link_pkid BIGINT NOT NULL,
+    user_pkid BIGINT NOT NULL,
+    access_count INTEGER NOT NULL DEFAULT 1,
+    first_accessed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_accessed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (link_pkid, user_pkid),
+    CONSTRAINT fk_page_share_link_accesses_link
+        FOREIGN KEY (link_pkid)
+        REFERENCES "page_share_links" (pkid) ON DELETE CASCADE,
+    CONSTRAINT fk_page_share_link_accesses_user
+        FOREIGN KEY (user_pkid)
+        REFERENCES "users" (pkid) ON DELETE CASCADE
+);
+
+-- Permission checks look up the links opened by a user
+CREATE INDEX IF NOT EXISTS idx_page_share_link_accesses_user ON page_share_link_accesses (user_pkid);
+
+-- Share links replace the public tokens, active tokens become viewer links with the same id so shared
+-- URLs keep opening the page
+INSERT INTO page_share_links (id, page_pkid, role, created_at, updated_at)
+SELECT id, page_pkid, 'viewer', created_at, created_at
+FROM "public_token"
+WHERE archived_at IS NULL
+ON CONFLICT (id) DO NOTHING;
+
+DROP TABLE IF EXISTS "public_token";
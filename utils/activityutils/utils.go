@@ -6,6 +6,8 @@ type UserCreatePageMeta struct {
 	NewPageName    string  `json:"page_name"`
 	NewPagePkID    int64   `json:"page_pkid"`
 	NewPageID      string  `json:"page_id"`
+	// Set when the page is created from a template.
+	TemplatePagePkID *int64 `json:"template_page_pkid,omitempty"`
 }
 
 type UserMovePageMeta struct {
@@ -36,3 +38,15 @@ type UserRemovePageMeta struct {
 	OldParentPagePkID *int64  `json:"parent_page_pkid"`
 	OldParentPageName *string `json:"parent_page_name"`
 }
+
+type UserRestorePageMeta struct {
+	ParentPagePkID *int64  `json:"parent_page_pkid"`
+	ParentPageName *string `json:"parent_page_name"`
+}
+
+type UserDuplicatePageMeta struct {
+	SourcePagePkID int64   `json:"source_page_pkid"`
+	SourcePageName string  `json:"source_page_name"`
+	ParentPagePkID *int64  `json:"parent_page_pkid"`
+	ParentPageName *string `json:"parent_page_name"`
+}
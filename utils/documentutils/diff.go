@@ -0,0 +1,298 @@
+package documentutils
+
+import (
+	"reflect"
+	"sort"
+)
+
+type ChangeType string
+
+const (
+	ChangeInserted ChangeType = "inserted"
+	ChangeDeleted  ChangeType = "deleted"
+	ChangeMoved    ChangeType = "moved"
+	ChangeModified ChangeType = "modified"
+)
+
+// BlockChange describes a change of a single block.
+// Paths are child indexes from the document root, OldPath refers to the old document and NewPath to the new one.
+// Moved blocks whose content also changed carry their TextChanges (or nested changes) as well.
+type BlockChange struct {
+	Type        ChangeType   `json:"type"`
+	NodeType    string       `json:"node_type"`
+	OldPath     []int        `json:"old_path,omitempty"`
+	NewPath     []int        `json:"new_path,omitempty"`
+	OldNode     *Node        `json:"old_node,omitempty"`
+	NewNode     *Node        `json:"new_node,omitempty"`
+	TextChanges []TextChange `json:"text_changes,omitempty"`
+}
+
+type DocumentDiff struct {
+	Changes []BlockChange `json:"changes"`
+	Stats   DiffStats     `json:"stats"`
+}
+
+type DiffStats struct {
+	Inserted int `json:"inserted"`
+	Deleted  int `json:"deleted"`
+	Moved    int `json:"moved"`
+	Modified int `json:"modified"`
+}
+
+// Diff computes the block level changes between two documents.
+// Blocks are matched by their "id" attribute first, then by identical content,
+// remaining blocks of the same type inside the same gap are treated as modified.
+func Diff(oldDoc, newDoc *Node) DocumentDiff {
+	if oldDoc == nil {
+		oldDoc = &Node{Type: NodeDoc}
+	}
+	if newDoc == nil {
+		newDoc = &Node{Type: NodeDoc}
+	}
+
+	changes := diffChildren(oldDoc.Content, newDoc.Content, []int{}, []int{})
+
+	diff := DocumentDiff{Changes: changes}
+	for _, change := range changes {
+		switch change.Type {
+		case ChangeInserted:
+			diff.Stats.Inserted++
+		case ChangeDeleted:
+			diff.Stats.Deleted++
+		case ChangeMoved:
+			diff.Stats.Moved++
+		case ChangeModified:
+			diff.Stats.Modified++
+		}
+	}
+
+	return diff
+}
+
+type blockPair struct {
+	oldIdx int
+	newIdx int
+}
+
+func diffChildren(oldNodes, newNodes []Node, oldPrefix, newPrefix []int) []BlockChange {
+	oldMatched := make([]int, len(oldNodes))
+	newMatched := make([]int, len(newNodes))
+	for i := range oldMatched {
+		oldMatched[i] = -1
+	}
+	for i := range newMatched {
+		newMatched[i] = -1
+	}
+
+	// Match by block id.
+	oldByID := map[string]int{}
+	for i := range oldNodes {
+		if id := oldNodes[i].Attr("id"); id != "" {
+			oldByID[id] = i
+		}
+	}
+	for j := range newNodes {
+		id := newNodes[j].Attr("id")
+		if id == "" {
+			continue
+		}
+		if i, ok := oldByID[id]; ok && oldMatched[i] == -1 {
+			oldMatched[i], newMatched[j] = j, i
+		}
+	}
+
+	// Match by identical content.
+	oldBySignature := map[string][]int{}
+	for i := range oldNodes {
+		if oldMatched[i] == -1 {
+			sig := oldNodes[i].String()
+			oldBySignature[sig] = append(oldBySignature[sig], i)
+		}
+	}
+	for j := range newNodes {
+		if newMatched[j] != -1 {
+			continue
+		}
+		sig := newNodes[j].String()
+		if candidates := oldBySignature[sig]; len(candidates) > 0 {
+			i := candidates[0]
+			oldBySignature[sig] = candidates[1:]
+			oldMatched[i], newMatched[j] = j, i
+		}
+	}
+
+	// Pairs in the longest increasing subsequence keep their relative order, the others moved.
+	pairs := []blockPair{}
+	for j := range newNodes {
+		if newMatched[j] != -1 {
+			pairs = append(pairs, blockPair{oldIdx: newMatched[j], newIdx: j})
+		}
+	}
+	stable := longestIncreasingPairs(pairs)
+
+	moved := map[int]bool{}
+	for _, p := range pairs {
+		if !stable[p.newIdx] {
+			moved[p.newIdx] = true
+		}
+	}
+
+	// Pair the remaining blocks of the same type between consecutive stable anchors.
+	anchors := []blockPair{{oldIdx: -1, newIdx: -1}}
+	for _, p := range pairs {
+		if stable[p.newIdx] {
+			anchors = append(anchors, p)
+		}
+	}
+	anchors = append(anchors, blockPair{oldIdx: len(oldNodes), newIdx: len(newNodes)})
+
+	modifiedPairs := map[int]bool{}
+	for a := 0; a < len(anchors)-1; a++ {
+		from, to := anchors[a], anchors[a+1]
+		for j := from.newIdx + 1; j < to.newIdx; j++ {
+			if newMatched[j] != -1 {
+				continue
+			}
+			for i := from.oldIdx + 1; i < to.oldIdx; i++ {
+				if oldMatched[i] == -1 && oldNodes[i].Type == newNodes[j].Type {
+					oldMatched[i], newMatched[j] = j, i
+					modifiedPairs[j] = true
+					break
+				}
+			}
+		}
+	}
+
+	changes := []BlockChange{}
+	deleted := make([]bool, len(oldNodes))
+	for i := range oldNodes {
+		deleted[i] = oldMatched[i] == -1
+	}
+
+	nextOld := 0
+	emitDeletedUntil := func(limit int) {
+		for ; nextOld < limit; nextOld++ {
+			if deleted[nextOld] {
+				node := oldNodes[nextOld]
+				changes = append(changes, BlockChange{
+					Type:     ChangeDeleted,
+					NodeType: node.Type,
+					OldPath:  childPath(oldPrefix, nextOld),
+					OldNode:  &node,
+				})
+			}
+		}
+	}
+
+	for j := range newNodes {
+		newNode := newNodes[j]
+		i := newMatched[j]
+
+		if i == -1 {
+			changes = append(changes, BlockChange{
+				Type:     ChangeInserted,
+				NodeType: newNode.Type,
+				NewPath:  childPath(newPrefix, j),
+				NewNode:  &newNode,
+			})
+			continue
+		}
+
+		if !moved[j] {
+			emitDeletedUntil(i + 1)
+		}
+
+		oldNode := oldNodes[i]
+		changed := modifiedPairs[j] || oldNode.String() != newNode.String()
+
+		switch {
+		case moved[j]:
+			change := BlockChange{
+				Type:     ChangeMoved,
+				NodeType: newNode.Type,
+				OldPath:  childPath(oldPrefix, i),
+				NewPath:  childPath(newPrefix, j),
+			}
+			if changed && newNode.IsTextBlock() {
+				change.OldNode, change.NewNode = &oldNode, &newNode
+				change.TextChanges = DiffText(oldNode.TextContent(), newNode.TextContent())
+			}
+			changes = append(changes, change)
+			if changed && !newNode.IsTextBlock() {
+				changes = append(changes, diffChildren(oldNode.Content, newNode.Content, childPath(oldPrefix, i), childPath(newPrefix, j))...)
+			}
+		case changed:
+			changes = append(changes, diffModified(&oldNode, &newNode, childPath(oldPrefix, i), childPath(newPrefix, j))...)
+		}
+	}
+	emitDeletedUntil(len(oldNodes))
+
+	return changes
+}
+
+func diffModified(oldNode, newNode *Node, oldPath, newPath []int) []BlockChange {
+	if oldNode.IsTextBlock() && newNode.IsTextBlock() {
+		return []BlockChange{{
+			Type:        ChangeModified,
+			NodeType:    newNode.Type,
+			OldPath:     oldPath,
+			NewPath:     newPath,
+			OldNode:     oldNode,
+			NewNode:     newNode,
+			TextChanges: DiffText(oldNode.TextContent(), newNode.TextContent()),
+		}}
+	}
+
+	changes := []BlockChange{}
+	if !reflect.DeepEqual(oldNode.Attrs, newNode.Attrs) {
+		changes = append(changes, BlockChange{
+			Type:     ChangeModified,
+			NodeType: newNode.Type,
+			OldPath:  oldPath,
+			NewPath:  newPath,
+			OldNode:  &Node{Type: oldNode.Type, Attrs: oldNode.Attrs},
+			NewNode:  &Node{Type: newNode.Type, Attrs: newNode.Attrs},
+		})
+	}
+
+	return append(changes, diffChildren(oldNode.Content, newNode.Content, oldPath, newPath)...)
+}
+
+// longestIncreasingPairs returns the new indexes of the pairs forming the longest run
+// of increasing old indexes, these blocks did not move relative to each other.
+func longestIncreasingPairs(pairs []blockPair) map[int]bool {
+	tails := []int{}
+	prev := make([]int, len(pairs))
+	for k, p := range pairs {
+		pos := sort.Search(len(tails), func(t int) bool {
+			return pairs[tails[t]].oldIdx >= p.oldIdx
+		})
+		if pos > 0 {
+			prev[k] = tails[pos-1]
+		} else {
+			prev[k] = -1
+		}
+		if pos == len(tails) {
+			tails = append(tails, k)
+		} else {
+			tails[pos] = k
+		}
+	}
+
+	stable := map[int]bool{}
+	if len(tails) == 0 {
+		return stable
+	}
+	for k := tails[len(tails)-1]; k != -1; k = prev[k] {
+		stable[pairs[k].newIdx] = true
+	}
+
+	return stable
+}
+
+func childPath(prefix []int, idx int) []int {
+	path := make([]int, len(prefix)+1)
+	copy(path, prefix)
+	path[len(prefix)] = idx
+	return path
+}
@@ -0,0 +1,208 @@
+package documentutils
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func paragraph(text string) Node {
+	return Node{Type: "paragraph", Content: []Node{{Type: NodeText, Text: text}}}
+}
+
+func block(id string, node Node) Node {
+	node.Attrs = map[string]any{"id": id}
+	return node
+}
+
+func list(items ...Node) Node {
+	content := make([]Node, 0, len(items))
+	for _, item := range items {
+		content = append(content, Node{Type: "listItem", Content: []Node{item}})
+	}
+	return Node{Type: "bulletList", Content: content}
+}
+
+func doc(nodes ...Node) *Node {
+	return &Node{Type: NodeDoc, Content: nodes}
+}
+
+// summary renders the changes as "type node_type old_path->new_path", nodes and text changes left out.
+func summary(changes []BlockChange) []string {
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, fmt.Sprintf("%s %s %v->%v", change.Type, change.NodeType, change.OldPath, change.NewPath))
+	}
+	return lines
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		oldDoc *Node
+		newDoc *Node
+		want   []string
+	}{
+		{
+			name:   "same blocks",
+			oldDoc: doc(paragraph("a"), paragraph("b")),
+			newDoc: doc(paragraph("a"), paragraph("b")),
+			want:   []string{},
+		},
+		{
+			name:   "nil old document",
+			newDoc: doc(paragraph("a")),
+			want:   []string{"inserted paragraph []->[0]"},
+		},
+		{
+			name:   "inserted block",
+			oldDoc: doc(paragraph("a"), paragraph("b")),
+			newDoc: doc(paragraph("a"), paragraph("x"), paragraph("b")),
+			want:   []string{"inserted paragraph []->[1]"},
+		},
+		{
+			name:   "deleted block",
+			oldDoc: doc(paragraph("a"), paragraph("x"), paragraph("b")),
+			newDoc: doc(paragraph("a"), paragraph("b")),
+			want:   []string{"deleted paragraph [1]->[]"},
+		},
+		{
+			name:   "modified block of the same type in the gap",
+			oldDoc: doc(paragraph("a"), paragraph("hello world")),
+			newDoc: doc(paragraph("a"), paragraph("hello there")),
+			want:   []string{"modified paragraph [1]->[1]"},
+		},
+		{
+			name:   "blocks of another type are replaced",
+			oldDoc: doc(paragraph("a"), paragraph("b")),
+			newDoc: doc(paragraph("a"), Node{Type: "heading", Content: []Node{{Type: NodeText, Text: "b"}}}),
+			want:   []string{"inserted heading []->[1]", "deleted paragraph [1]->[]"},
+		},
+		{
+			name:   "moved block keeps the longest ordered run",
+			oldDoc: doc(paragraph("a"), paragraph("b"), paragraph("c")),
+			newDoc: doc(paragraph("c"), paragraph("a"), paragraph("b")),
+			want:   []string{"moved paragraph [2]->[0]"},
+		},
+		{
+			name:   "block matched by id",
+			oldDoc: doc(block("1", paragraph("old")), paragraph("a")),
+			newDoc: doc(block("1", paragraph("new")), paragraph("a")),
+			want:   []string{"modified paragraph [0]->[0]"},
+		},
+		{
+			name:   "block matched by id moved and edited",
+			oldDoc: doc(block("1", paragraph("x")), paragraph("a")),
+			newDoc: doc(paragraph("a"), block("1", paragraph("y"))),
+			want:   []string{"moved paragraph [1]->[0]", "modified paragraph [0]->[1]"},
+		},
+		{
+			name:   "nested change",
+			oldDoc: doc(list(paragraph("a"), paragraph("b"))),
+			newDoc: doc(list(paragraph("a"), paragraph("c"))),
+			want:   []string{"modified paragraph [0 1 0]->[0 1 0]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := Diff(tt.oldDoc, tt.newDoc)
+			if got := summary(diff.Changes); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Diff()\n got: %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffStats(t *testing.T) {
+	diff := Diff(
+		doc(paragraph("a"), paragraph("b"), paragraph("c"), paragraph("gone")),
+		doc(paragraph("c"), paragraph("a"), paragraph("b!"), paragraph("new"), Node{Type: "horizontalRule"}),
+	)
+
+	want := DiffStats{Inserted: 1, Deleted: 0, Moved: 1, Modified: 2}
+	if diff.Stats != want {
+		t.Fatalf("Diff() stats = %+v, want %+v\nchanges: %q", diff.Stats, want, summary(diff.Changes))
+	}
+}
+
+func TestLongestIncreasingPairs(t *testing.T) {
+	tests := []struct {
+		name  string
+		pairs []blockPair
+		want  map[int]bool
+	}{
+		{name: "no pairs", want: map[int]bool{}},
+		{
+			name:  "ordered pairs",
+			pairs: []blockPair{{oldIdx: 0, newIdx: 0}, {oldIdx: 1, newIdx: 1}, {oldIdx: 2, newIdx: 2}},
+			want:  map[int]bool{0: true, 1: true, 2: true},
+		},
+		{
+			name:  "last block moved first",
+			pairs: []blockPair{{oldIdx: 2, newIdx: 0}, {oldIdx: 0, newIdx: 1}, {oldIdx: 1, newIdx: 2}},
+			want:  map[int]bool{1: true, 2: true},
+		},
+		{
+			name: "swapped halves",
+			pairs: []blockPair{
+				{oldIdx: 3, newIdx: 0}, {oldIdx: 4, newIdx: 1}, {oldIdx: 5, newIdx: 2},
+				{oldIdx: 0, newIdx: 3}, {oldIdx: 1, newIdx: 4},
+			},
+			want: map[int]bool{0: true, 1: true, 2: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longestIncreasingPairs(tt.pairs); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("longestIncreasingPairs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffText(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		want    []TextChange
+	}{
+		{name: "empty texts", want: []TextChange{}},
+		{name: "same text", oldText: "a b", newText: "a b", want: []TextChange{{Type: TextEqual, Text: "a b"}}},
+		{
+			name:    "replaced word",
+			oldText: "hello world",
+			newText: "hello there",
+			want: []TextChange{
+				{Type: TextEqual, Text: "hello "},
+				{Type: TextInsert, Text: "there"},
+				{Type: TextDelete, Text: "world"},
+			},
+		},
+		{
+			name:    "deleted punctuation",
+			oldText: "a, b",
+			newText: "a b",
+			want: []TextChange{
+				{Type: TextEqual, Text: "a"},
+				{Type: TextDelete, Text: ","},
+				{Type: TextEqual, Text: " b"},
+			},
+		},
+		{
+			name:    "inserted text",
+			newText: "new",
+			want:    []TextChange{{Type: TextInsert, Text: "new"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffText(tt.oldText, tt.newText); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DiffText(%q, %q) = %+v, want %+v", tt.oldText, tt.newText, got, tt.want)
+			}
+		})
+	}
+}
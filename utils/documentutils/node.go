@@ -0,0 +1,155 @@
+package documentutils
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Node is a single node of the editor JSON tree.
+// Documents are stored as a "doc" node whose content holds the top-level blocks.
+type Node struct {
+	Type    string         `json:"type"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Content []Node         `json:"content,omitempty"`
+	Text    string         `json:"text,omitempty"`
+	Marks   []Mark         `json:"marks,omitempty"`
+}
+
+type Mark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+const (
+	NodeDoc       = "doc"
+	NodeText      = "text"
+	NodeHardBreak = "hardBreak"
+	NodeMention   = "mention"
+)
+
+// ParseDocument parses a document json content, empty content ("" or "{}") is an empty doc.
+func ParseDocument(jsonContent string) (*Node, error) {
+	doc := &Node{Type: NodeDoc}
+
+	trimmed := strings.TrimSpace(jsonContent)
+	if trimmed == "" || trimmed == "{}" || trimmed == "null" {
+		return doc, nil
+	}
+
+	if err := json.Unmarshal([]byte(trimmed), doc); err != nil {
+		return nil, err
+	}
+	if doc.Type == "" {
+		doc.Type = NodeDoc
+	}
+
+	return doc, nil
+}
+
+// String returns the json representation of the node.
+func (n *Node) String() string {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func (n *Node) IsText() bool {
+	return n.Type == NodeText
+}
+
+// IsInline reports whether the node lives inside a text block (text, hard breaks, mentions...).
+func (n *Node) IsInline() bool {
+	return n.IsText() || n.Type == NodeHardBreak || n.Type == NodeMention
+}
+
+// IsTextBlock reports whether the node is a block holding inline content only, e.g. paragraph or heading.
+func (n *Node) IsTextBlock() bool {
+	if n.IsText() || len(n.Content) == 0 {
+		return n.Type == "paragraph" || n.Type == "heading" || n.Type == "codeBlock"
+	}
+	for i := range n.Content {
+		if !n.Content[i].IsInline() {
+			return false
+		}
+	}
+	return true
+}
+
+// Attr returns the string attribute of the node, or empty string if missing.
+func (n *Node) Attr(key string) string {
+	if n.Attrs == nil {
+		return ""
+	}
+	switch v := n.Attrs[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// TextContent returns the concatenated text of the node and its descendants.
+func (n *Node) TextContent() string {
+	if n.IsText() {
+		return n.Text
+	}
+	if n.Type == NodeHardBreak {
+		return "\n"
+	}
+	if n.Type == NodeMention {
+		return "@" + n.Attr("label")
+	}
+
+	var sb strings.Builder
+	for i := range n.Content {
+		sb.WriteString(n.Content[i].TextContent())
+	}
+	return sb.String()
+}
+
+// Walk visits the node and its descendants depth first, stopping a branch when fn returns false.
+func (n *Node) Walk(fn func(node *Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for i := range n.Content {
+		n.Content[i].Walk(fn)
+	}
+}
+
+// PlainText returns the document text with one line per text block.
+func PlainText(doc *Node) string {
+	if doc == nil {
+		return ""
+	}
+
+	lines := []string{}
+	doc.Walk(func(node *Node) bool {
+		if node.Type == NodeDoc {
+			return true
+		}
+		if node.IsTextBlock() {
+			if text := strings.TrimSpace(node.TextContent()); text != "" {
+				lines = append(lines, text)
+			}
+			return false
+		}
+		return true
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+// PlainTextFromJSON returns the plain text of an editor json document, empty when it does not parse.
+func PlainTextFromJSON(jsonContent string) string {
+	doc, err := ParseDocument(jsonContent)
+	if err != nil {
+		return ""
+	}
+	return PlainText(doc)
+}
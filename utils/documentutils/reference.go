@@ -0,0 +1,85 @@
+package documentutils
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Kinds of page references.
+const (
+	ReferenceMention = "mention"
+	ReferenceLink    = "link"
+)
+
+var pageIDPattern = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// PageReference is a page mentioned or linked to by a document.
+type PageReference struct {
+	PageID string
+	Kind   string
+}
+
+// PageReferences returns the pages the document mentions or links to, once per page and kind in document order.
+// Links reference the page whose id is the last one of the url path, other urls are ignored.
+// The ids are not resolved, they may belong to no page or to pages of another organization.
+func PageReferences(doc *Node) []PageReference {
+	references := []PageReference{}
+	if doc == nil {
+		return references
+	}
+
+	seen := map[PageReference]bool{}
+	add := func(pageID, kind string) {
+		reference := PageReference{PageID: strings.ToLower(pageID), Kind: kind}
+		if pageID == "" || seen[reference] {
+			return
+		}
+		seen[reference] = true
+		references = append(references, reference)
+	}
+
+	doc.Walk(func(node *Node) bool {
+		if node.Type == NodeMention {
+			add(mentionPageID(node), ReferenceMention)
+		}
+		for _, mark := range node.Marks {
+			if mark.Type == "link" {
+				href, _ := mark.Attrs["href"].(string)
+				add(linkPageID(href), ReferenceLink)
+			}
+		}
+		return true
+	})
+
+	return references
+}
+
+// mentionPageID returns the id of the mentioned page, empty when the mention is not of a page.
+func mentionPageID(node *Node) string {
+	if kind := node.Attr("type"); kind != "" && kind != "page" {
+		return ""
+	}
+	for _, key := range []string{"pageId", "id"} {
+		if id := node.Attr(key); id != "" && pageIDPattern.FindString(id) == id {
+			return id
+		}
+	}
+	return ""
+}
+
+func linkPageID(href string) string {
+	parsed, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return ""
+	}
+	if scheme := strings.ToLower(parsed.Scheme); scheme != "" && scheme != "http" && scheme != "https" {
+		return ""
+	}
+
+	ids := pageIDPattern.FindAllString(parsed.Path, -1)
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[len(ids)-1]
+}
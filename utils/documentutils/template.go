@@ -0,0 +1,52 @@
+package documentutils
+
+import (
+	"regexp"
+)
+
+// Variables supported in page templates.
+const (
+	TemplateVariableDate   = "date"
+	TemplateVariableTime   = "time"
+	TemplateVariableAuthor = "author"
+	TemplateVariableEmail  = "email"
+)
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+
+// ReplaceVariables replaces the {{name}} placeholders with their values, unknown ones are kept.
+func ReplaceVariables(text string, variables map[string]string) string {
+	if len(variables) == 0 {
+		return text
+	}
+
+	return variablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// ReplaceDocumentVariables replaces the placeholders in the text nodes of a document json content.
+// Content that is not a valid document is returned as is.
+func ReplaceDocumentVariables(jsonContent string, variables map[string]string) string {
+	if len(variables) == 0 || !variablePattern.MatchString(jsonContent) {
+		return jsonContent
+	}
+
+	doc, err := ParseDocument(jsonContent)
+	if err != nil {
+		return jsonContent
+	}
+
+	doc.Walk(func(node *Node) bool {
+		if node.IsText() {
+			node.Text = ReplaceVariables(node.Text, variables)
+		}
+		return true
+	})
+
+	return doc.String()
+}
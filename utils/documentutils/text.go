@@ -0,0 +1,132 @@
+package documentutils
+
+import (
+	"strings"
+	"unicode"
+)
+
+type TextChangeType string
+
+const (
+	TextEqual  TextChangeType = "equal"
+	TextInsert TextChangeType = "insert"
+	TextDelete TextChangeType = "delete"
+)
+
+type TextChange struct {
+	Type TextChangeType `json:"type"`
+	Text string         `json:"text"`
+}
+
+// maxDiffTokens bounds the word diff table, longer texts are reported as a whole replacement.
+const maxDiffTokens = 2000
+
+// DiffText computes the word level changes between two texts.
+func DiffText(oldText, newText string) []TextChange {
+	if oldText == newText {
+		if oldText == "" {
+			return []TextChange{}
+		}
+		return []TextChange{{Type: TextEqual, Text: oldText}}
+	}
+
+	oldTokens := tokenize(oldText)
+	newTokens := tokenize(newText)
+
+	if len(oldTokens) > maxDiffTokens || len(newTokens) > maxDiffTokens {
+		return mergeTextChanges([]TextChange{
+			{Type: TextDelete, Text: oldText},
+			{Type: TextInsert, Text: newText},
+		})
+	}
+
+	// lcs[i][j] is the longest common subsequence length of oldTokens[i:] and newTokens[j:].
+	lcs := make([][]int, len(oldTokens)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newTokens)+1)
+	}
+	for i := len(oldTokens) - 1; i >= 0; i-- {
+		for j := len(newTokens) - 1; j >= 0; j-- {
+			if oldTokens[i] == newTokens[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changes := []TextChange{}
+	i, j := 0, 0
+	for i < len(oldTokens) && j < len(newTokens) {
+		switch {
+		case oldTokens[i] == newTokens[j]:
+			changes = append(changes, TextChange{Type: TextEqual, Text: oldTokens[i]})
+			i++
+			j++
+		case lcs[i+1][j] > lcs[i][j+1]:
+			changes = append(changes, TextChange{Type: TextDelete, Text: oldTokens[i]})
+			i++
+		default:
+			changes = append(changes, TextChange{Type: TextInsert, Text: newTokens[j]})
+			j++
+		}
+	}
+	for ; i < len(oldTokens); i++ {
+		changes = append(changes, TextChange{Type: TextDelete, Text: oldTokens[i]})
+	}
+	for ; j < len(newTokens); j++ {
+		changes = append(changes, TextChange{Type: TextInsert, Text: newTokens[j]})
+	}
+
+	return mergeTextChanges(changes)
+}
+
+// tokenize splits text into words, whitespace runs and single punctuation characters.
+func tokenize(text string) []string {
+	tokens := []string{}
+
+	var current strings.Builder
+	currentKind := 0
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range text {
+		kind := 0
+		switch {
+		case unicode.IsSpace(r):
+			kind = 1
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			kind = 2
+		default:
+			kind = 3
+		}
+
+		if kind != currentKind || kind == 3 {
+			flush()
+		}
+		current.WriteRune(r)
+		currentKind = kind
+	}
+	flush()
+
+	return tokens
+}
+
+func mergeTextChanges(changes []TextChange) []TextChange {
+	merged := []TextChange{}
+	for _, change := range changes {
+		if change.Text == "" {
+			continue
+		}
+		if last := len(merged) - 1; last >= 0 && merged[last].Type == change.Type {
+			merged[last].Text += change.Text
+			continue
+		}
+		merged = append(merged, change)
+	}
+	return merged
+}
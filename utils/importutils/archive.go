@@ -0,0 +1,103 @@
+package importutils
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+const (
+	// MaxUploadSize is the upper bound of an uploaded file or archive.
+	MaxUploadSize = 20 << 20
+	// Upper bounds of an archive, sizes are uncompressed.
+	maxArchiveFiles = 500
+	maxArchiveSize  = 100 << 20
+	maxFileSize     = 5 << 20
+)
+
+// ReadFiles returns the files to import from the upload, the entries of zip archives sorted by path.
+// Directories, hidden files and macOS resource forks of archives are skipped, other entries are returned
+// as is so unsupported ones can be reported.
+func ReadFiles(file domain.PageImportFile) ([]domain.PageImportFile, *domain.Error) {
+	if strings.ToLower(path.Ext(file.Path)) != ".zip" {
+		file.Path = path.Base(file.Path)
+		if !IsSupported(file.Path) {
+			return nil, domain.ErrUnsupportedImportFile
+		}
+		if len(file.Content) > maxFileSize {
+			return nil, domain.ErrImportTooLarge
+		}
+		return []domain.PageImportFile{file}, nil
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(file.Content), int64(len(file.Content)))
+	if err != nil {
+		return nil, domain.ErrInvalidImportArchive
+	}
+
+	files := []domain.PageImportFile{}
+	var totalSize int64
+	for _, entry := range reader.File {
+		entryPath, ok := archivePath(entry.Name)
+		if !ok || entry.FileInfo().IsDir() {
+			continue
+		}
+		if len(files) == maxArchiveFiles {
+			return nil, domain.ErrImportTooLarge
+		}
+
+		content, dErr := readEntry(entry)
+		if dErr != nil {
+			return nil, dErr
+		}
+		// Sizes are counted from the content, the entry headers can not be trusted
+		if totalSize += int64(len(content)); totalSize > maxArchiveSize {
+			return nil, domain.ErrImportTooLarge
+		}
+
+		files = append(files, domain.PageImportFile{Path: entryPath, Content: content})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	return files, nil
+}
+
+func readEntry(entry *zip.File) ([]byte, *domain.Error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, domain.ErrInvalidImportArchive
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxFileSize+1))
+	if err != nil {
+		return nil, domain.ErrInvalidImportArchive
+	}
+	if len(content) > maxFileSize {
+		return nil, domain.ErrImportTooLarge
+	}
+	return content, nil
+}
+
+// archivePath cleans the entry name relative to the archive root, hidden entries are skipped.
+func archivePath(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	cleaned := path.Clean("/" + name)[1:]
+	if cleaned == "" {
+		return "", false
+	}
+	for _, segment := range strings.Split(cleaned, "/") {
+		if strings.HasPrefix(segment, ".") || segment == "__MACOSX" {
+			return "", false
+		}
+	}
+	return cleaned, true
+}
@@ -0,0 +1,367 @@
+package importutils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Stuhub-io/utils/documentutils"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var htmlSpaces = regexp.MustCompile(`[ \t\n\r\f]+`)
+
+// Elements converted to blocks, other elements are inline and converted through their content.
+var htmlBlockContainers = map[atom.Atom]bool{
+	atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true, atom.Header: true,
+	atom.Footer: true, atom.Nav: true, atom.Aside: true, atom.Figure: true, atom.Figcaption: true,
+	atom.Details: true, atom.Summary: true, atom.Center: true, atom.Address: true, atom.Form: true,
+	atom.Fieldset: true, atom.Dl: true, atom.Dt: true, atom.Dd: true, atom.Li: true, atom.Body: true,
+}
+
+// Elements skipped with their content.
+var htmlSkipped = map[atom.Atom]bool{
+	atom.Head: true, atom.Title: true, atom.Script: true, atom.Style: true, atom.Noscript: true,
+	atom.Template: true, atom.Iframe: true, atom.Object: true, atom.Embed: true, atom.Svg: true,
+	atom.Canvas: true, atom.Button: true, atom.Input: true, atom.Select: true, atom.Textarea: true,
+}
+
+var htmlInlineMarks = map[atom.Atom]string{
+	atom.Strong: markBold, atom.B: markBold,
+	atom.Em: markItalic, atom.I: markItalic, atom.Cite: markItalic,
+	atom.S: markStrike, atom.Del: markStrike, atom.Strike: markStrike,
+	atom.U: markUnderline, atom.Ins: markUnderline,
+	atom.Code: markCode, atom.Kbd: markCode, atom.Samp: markCode, atom.Tt: markCode,
+	atom.Mark: markHighlight,
+	atom.Sub:  markSubscript,
+	atom.Sup:  markSuperscript,
+}
+
+// HTML converts an html document or fragment into an editor document, scripts, styles and forms are dropped.
+func HTML(src string) *documentutils.Node {
+	doc := &documentutils.Node{Type: documentutils.NodeDoc, Content: []documentutils.Node{}}
+
+	// The parser recovers from any malformed input, it only fails on read errors
+	root, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return doc
+	}
+	if body := findElement(root, atom.Body); body != nil {
+		doc.Content = htmlBlocks(body)
+	}
+	return doc
+}
+
+// htmlBlocks converts the children of a block container, inline runs between blocks become paragraphs.
+func htmlBlocks(parent *html.Node) []documentutils.Node {
+	blocks := []documentutils.Node{}
+	var inline []documentutils.Node
+	flush := func() {
+		blocks = append(blocks, textBlocks(collapseSpaces(inline))...)
+		inline = nil
+	}
+
+	for child := parent.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && !htmlSkipped[child.DataAtom] && isHTMLBlock(child) {
+			flush()
+			blocks = append(blocks, htmlBlock(child)...)
+			continue
+		}
+		inline = append(inline, htmlInline(child, nil)...)
+	}
+	flush()
+
+	return blocks
+}
+
+func isHTMLBlock(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.P, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Blockquote,
+		atom.Ul, atom.Ol, atom.Pre, atom.Hr, atom.Table:
+		return true
+	default:
+		return htmlBlockContainers[n.DataAtom]
+	}
+}
+
+func htmlBlock(n *html.Node) []documentutils.Node {
+	switch n.DataAtom {
+	case atom.P:
+		return textBlocks(htmlInlineChildren(n, nil))
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		return []documentutils.Node{headingNode(level, htmlInlineChildren(n, nil))}
+	case atom.Blockquote:
+		return []documentutils.Node{{Type: nodeBlockquote, Content: nonEmptyBlocks(htmlBlocks(n))}}
+	case atom.Ul, atom.Ol:
+		return htmlList(n)
+	case atom.Pre:
+		return []documentutils.Node{htmlCodeBlock(n)}
+	case atom.Hr:
+		return []documentutils.Node{{Type: nodeHorizontalRule}}
+	case atom.Table:
+		return htmlTable(n)
+	default:
+		return htmlBlocks(n)
+	}
+}
+
+func htmlList(n *html.Node) []documentutils.Node {
+	listNode := documentutils.Node{Type: nodeBulletList}
+	if n.DataAtom == atom.Ol {
+		listNode.Type = nodeOrderedList
+		if start, err := strconv.Atoi(htmlAttr(n, "start")); err == nil && start != 1 {
+			listNode.Attrs = map[string]any{"start": start}
+		}
+	}
+
+	// The first item decides whether the list is a task list, like markdown
+	isTaskList := false
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom == atom.Li {
+			isTaskList = htmlCheckbox(child) != nil
+			break
+		}
+	}
+	if isTaskList {
+		listNode = documentutils.Node{Type: nodeTaskList}
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		// Lists nested directly in a list belong to the previous item
+		if child.DataAtom != atom.Li {
+			if last := len(listNode.Content) - 1; last >= 0 && isHTMLBlock(child) {
+				listNode.Content[last].Content = append(listNode.Content[last].Content, htmlBlock(child)...)
+			}
+			continue
+		}
+
+		item := documentutils.Node{Type: nodeListItem}
+		if isTaskList {
+			checkbox := htmlCheckbox(child)
+			item = documentutils.Node{
+				Type:  nodeTaskItem,
+				Attrs: map[string]any{"checked": checkbox != nil && hasHTMLAttr(checkbox, "checked")},
+			}
+		}
+		item.Content = listItemContent(htmlBlocks(child))
+		listNode.Content = append(listNode.Content, item)
+	}
+
+	if len(listNode.Content) == 0 {
+		return nil
+	}
+	return []documentutils.Node{listNode}
+}
+
+// htmlCheckbox returns the checkbox starting a task item, GitHub and most editors render tasks as
+// "<li><input type="checkbox" checked> text</li>", possibly wrapped in a label or paragraph.
+func htmlCheckbox(li *html.Node) *html.Node {
+	for n := li.FirstChild; n != nil; {
+		switch {
+		case n.Type == html.TextNode && strings.TrimSpace(n.Data) == "":
+			n = n.NextSibling
+		case n.DataAtom == atom.Input:
+			if strings.EqualFold(htmlAttr(n, "type"), "checkbox") {
+				return n
+			}
+			return nil
+		case n.DataAtom == atom.P || n.DataAtom == atom.Label || n.DataAtom == atom.Span:
+			n = n.FirstChild
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func htmlCodeBlock(pre *html.Node) documentutils.Node {
+	language := ""
+	if code := findElement(pre, atom.Code); code != nil {
+		for _, class := range strings.Fields(htmlAttr(code, "class")) {
+			if after, ok := strings.CutPrefix(class, "language-"); ok {
+				language = after
+			} else if after, ok := strings.CutPrefix(class, "lang-"); ok {
+				language = after
+			}
+		}
+	}
+
+	var sb strings.Builder
+	htmlText(pre, &sb)
+	// The parser drops the newline following <pre>, not the one before </pre>
+	return codeBlockNode(language, strings.TrimSuffix(sb.String(), "\n"))
+}
+
+func htmlTable(table *html.Node) []documentutils.Node {
+	tableNode := documentutils.Node{Type: nodeTable}
+
+	var rows func(n *html.Node)
+	rows = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			switch child.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				rows(child)
+			case atom.Tr:
+				if row := htmlTableRow(child); len(row.Content) > 0 {
+					tableNode.Content = append(tableNode.Content, row)
+				}
+			}
+		}
+	}
+	rows(table)
+
+	if len(tableNode.Content) == 0 {
+		return nil
+	}
+	return []documentutils.Node{tableNode}
+}
+
+func htmlTableRow(tr *html.Node) documentutils.Node {
+	row := documentutils.Node{Type: nodeTableRow}
+	for child := tr.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom != atom.Th && child.DataAtom != atom.Td {
+			continue
+		}
+
+		cell := documentutils.Node{Type: nodeTableCell, Content: nonEmptyBlocks(htmlBlocks(child))}
+		if child.DataAtom == atom.Th {
+			cell.Type = nodeTableHeader
+		}
+		for _, key := range []string{"colspan", "rowspan"} {
+			if span, err := strconv.Atoi(htmlAttr(child, key)); err == nil && span > 1 {
+				if cell.Attrs == nil {
+					cell.Attrs = map[string]any{}
+				}
+				cell.Attrs[key] = span
+			}
+		}
+		row.Content = append(row.Content, cell)
+	}
+	return row
+}
+
+func htmlInlineChildren(n *html.Node, marks []documentutils.Mark) []documentutils.Node {
+	nodes := []documentutils.Node{}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		for _, node := range htmlInline(child, marks) {
+			if node.IsText() {
+				nodes = appendText(nodes, node.Text, node.Marks)
+				continue
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return collapseSpaces(nodes)
+}
+
+// htmlInline converts inline content, blocks nested in inline elements are flattened into their text.
+func htmlInline(n *html.Node, marks []documentutils.Mark) []documentutils.Node {
+	switch n.Type {
+	case html.TextNode:
+		return appendText(nil, htmlSpaces.ReplaceAllString(n.Data, " "), marks)
+	case html.ElementNode:
+	default:
+		return nil
+	}
+
+	if htmlSkipped[n.DataAtom] {
+		return nil
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return []documentutils.Node{{Type: documentutils.NodeHardBreak}}
+	case atom.Img:
+		if src := imageURL(htmlAttr(n, "src")); src != "" {
+			return []documentutils.Node{imageNode(src, htmlAttr(n, "alt"), htmlAttr(n, "title"))}
+		}
+		return nil
+	case atom.A:
+		if href := linkURL(htmlAttr(n, "href")); href != "" {
+			marks = withMark(marks, linkMark(href))
+		}
+	default:
+		if markType, ok := htmlInlineMarks[n.DataAtom]; ok {
+			// Code excludes the other marks in the editor
+			if markType == markCode {
+				marks = nil
+			}
+			marks = withMark(marks, documentutils.Mark{Type: markType})
+		}
+	}
+
+	if isHTMLBlock(n) {
+		return append(htmlInlineChildren(n, marks), documentutils.Node{Type: documentutils.NodeText, Text: " ", Marks: marks})
+	}
+	return htmlInlineChildren(n, marks)
+}
+
+// collapseSpaces collapses the whitespace runs spanning several text nodes.
+func collapseSpaces(nodes []documentutils.Node) []documentutils.Node {
+	collapsed := make([]documentutils.Node, 0, len(nodes))
+	previousSpace := true
+	for _, node := range nodes {
+		if !node.IsText() {
+			previousSpace = node.Type == documentutils.NodeHardBreak
+			collapsed = append(collapsed, node)
+			continue
+		}
+		if previousSpace {
+			node.Text = strings.TrimLeft(node.Text, " ")
+		}
+		if node.Text == "" {
+			continue
+		}
+		previousSpace = strings.HasSuffix(node.Text, " ")
+		collapsed = appendText(collapsed, node.Text, node.Marks)
+	}
+	return collapsed
+}
+
+func htmlText(n *html.Node, sb *strings.Builder) {
+	if n.Type == html.TextNode {
+		sb.WriteString(n.Data)
+		return
+	}
+	if n.DataAtom == atom.Br {
+		sb.WriteByte('\n')
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		htmlText(child, sb)
+	}
+}
+
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func htmlAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func hasHTMLAttr(n *html.Node, key string) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
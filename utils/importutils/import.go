@@ -0,0 +1,276 @@
+package importutils
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+// Editor node and mark types produced by the parsers.
+const (
+	nodeParagraph      = "paragraph"
+	nodeHeading        = "heading"
+	nodeBlockquote     = "blockquote"
+	nodeBulletList     = "bulletList"
+	nodeOrderedList    = "orderedList"
+	nodeListItem       = "listItem"
+	nodeTaskList       = "taskList"
+	nodeTaskItem       = "taskItem"
+	nodeCodeBlock      = "codeBlock"
+	nodeHorizontalRule = "horizontalRule"
+	nodeImage          = "image"
+	nodeTable          = "table"
+	nodeTableRow       = "tableRow"
+	nodeTableHeader    = "tableHeader"
+	nodeTableCell      = "tableCell"
+
+	markBold        = "bold"
+	markItalic      = "italic"
+	markStrike      = "strike"
+	markUnderline   = "underline"
+	markCode        = "code"
+	markLink        = "link"
+	markHighlight   = "highlight"
+	markSubscript   = "subscript"
+	markSuperscript = "superscript"
+)
+
+// Notion exports suffix file and folder names with the page id, "Roadmap 0c3f...e1.md".
+var notionIDSuffix = regexp.MustCompile(`\s+[0-9a-f]{32}$`)
+
+var codeLanguagePattern = regexp.MustCompile(`^[A-Za-z0-9_+#.-]{1,32}$`)
+
+// IsSupported reports whether the file can be parsed into a document.
+func IsSupported(filePath string) bool {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".md", ".markdown", ".html", ".htm":
+		return true
+	default:
+		return false
+	}
+}
+
+// PageName is the name of the page imported from the file or directory.
+func PageName(filePath string) string {
+	name := path.Base(filePath)
+	if IsSupported(name) {
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+	name = strings.TrimSpace(notionIDSuffix.ReplaceAllString(name, ""))
+	if name == "" || name == "." || name == "/" {
+		return "Untitled"
+	}
+	return name
+}
+
+// Parse parses a markdown or html file into an editor document.
+// A leading heading repeating the page name is dropped, the page name is already displayed as its title.
+func Parse(file domain.PageImportFile) (*documentutils.Node, *domain.Error) {
+	if !IsSupported(file.Path) {
+		return nil, domain.ErrUnsupportedImportFile
+	}
+
+	content := bytes.TrimPrefix(file.Content, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(content) {
+		return nil, domain.ErrInvalidImportFile
+	}
+
+	var doc *documentutils.Node
+	switch strings.ToLower(path.Ext(file.Path)) {
+	case ".md", ".markdown":
+		doc = Markdown(string(content))
+	default:
+		doc = HTML(string(content))
+	}
+
+	if len(doc.Content) > 1 && doc.Content[0].Type == nodeHeading &&
+		strings.EqualFold(strings.TrimSpace(doc.Content[0].TextContent()), PageName(file.Path)) {
+		doc.Content = doc.Content[1:]
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []documentutils.Node{{Type: nodeParagraph}}
+	}
+
+	return doc, nil
+}
+
+func withMark(marks []documentutils.Mark, mark documentutils.Mark) []documentutils.Mark {
+	for _, m := range marks {
+		if m.Type == mark.Type {
+			return marks
+		}
+	}
+	// Copied, sibling nodes share the parent marks
+	return append(append(make([]documentutils.Mark, 0, len(marks)+1), marks...), mark)
+}
+
+func linkMark(href string) documentutils.Mark {
+	return documentutils.Mark{Type: markLink, Attrs: map[string]any{"href": href}}
+}
+
+// appendText appends the text to the inline nodes, merged into the last node when it has the same marks.
+func appendText(nodes []documentutils.Node, text string, marks []documentutils.Mark) []documentutils.Node {
+	if text == "" {
+		return nodes
+	}
+	if last := len(nodes) - 1; last >= 0 && nodes[last].IsText() && sameMarks(nodes[last].Marks, marks) {
+		nodes[last].Text += text
+		return nodes
+	}
+	return append(nodes, documentutils.Node{Type: documentutils.NodeText, Text: text, Marks: marks})
+}
+
+func sameMarks(a, b []documentutils.Mark) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].Attrs["href"] != b[i].Attrs["href"] {
+			return false
+		}
+	}
+	return true
+}
+
+// textBlocks wraps inline nodes into paragraphs, images are block nodes of the editor
+// so they split the paragraph they were written in.
+func textBlocks(inline []documentutils.Node) []documentutils.Node {
+	blocks := []documentutils.Node{}
+	var run []documentutils.Node
+	flush := func() {
+		if run = trimInline(run); len(run) > 0 {
+			blocks = append(blocks, documentutils.Node{Type: nodeParagraph, Content: run})
+		}
+		run = nil
+	}
+	for _, node := range inline {
+		if node.Type == nodeImage {
+			flush()
+			blocks = append(blocks, node)
+			continue
+		}
+		run = append(run, node)
+	}
+	flush()
+	return blocks
+}
+
+// inlineContent drops the images of inline nodes kept in a single text block, e.g. a heading.
+func inlineContent(inline []documentutils.Node) []documentutils.Node {
+	content := make([]documentutils.Node, 0, len(inline))
+	for _, node := range inline {
+		if node.Type != nodeImage {
+			content = append(content, node)
+		}
+	}
+	return trimInline(content)
+}
+
+// trimInline trims the whitespace around the text block and the hard breaks ending it.
+func trimInline(nodes []documentutils.Node) []documentutils.Node {
+	for len(nodes) > 0 {
+		if last := nodes[len(nodes)-1]; last.Type == documentutils.NodeHardBreak {
+			nodes = nodes[:len(nodes)-1]
+			continue
+		}
+		if first := &nodes[0]; first.IsText() {
+			first.Text = strings.TrimLeft(first.Text, " \t\n")
+			if first.Text == "" {
+				nodes = nodes[1:]
+				continue
+			}
+		}
+		if last := &nodes[len(nodes)-1]; last.IsText() {
+			last.Text = strings.TrimRight(last.Text, " \t\n")
+			if last.Text == "" {
+				nodes = nodes[:len(nodes)-1]
+				continue
+			}
+		}
+		break
+	}
+	return nodes
+}
+
+// listItemContent makes the first block of a list item a paragraph, as the editor schema requires.
+func listItemContent(blocks []documentutils.Node) []documentutils.Node {
+	if len(blocks) == 0 || blocks[0].Type != nodeParagraph {
+		return append([]documentutils.Node{{Type: nodeParagraph}}, blocks...)
+	}
+	return blocks
+}
+
+// nonEmptyBlocks returns the blocks, or an empty paragraph for containers requiring a block.
+func nonEmptyBlocks(blocks []documentutils.Node) []documentutils.Node {
+	if len(blocks) == 0 {
+		return []documentutils.Node{{Type: nodeParagraph}}
+	}
+	return blocks
+}
+
+// linkURL returns the link destination when it is relative or uses a safe scheme, empty otherwise.
+func linkURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if rawURL == "" || err != nil {
+		return ""
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto", "tel":
+		return rawURL
+	default:
+		return ""
+	}
+}
+
+// imageURL returns the image source when it is an absolute http url, empty otherwise.
+// Relative images point to files of the source tool, they are not uploaded so they are dropped.
+func imageURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+		return rawURL
+	default:
+		return ""
+	}
+}
+
+func imageNode(src, alt, title string) documentutils.Node {
+	attrs := map[string]any{"src": src}
+	if alt != "" {
+		attrs["alt"] = alt
+	}
+	if title != "" {
+		attrs["title"] = title
+	}
+	return documentutils.Node{Type: nodeImage, Attrs: attrs}
+}
+
+func headingNode(level int, inline []documentutils.Node) documentutils.Node {
+	return documentutils.Node{
+		Type:    nodeHeading,
+		Attrs:   map[string]any{"level": level},
+		Content: inlineContent(inline),
+	}
+}
+
+func codeBlockNode(language, code string) documentutils.Node {
+	node := documentutils.Node{Type: nodeCodeBlock}
+	if codeLanguagePattern.MatchString(language) {
+		node.Attrs = map[string]any{"language": language}
+	}
+	if code != "" {
+		node.Content = []documentutils.Node{{Type: documentutils.NodeText, Text: code}}
+	}
+	return node
+}
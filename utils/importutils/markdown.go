@@ -0,0 +1,913 @@
+package importutils
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+var (
+	tableDelimiterCell = regexp.MustCompile(`^:?-+:?$`)
+	emailAutolink      = regexp.MustCompile(`^[^@\s<>]+@[^@\s<>]+\.[^@\s<>]+$`)
+	lineBreakTag       = regexp.MustCompile(`(?i)^br\s*/?$`)
+)
+
+// Markdown parses CommonMark with the GitHub extensions (tables, task lists, strikethrough, autolinks)
+// into an editor document. Front matter is dropped, raw html and reference links are kept as text.
+func Markdown(src string) *documentutils.Node {
+	src = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(src)
+
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line)
+	}
+
+	return &documentutils.Node{
+		Type:    documentutils.NodeDoc,
+		Content: parseBlocks(stripFrontMatter(lines)),
+	}
+}
+
+func stripFrontMatter(lines []string) []string {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return lines
+	}
+	for i := 1; i < len(lines); i++ {
+		if line := strings.TrimSpace(lines[i]); line == "---" || line == "..." {
+			return lines[i+1:]
+		}
+		// Front matter is yaml, a line without a key is the setext heading of a document starting with "---"
+		if i == 1 && !strings.Contains(lines[i], ":") {
+			return lines
+		}
+	}
+	return lines
+}
+
+// expandTabs replaces the tabs of the line indentation by spaces, to the next multiple of 4 columns.
+func expandTabs(line string) string {
+	return expandTabsAt(line, 0)
+}
+
+// expandTabsAt expands the indentation of text starting at the column, the content after a block marker.
+func expandTabsAt(line string, column int) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	var sb strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\t':
+			width := 4 - column%4
+			sb.WriteString(strings.Repeat(" ", width))
+			column += width
+		case ' ':
+			sb.WriteByte(' ')
+			column++
+		default:
+			sb.WriteString(line[i:])
+			return sb.String()
+		}
+	}
+	return sb.String()
+}
+
+func parseBlocks(lines []string) []documentutils.Node {
+	blocks := []documentutils.Node{}
+
+	for i := 0; i < len(lines); {
+		start := i
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		var node documentutils.Node
+		switch {
+		case trimmed == "":
+			i++
+			continue
+		case indentOf(line) >= 4:
+			node, i = indentedCode(lines, i)
+		case fenceOf(line) != "":
+			node, i = fencedCode(lines, i)
+		case isThematicBreak(trimmed):
+			node = documentutils.Node{Type: nodeHorizontalRule}
+			i++
+		case isQuoteLine(line):
+			node, i = blockquote(lines, i)
+		default:
+			if level, text := atxHeading(trimmed); level > 0 {
+				node = headingNode(level, parseInline(text, nil))
+				i++
+				break
+			}
+			if _, ok := listMarkerOf(line); ok {
+				node, i = list(lines, i)
+				break
+			}
+			if isTableStart(lines, i) {
+				node, i = table(lines, i)
+				break
+			}
+
+			var paragraphBlocks []documentutils.Node
+			paragraphBlocks, i = paragraph(lines, i)
+			blocks = append(blocks, paragraphBlocks...)
+			continue
+		}
+
+		// A block consuming no line is kept as text instead, parsing always advances
+		if i <= start {
+			var paragraphBlocks []documentutils.Node
+			paragraphBlocks, i = paragraph(lines, start)
+			blocks = append(blocks, paragraphBlocks...)
+			continue
+		}
+
+		blocks = append(blocks, node)
+	}
+
+	return blocks
+}
+
+// startsBlock reports whether the line starts a block interrupting a paragraph.
+func startsBlock(lines []string, i int) bool {
+	line := lines[i]
+	if indentOf(line) >= 4 {
+		return false
+	}
+
+	trimmed := strings.TrimSpace(line)
+	if fenceOf(line) != "" || isThematicBreak(trimmed) || isQuoteLine(line) || isTableStart(lines, i) {
+		return true
+	}
+	if level, _ := atxHeading(trimmed); level > 0 {
+		return true
+	}
+
+	// Only non empty items interrupt a paragraph, ordered ones when starting at 1
+	marker, ok := listMarkerOf(line)
+	return ok && !marker.empty && (!marker.ordered || marker.start == 1)
+}
+
+func paragraph(lines []string, i int) ([]documentutils.Node, int) {
+	text := []string{strings.TrimLeft(lines[i], " ")}
+
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) {
+			break
+		}
+		if level := setextLevel(line); level > 0 {
+			return []documentutils.Node{headingNode(level, parseInline(strings.Join(text, "\n"), nil))}, i + 1
+		}
+		if startsBlock(lines, i) {
+			break
+		}
+		text = append(text, strings.TrimLeft(line, " "))
+	}
+
+	return textBlocks(parseInline(strings.Join(text, "\n"), nil)), i
+}
+
+func setextLevel(line string) int {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case indentOf(line) >= 4 || trimmed == "":
+		return 0
+	case strings.Trim(trimmed, "=") == "":
+		return 1
+	case strings.Trim(trimmed, "-") == "":
+		return 2
+	default:
+		return 0
+	}
+}
+
+func atxHeading(trimmed string) (int, string) {
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(trimmed) && trimmed[level] != ' ') {
+		return 0, ""
+	}
+
+	text := strings.TrimSpace(trimmed[level:])
+	// Optional closing sequence, "## Title ##"
+	if stripped := strings.TrimRight(text, "#"); stripped == "" || strings.HasSuffix(stripped, " ") {
+		text = strings.TrimSpace(stripped)
+	}
+	return level, text
+}
+
+func isThematicBreak(trimmed string) bool {
+	if len(trimmed) < 3 || !strings.ContainsRune("-*_", rune(trimmed[0])) {
+		return false
+	}
+	count := 0
+	for i := 0; i < len(trimmed); i++ {
+		switch trimmed[i] {
+		case trimmed[0]:
+			count++
+		case ' ', '\t':
+		default:
+			return false
+		}
+	}
+	return count >= 3
+}
+
+func indentedCode(lines []string, i int) (documentutils.Node, int) {
+	code := []string{}
+	for ; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			code = append(code, "")
+			continue
+		}
+		if indentOf(lines[i]) < 4 {
+			break
+		}
+		code = append(code, lines[i][4:])
+	}
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	return codeBlockNode("", strings.Join(code, "\n")), i
+}
+
+// fenceOf returns the code fence opening the line, a run of at least 3 backticks or tildes.
+func fenceOf(line string) string {
+	if indentOf(line) >= 4 {
+		return ""
+	}
+	trimmed := strings.TrimLeft(line, " ")
+	for _, c := range []byte{'`', '~'} {
+		n := runLength(trimmed, 0, c)
+		if n < 3 {
+			continue
+		}
+		// The info string of backtick fences can not hold backticks, "```a```" is inline code
+		if c == '`' && strings.Contains(trimmed[n:], "`") {
+			return ""
+		}
+		return trimmed[:n]
+	}
+	return ""
+}
+
+func fencedCode(lines []string, i int) (documentutils.Node, int) {
+	indent := indentOf(lines[i])
+	fence := fenceOf(lines[i])
+	language := ""
+	if info := strings.Fields(strings.TrimLeft(lines[i], " ")[len(fence):]); len(info) > 0 {
+		language = info[0]
+	}
+
+	code := []string{}
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if indentOf(lines[i]) < 4 && strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		code = append(code, trimIndent(lines[i], indent))
+	}
+
+	return codeBlockNode(language, strings.Join(code, "\n")), i
+}
+
+func blockquote(lines []string, i int) (documentutils.Node, int) {
+	quoted := []string{}
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimLeft(line, " ")
+		if indentOf(line) >= 4 || !strings.HasPrefix(trimmed, ">") {
+			// Lazy continuation of the quoted paragraph
+			if len(quoted) > 0 && !isBlank(quoted[len(quoted)-1]) && !isBlank(line) && !startsBlock(lines, i) {
+				quoted = append(quoted, trimmed)
+				continue
+			}
+			break
+		}
+		// Tabs after the marker expand from its column, one space of them belongs to the marker
+		quoted = append(quoted, strings.TrimPrefix(expandTabsAt(trimmed[1:], indentOf(line)+1), " "))
+	}
+
+	return documentutils.Node{
+		Type:    nodeBlockquote,
+		Content: nonEmptyBlocks(parseBlocks(quoted)),
+	}, i
+}
+
+type listMarker struct {
+	ordered bool
+	// Bullet character or ordered delimiter, "." or ")", a different one starts a new list.
+	delimiter byte
+	start     int
+	// Columns before the item content, continuation lines are indented by at least as much.
+	width int
+	empty bool
+}
+
+func listMarkerOf(line string) (listMarker, bool) {
+	indent := indentOf(line)
+	if indent >= 4 {
+		return listMarker{}, false
+	}
+
+	rest := line[indent:]
+	marker := listMarker{}
+	n := 0
+	if rest != "" && strings.ContainsRune("-+*", rune(rest[0])) {
+		marker.delimiter = rest[0]
+		n = 1
+	} else {
+		for n < len(rest) && n < 9 && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		if n == 0 || n >= len(rest) || (rest[n] != '.' && rest[n] != ')') {
+			return listMarker{}, false
+		}
+		marker.ordered = true
+		marker.start, _ = strconv.Atoi(rest[:n])
+		marker.delimiter = rest[n]
+		n++
+	}
+
+	after := rest[n:]
+	if isBlank(after) {
+		marker.empty = true
+		marker.width = indent + n + 1
+		return marker, true
+	}
+	if after[0] != ' ' {
+		return listMarker{}, false
+	}
+
+	spaces := indentOf(after)
+	// Content indented further is an indented code block, one space belongs to the marker
+	if spaces > 4 {
+		spaces = 1
+	}
+	marker.width = indent + n + spaces
+	return marker, true
+}
+
+func list(lines []string, i int) (documentutils.Node, int) {
+	first, _ := listMarkerOf(lines[i])
+	sameList := func(line string) bool {
+		marker, ok := listMarkerOf(line)
+		return ok && marker.ordered == first.ordered && marker.delimiter == first.delimiter &&
+			!isThematicBreak(strings.TrimSpace(line))
+	}
+
+	items := [][]string{}
+	for i < len(lines) && sameList(lines[i]) {
+		marker, _ := listMarkerOf(lines[i])
+		itemLines := []string{""}
+		if !marker.empty {
+			itemLines[0] = lines[i][marker.width:]
+		}
+
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if isBlank(line) {
+				if next := nextNonBlank(lines, i); next < 0 || indentOf(lines[next]) < marker.width {
+					break
+				}
+				itemLines = append(itemLines, "")
+				continue
+			}
+			if indentOf(line) >= marker.width {
+				itemLines = append(itemLines, line[marker.width:])
+				continue
+			}
+			// Lazy continuation of the item paragraph, any list marker starts the next item
+			if _, isMarker := listMarkerOf(line); !isMarker && !isBlank(itemLines[len(itemLines)-1]) && !startsBlock(lines, i) {
+				itemLines = append(itemLines, strings.TrimLeft(line, " "))
+				continue
+			}
+			break
+		}
+		items = append(items, itemLines)
+
+		// Blank lines between items of a loose list
+		if next := nextNonBlank(lines, i); next > i && sameList(lines[next]) {
+			i = next
+		}
+	}
+
+	listNode := documentutils.Node{Type: nodeBulletList}
+	if first.ordered {
+		listNode.Type = nodeOrderedList
+		if first.start != 1 {
+			listNode.Attrs = map[string]any{"start": first.start}
+		}
+	}
+
+	// The first item decides whether the list is a task list
+	isTaskList, _, _ := taskMarker(items[0][0])
+	if isTaskList {
+		listNode = documentutils.Node{Type: nodeTaskList}
+	}
+
+	for _, itemLines := range items {
+		item := documentutils.Node{Type: nodeListItem}
+		if isTaskList {
+			isTask, checked, rest := taskMarker(itemLines[0])
+			if isTask {
+				itemLines[0] = rest
+			}
+			item = documentutils.Node{Type: nodeTaskItem, Attrs: map[string]any{"checked": checked}}
+		}
+		item.Content = listItemContent(parseBlocks(itemLines))
+		listNode.Content = append(listNode.Content, item)
+	}
+
+	return listNode, i
+}
+
+// taskMarker parses the "[ ]" or "[x]" starting a task item.
+func taskMarker(line string) (isTask bool, checked bool, rest string) {
+	if len(line) < 3 || line[0] != '[' || line[2] != ']' || !strings.ContainsRune(" xX", rune(line[1])) {
+		return false, false, line
+	}
+	if len(line) > 3 && line[3] != ' ' {
+		return false, false, line
+	}
+	return true, line[1] != ' ', strings.TrimLeft(line[3:], " ")
+}
+
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") || !strings.Contains(lines[i+1], "|") {
+		return false
+	}
+	delimiters := splitTableRow(lines[i+1])
+	for _, cell := range delimiters {
+		if !tableDelimiterCell.MatchString(cell) {
+			return false
+		}
+	}
+	return len(splitTableRow(lines[i])) == len(delimiters)
+}
+
+func table(lines []string, i int) (documentutils.Node, int) {
+	header := splitTableRow(lines[i])
+	rows := []documentutils.Node{tableRow(header, len(header), nodeTableHeader)}
+
+	for i += 2; i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|"); i++ {
+		rows = append(rows, tableRow(splitTableRow(lines[i]), len(header), nodeTableCell))
+	}
+
+	return documentutils.Node{Type: nodeTable, Content: rows}, i
+}
+
+// tableRow builds a row of the header width, missing cells are empty and extra ones dropped.
+func tableRow(cells []string, columns int, cellType string) documentutils.Node {
+	row := documentutils.Node{Type: nodeTableRow}
+	for column := 0; column < columns; column++ {
+		text := ""
+		if column < len(cells) {
+			text = cells[column]
+		}
+		row.Content = append(row.Content, documentutils.Node{
+			Type:    cellType,
+			Content: nonEmptyBlocks(textBlocks(parseInline(text, nil))),
+		})
+	}
+	return row
+}
+
+// splitTableRow splits the row on its pipes, escaped pipes and pipes inside code spans are cell content.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	cells := []string{}
+	var cell strings.Builder
+	inCode := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '`':
+			inCode = !inCode
+			cell.WriteByte('`')
+		case line[i] == '|' && !inCode:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseInline parses the inline content of a text block, the nodes carry the given marks.
+func parseInline(text string, marks []documentutils.Mark) []documentutils.Node {
+	nodes := []documentutils.Node{}
+	var plain strings.Builder
+	flush := func() {
+		nodes = appendText(nodes, html.UnescapeString(plain.String()), marks)
+		plain.Reset()
+	}
+	appendNodes := func(inline []documentutils.Node) {
+		flush()
+		for _, node := range inline {
+			if node.IsText() {
+				nodes = appendText(nodes, node.Text, node.Marks)
+				continue
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	hardBreak := documentutils.Node{Type: documentutils.NodeHardBreak}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			appendNodes([]documentutils.Node{hardBreak})
+			i += 2
+			continue
+
+		case c == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]):
+			// Escaped characters are literal, entities included
+			appendNodes([]documentutils.Node{{Type: documentutils.NodeText, Text: text[i+1 : i+2], Marks: marks}})
+			i += 2
+			continue
+
+		case c == '\n':
+			// Two trailing spaces end the line with a hard break, other line endings are spaces
+			line := plain.String()
+			trimmed := strings.TrimRight(line, " ")
+			plain.Reset()
+			plain.WriteString(trimmed)
+			if len(line)-len(trimmed) >= 2 {
+				appendNodes([]documentutils.Node{hardBreak})
+			} else {
+				plain.WriteByte(' ')
+			}
+			for i++; i < len(text) && text[i] == ' '; i++ {
+			}
+			continue
+
+		case c == '`':
+			n := runLength(text, i, '`')
+			end := findCodeSpanClose(text, i+n, n)
+			if end < 0 {
+				plain.WriteString(text[i : i+n])
+				i += n
+				continue
+			}
+			code := strings.ReplaceAll(text[i+n:end], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			// Code excludes the other marks in the editor
+			appendNodes([]documentutils.Node{{
+				Type:  documentutils.NodeText,
+				Text:  code,
+				Marks: []documentutils.Mark{{Type: markCode}},
+			}})
+			i = end + n
+			continue
+
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			if label, dest, title, end, ok := parseLink(text, i+1); ok {
+				if src := imageURL(html.UnescapeString(dest)); src != "" {
+					alt := documentutils.Node{Content: parseInline(label, nil)}
+					appendNodes([]documentutils.Node{imageNode(src, alt.TextContent(), title)})
+				}
+				i = end
+				continue
+			}
+
+		case c == '[':
+			if label, dest, _, end, ok := parseLink(text, i); ok {
+				linkMarks := marks
+				if href := linkURL(html.UnescapeString(dest)); href != "" {
+					linkMarks = withMark(marks, linkMark(href))
+				}
+				appendNodes(parseInline(label, linkMarks))
+				i = end
+				continue
+			}
+
+		case c == '<':
+			end := strings.IndexByte(text[i:], '>')
+			if end < 0 {
+				break
+			}
+			inner := text[i+1 : i+end]
+			if href := autolinkURL(inner); href != "" {
+				appendNodes([]documentutils.Node{{
+					Type:  documentutils.NodeText,
+					Text:  inner,
+					Marks: withMark(marks, linkMark(href)),
+				}})
+				i += end + 1
+				continue
+			}
+			if lineBreakTag.MatchString(inner) {
+				appendNodes([]documentutils.Node{hardBreak})
+				i = skipSpaces(text, i+end+1)
+				continue
+			}
+
+		case c == 'h' && (i == 0 || strings.ContainsRune(" \n(", rune(text[i-1]))):
+			// Bare urls are links, GitHub autolink extension
+			if end := bareURLEnd(text, i); end > i {
+				appendNodes([]documentutils.Node{{
+					Type:  documentutils.NodeText,
+					Text:  text[i:end],
+					Marks: withMark(marks, linkMark(text[i:end])),
+				}})
+				i = end
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~' || c == '=':
+			n := runLength(text, i, c)
+			if inline, end, ok := parseEmphasis(text, i, n, marks); ok {
+				appendNodes(inline)
+				i = end
+				continue
+			}
+			plain.WriteString(text[i : i+n])
+			i += n
+			continue
+		}
+
+		plain.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return nodes
+}
+
+// parseEmphasis parses the emphasis opened by the delimiter run at i, returns its nodes and the end offset.
+// Runs are matched with the next closing run of the same length, which covers the common nesting cases.
+func parseEmphasis(text string, i, n int, marks []documentutils.Mark) ([]documentutils.Node, int, bool) {
+	c := text[i]
+
+	var runMarks []string
+	switch {
+	case (c == '*' || c == '_') && n == 1:
+		runMarks = []string{markItalic}
+	case (c == '*' || c == '_') && n == 2:
+		runMarks = []string{markBold}
+	case (c == '*' || c == '_') && n == 3:
+		runMarks = []string{markBold, markItalic}
+	case c == '~' && n <= 2:
+		runMarks = []string{markStrike}
+	case c == '=' && n == 2:
+		runMarks = []string{markHighlight}
+	default:
+		return nil, 0, false
+	}
+
+	// The opening run is followed by text, underscores inside words are literal
+	after := i + n
+	if after >= len(text) || isSpace(text[after]) || (c == '_' && i > 0 && isWordByte(text[i-1])) {
+		return nil, 0, false
+	}
+
+	end := findEmphasisClose(text, after, c, n)
+	if end < 0 {
+		return nil, 0, false
+	}
+
+	for _, markType := range runMarks {
+		marks = withMark(marks, documentutils.Mark{Type: markType})
+	}
+	return parseInline(text[after:end], marks), end + n, true
+}
+
+func findEmphasisClose(text string, from int, c byte, n int) int {
+	for j := from; j < len(text); {
+		switch text[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			m := runLength(text, j, '`')
+			if end := findCodeSpanClose(text, j+m, m); end >= 0 {
+				j = end + m
+				continue
+			}
+			j += m
+			continue
+		case c:
+			m := runLength(text, j, c)
+			if m == n && j > from && !isSpace(text[j-1]) && (c != '_' || j+m >= len(text) || !isWordByte(text[j+m])) {
+				return j
+			}
+			j += m
+			continue
+		}
+		j++
+	}
+	return -1
+}
+
+// findCodeSpanClose returns the offset of the backtick run of length n closing a code span, -1 if none.
+func findCodeSpanClose(text string, from, n int) int {
+	for j := from; j < len(text); {
+		if text[j] != '`' {
+			j++
+			continue
+		}
+		m := runLength(text, j, '`')
+		if m == n {
+			return j
+		}
+		j += m
+	}
+	return -1
+}
+
+// parseLink parses the inline link whose label opens at i, "[label](destination "title")".
+func parseLink(text string, i int) (label, dest, title string, end int, ok bool) {
+	depth := 0
+	j := i
+	for ; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '`':
+			m := runLength(text, j, '`')
+			if closeAt := findCodeSpanClose(text, j+m, m); closeAt >= 0 {
+				j = closeAt + m - 1
+			} else {
+				j += m - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if j+1 >= len(text) || text[j+1] != '(' {
+		return "", "", "", 0, false
+	}
+	label = text[i+1 : j]
+
+	k := skipSpaces(text, j+2)
+	if k < len(text) && text[k] == '<' {
+		closeAt := strings.IndexByte(text[k:], '>')
+		if closeAt < 0 {
+			return "", "", "", 0, false
+		}
+		dest = text[k+1 : k+closeAt]
+		k += closeAt + 1
+	} else {
+		start := k
+		parens := 0
+	destination:
+		for ; k < len(text); k++ {
+			switch text[k] {
+			case '\\':
+				k++
+			case ' ', '\n':
+				break destination
+			case '(':
+				parens++
+			case ')':
+				if parens == 0 {
+					break destination
+				}
+				parens--
+			}
+		}
+		k = min(k, len(text))
+		dest = text[start:k]
+	}
+
+	k = skipSpaces(text, k)
+	if k < len(text) && strings.ContainsRune(`"'(`, rune(text[k])) {
+		closing := text[k]
+		if closing == '(' {
+			closing = ')'
+		}
+		closeAt := strings.IndexByte(text[k+1:], closing)
+		if closeAt < 0 {
+			return "", "", "", 0, false
+		}
+		title = html.UnescapeString(text[k+1 : k+1+closeAt])
+		k = skipSpaces(text, k+closeAt+2)
+	}
+
+	if k >= len(text) || text[k] != ')' {
+		return "", "", "", 0, false
+	}
+	return label, dest, title, k + 1, true
+}
+
+func autolinkURL(inner string) string {
+	if strings.ContainsAny(inner, " \n<") {
+		return ""
+	}
+	lower := strings.ToLower(inner)
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "mailto:"):
+		return inner
+	case emailAutolink.MatchString(inner):
+		return "mailto:" + inner
+	default:
+		return ""
+	}
+}
+
+// bareURLEnd returns the end of the http url starting at i, or i when there is none.
+// Trailing punctuation is left out of the url, as well as an unbalanced closing parenthesis.
+func bareURLEnd(text string, i int) int {
+	lower := strings.ToLower(text[i:min(i+8, len(text))])
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return i
+	}
+
+	end := i
+	for end < len(text) && !isSpace(text[end]) && text[end] != '<' {
+		end++
+	}
+	for end > i {
+		last := text[end-1]
+		if strings.ContainsRune(".,:;!?*_~'\"", rune(last)) ||
+			(last == ')' && strings.Count(text[i:end], "(") < strings.Count(text[i:end], ")")) {
+			end--
+			continue
+		}
+		break
+	}
+
+	if strings.HasSuffix(text[i:end], "://") {
+		return i
+	}
+	return end
+}
+
+func runLength(text string, i int, c byte) int {
+	n := 0
+	for i+n < len(text) && text[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func skipSpaces(text string, i int) int {
+	for i < len(text) && (text[i] == ' ' || text[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+func nextNonBlank(lines []string, i int) int {
+	for ; i < len(lines); i++ {
+		if !isBlank(lines[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isQuoteLine(line string) bool {
+	return strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// trimIndent removes up to n spaces of indentation.
+func trimIndent(line string, n int) string {
+	return line[min(indentOf(line), n):]
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+// isWordByte reports whether the byte belongs to a word, bytes of multi-byte characters included.
+func isWordByte(c byte) bool {
+	return c >= 0x80 || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,159 @@
+package importutils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+// outline renders the node types and texts of the document, marks and attributes left out.
+func outline(nodes []documentutils.Node) string {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		switch {
+		case node.Type == "text":
+			parts = append(parts, fmt.Sprintf("%q", node.Text))
+		case len(node.Content) > 0:
+			parts = append(parts, node.Type+"("+outline(node.Content)+")")
+		default:
+			parts = append(parts, node.Type)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "paragraphs",
+			src:  "one\ntwo\n\nthree",
+			want: `paragraph("one two") paragraph("three")`,
+		},
+		{
+			name: "atx and setext headings",
+			src:  "# Title #\n\nSub\n---",
+			want: `heading("Title") heading("Sub")`,
+		},
+		{
+			name: "thematic break",
+			src:  "a\n\n***\n\nb",
+			want: `paragraph("a") horizontalRule paragraph("b")`,
+		},
+		{
+			name: "fenced code keeps its content",
+			src:  "```go\nfunc main() {}\n\n# not a heading\n```",
+			want: `codeBlock("func main() {}\n\n# not a heading")`,
+		},
+		{
+			name: "indented code",
+			src:  "    code\n\tmore",
+			want: `codeBlock("code\nmore")`,
+		},
+		{
+			name: "blockquote with lazy continuation",
+			src:  "> quoted\nlazy",
+			want: `blockquote(paragraph("quoted lazy"))`,
+		},
+		{
+			name: "nested blockquote",
+			src:  "> > inner",
+			want: `blockquote(blockquote(paragraph("inner")))`,
+		},
+		{
+			name: "nested blockquote separated by a tab",
+			src:  ">\t> inner",
+			want: `blockquote(blockquote(paragraph("inner")))`,
+		},
+		{
+			name: "empty nested blockquote separated by a tab",
+			src:  ">\t>",
+			want: `blockquote(blockquote(paragraph))`,
+		},
+		{
+			name: "quote marker after a vertical tab",
+			src:  "\v>",
+			want: `paragraph("\v>")`,
+		},
+		{
+			name: "bullet list",
+			src:  "- one\n- two",
+			want: `bulletList(listItem(paragraph("one")) listItem(paragraph("two")))`,
+		},
+		{
+			name: "ordered list keeps its start",
+			src:  "3. three\n4. four",
+			want: `orderedList(listItem(paragraph("three")) listItem(paragraph("four")))`,
+		},
+		{
+			name: "task list",
+			src:  "- [x] done\n- [ ] todo",
+			want: `taskList(taskItem(paragraph("done")) taskItem(paragraph("todo")))`,
+		},
+		{
+			name: "table",
+			src:  "| a | b |\n| - | - |\n| 1 | 2 |",
+			want: `table(tableRow(tableHeader(paragraph("a")) tableHeader(paragraph("b"))) tableRow(tableCell(paragraph("1")) tableCell(paragraph("2"))))`,
+		},
+		{
+			name: "front matter is dropped",
+			src:  "---\ntitle: x\n---\nbody",
+			want: `paragraph("body")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseWithDeadline(t, tt.src)
+			if got := outline(doc.Content); got != tt.want {
+				t.Errorf("Markdown(%q)\n got: %s\nwant: %s", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzMarkdown(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		">\t>",
+		"> >\t>\t> x",
+		"\v>\n\f> x",
+		"-\t[ ]\t>",
+		"1.\n2)\n   - >",
+		"| a |\n| - |\n>",
+		"```\n>\t>",
+		"    \t>\n\t- x",
+		"> ---\nx\n===",
+		"***bold** *em* `code` [link](http://x) <a@b.c>",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, src string) {
+		parseWithDeadline(t, src)
+	})
+}
+
+// parseWithDeadline fails the test instead of hanging when the parser stops advancing.
+func parseWithDeadline(t *testing.T, src string) *documentutils.Node {
+	t.Helper()
+
+	done := make(chan *documentutils.Node, 1)
+	go func() {
+		done <- Markdown(src)
+	}()
+
+	select {
+	case doc := <-done:
+		return doc
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Markdown(%q) did not return", src)
+		return nil
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 const OrgPkIDParam = "orgPkID"
 const OrgSlugParam = "orgSlug"
+const MemberPkIDParam = "memberPkID"
 
 func GetOrgPkIDParam(c *gin.Context) (int64, bool) {
 	orgPkID := c.Params.ByName(OrgPkIDParam)
@@ -25,3 +26,22 @@ func GetOrgSlugParam(c *gin.Context) (string, bool) {
 	}
 	return orgSlug, true
 }
+
+// GetMemberPkIDParam returns the user pkid of the member in the path.
+func GetMemberPkIDParam(c *gin.Context) (int64, bool) {
+	memberPkID, err := strconv.ParseInt(c.Params.ByName(MemberPkIDParam), 10, 64)
+	if err != nil {
+		return int64(-1), false
+	}
+	return memberPkID, true
+}
+
+const GroupIDParam = "groupID"
+
+func GetGroupIDParam(c *gin.Context) (string, bool) {
+	groupID := c.Params.ByName(GroupIDParam)
+	if groupID == "" {
+		return "", false
+	}
+	return groupID, true
+}
@@ -136,3 +136,28 @@ func TransformOrganizationMemberModelToDomain_New(member model.OrganizationMembe
 		UpdatedAt:        member.UpdatedAt.String(),
 	}
 }
+
+type OrgGroupWithMembers struct {
+	model.OrgGroup
+	MemberCount int64        `gorm:"column:member_count" json:"member_count"`
+	Members     []model.User `gorm:"-"                   json:"members"`
+}
+
+func TransformOrgGroupModelToDomain(group OrgGroupWithMembers) *domain.OrgGroup {
+	members := make([]domain.User, 0, len(group.Members))
+	for i := range group.Members {
+		members = append(members, *userutils.TransformUserModelToDomain(&group.Members[i]))
+	}
+
+	return &domain.OrgGroup{
+		PkID:             group.Pkid,
+		ID:               group.ID,
+		OrganizationPkID: group.OrgPkid,
+		Name:             group.Name,
+		Description:      group.Description,
+		MemberCount:      group.MemberCount,
+		Members:          members,
+		CreatedAt:        group.CreatedAt.String(),
+		UpdatedAt:        group.UpdatedAt.String(),
+	}
+}
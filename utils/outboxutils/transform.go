@@ -0,0 +1,32 @@
+package outboxutils
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+)
+
+func TransformOutboxEventModelToDomain(event model.OutboxEvent) domain.OutboxEvent {
+	lastError := ""
+	if event.LastError != nil {
+		lastError = *event.LastError
+	}
+	deliveredAt := ""
+	if event.DeliveredAt != nil {
+		deliveredAt = event.DeliveredAt.Format(time.RFC3339)
+	}
+
+	return domain.OutboxEvent{
+		PkID:          event.Pkid,
+		Topic:         domain.OutboxTopic(event.Topic),
+		Payload:       json.RawMessage(event.Payload),
+		Status:        domain.OutboxStatus(event.Status),
+		Attempts:      int(event.Attempts),
+		LastError:     lastError,
+		NextAttemptAt: event.NextAttemptAt.Format(time.RFC3339),
+		CreatedAt:     event.CreatedAt.Format(time.RFC3339),
+		DeliveredAt:   deliveredAt,
+	}
+}
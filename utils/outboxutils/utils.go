@@ -0,0 +1,88 @@
+package outboxutils
+
+import (
+	"context"
+
+	"github.com/Stuhub-io/core/domain"
+)
+
+type eventsKey struct{}
+
+// EventsBuilder builds the outbox events of a mutation from the pages it wrote,
+// so events can reference pages created by the mutation.
+type EventsBuilder func(pages []domain.Page) []domain.OutboxEventInput
+
+// WithEvents attaches events to a repository mutation, they are written in its transaction.
+// Builders attached to the same context are all run.
+func WithEvents(ctx context.Context, build EventsBuilder) context.Context {
+	if prev, ok := ctx.Value(eventsKey{}).(EventsBuilder); ok {
+		next := build
+		build = func(pages []domain.Page) []domain.OutboxEventInput {
+			return append(prev(pages), next(pages)...)
+		}
+	}
+	return context.WithValue(ctx, eventsKey{}, build)
+}
+
+// Events returns the events attached to ctx for the pages written by the mutation.
+func Events(ctx context.Context, pages ...domain.Page) []domain.OutboxEventInput {
+	build, ok := ctx.Value(eventsKey{}).(EventsBuilder)
+	if !ok {
+		return nil
+	}
+	return build(pages)
+}
+
+func ActivityEvent(input domain.ActivityInput) domain.OutboxEventInput {
+	return domain.OutboxEventInput{
+		Topic:   domain.OutboxTopicActivity,
+		Payload: input,
+	}
+}
+
+func SearchEvent(payload domain.OutboxSearchPayload) domain.OutboxEventInput {
+	return domain.OutboxEventInput{
+		Topic:   domain.OutboxTopicSearch,
+		Payload: payload,
+	}
+}
+
+func MailEvent(payload domain.OutboxMailPayload) domain.OutboxEventInput {
+	return domain.OutboxEventInput{
+		Topic:   domain.OutboxTopicMail,
+		Payload: payload,
+	}
+}
+
+// IndexPages refreshes the search documents of the written pages.
+func IndexPages(pages []domain.Page) []domain.OutboxEventInput {
+	if len(pages) == 0 {
+		return nil
+	}
+	return []domain.OutboxEventInput{SearchEvent(domain.OutboxSearchPayload{PagePkIDs: pagePkIDs(pages)})}
+}
+
+// IndexPageTrees refreshes the written pages with their descendants,
+// moving, archiving or restoring a page changes the path or archived state of the whole subtree.
+func IndexPageTrees(pages []domain.Page) []domain.OutboxEventInput {
+	if len(pages) == 0 {
+		return nil
+	}
+	return []domain.OutboxEventInput{SearchEvent(domain.OutboxSearchPayload{TreePagePkIDs: pagePkIDs(pages)})}
+}
+
+// UnindexPages removes the deleted pages from the search index.
+func UnindexPages(pages []domain.Page) []domain.OutboxEventInput {
+	if len(pages) == 0 {
+		return nil
+	}
+	return []domain.OutboxEventInput{SearchEvent(domain.OutboxSearchPayload{DeletedPagePkIDs: pagePkIDs(pages)})}
+}
+
+func pagePkIDs(pages []domain.Page) []int64 {
+	pkids := make([]int64, 0, len(pages))
+	for _, page := range pages {
+		pkids = append(pkids, page.PkID)
+	}
+	return pkids
+}
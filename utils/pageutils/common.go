@@ -1,6 +1,7 @@
 package pageutils
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -9,9 +10,12 @@ import (
 )
 
 const (
-	PagePkIDParam      = "pagePkID"
-	PageIDParam        = "pageID"
-	PublicTokenIDParam = "publicTokenID"
+	PagePkIDParam     = "pagePkID"
+	PageIDParam       = "pageID"
+	ShareLinkIDParam  = "shareLinkID"
+	RevisionPkIDParam = "revisionPkID"
+	ExportIDParam     = "exportID"
+	PropertyIDParam   = "propertyID"
 )
 
 func GetPageIDParam(c *gin.Context) (string, bool) {
@@ -31,12 +35,92 @@ func GetPagePkIDParam(c *gin.Context) (int64, bool) {
 	return int64(pagePkID), true
 }
 
-func GetPublicTokenIDParam(c *gin.Context) (string, bool) {
-	publicTokenID := c.Params.ByName(PublicTokenIDParam)
-	if publicTokenID == "" {
+func GetRevisionPkIDParam(c *gin.Context) (int64, bool) {
+	revisionPkIDStr := c.Params.ByName(RevisionPkIDParam)
+	if revisionPkIDStr == "" {
+		return int64(-1), false
+	}
+	revisionPkID, err := strconv.ParseInt(revisionPkIDStr, 10, 64)
+	if err != nil {
+		return int64(-1), false
+	}
+	return revisionPkID, true
+}
+
+// The page and its document are versioned separately, document ETags are prefixed so a page ETag can
+// not be sent to a document update by mistake.
+const (
+	documentETagPrefix = "document-"
+	// DocumentETagHeader holds the document ETag on responses whose ETag is the page one.
+	DocumentETagHeader = "X-Document-ETag"
+)
+
+// GetIfMatchVersion reads the page version from the If-Match header,
+// a missing header or "*" returns nil which skips the version check.
+func GetIfMatchVersion(c *gin.Context) (*int64, bool) {
+	return getIfMatchVersion(c, "")
+}
+
+// GetIfMatchDocumentVersion reads the document version from the If-Match header, like GetIfMatchVersion.
+func GetIfMatchDocumentVersion(c *gin.Context) (*int64, bool) {
+	return getIfMatchVersion(c, documentETagPrefix)
+}
+
+func getIfMatchVersion(c *gin.Context, prefix string) (*int64, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, true
+	}
+	ifMatch = strings.Trim(strings.TrimPrefix(ifMatch, "W/"), "\"")
+	if !strings.HasPrefix(ifMatch, prefix) {
+		return nil, false
+	}
+	version, err := strconv.ParseInt(strings.TrimPrefix(ifMatch, prefix), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &version, true
+}
+
+func SetETagVersion(c *gin.Context, version int64) {
+	c.Header("ETag", fmt.Sprintf("\"%d\"", version))
+}
+
+func SetDocumentETagVersion(c *gin.Context, version int64) {
+	c.Header("ETag", documentETag(version))
+}
+
+// SetDocumentETagHeader sets the document ETag next to the page ETag of the response.
+func SetDocumentETagHeader(c *gin.Context, version int64) {
+	c.Header(DocumentETagHeader, documentETag(version))
+}
+
+func documentETag(version int64) string {
+	return fmt.Sprintf("\"%s%d\"", documentETagPrefix, version)
+}
+
+func GetExportIDParam(c *gin.Context) (string, bool) {
+	exportID := c.Params.ByName(ExportIDParam)
+	if exportID == "" {
+		return "", false
+	}
+	return exportID, true
+}
+
+func GetPropertyIDParam(c *gin.Context) (string, bool) {
+	propertyID := c.Params.ByName(PropertyIDParam)
+	if propertyID == "" {
+		return "", false
+	}
+	return propertyID, true
+}
+
+func GetShareLinkIDParam(c *gin.Context) (string, bool) {
+	shareLinkID := c.Params.ByName(ShareLinkIDParam)
+	if shareLinkID == "" {
 		return "", false
 	}
-	return publicTokenID, true
+	return shareLinkID, true
 }
 
 func AppendPath(path string, pkID string) string {
@@ -0,0 +1,59 @@
+package pageutils
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		ifMatch      string
+		document     bool
+		wantVersion  int64
+		wantNil      bool
+		wantRejected bool
+	}{
+		{name: "missing header", wantNil: true},
+		{name: "any version", ifMatch: "*", wantNil: true},
+		{name: "page version", ifMatch: `"3"`, wantVersion: 3},
+		{name: "weak page version", ifMatch: `W/"3"`, wantVersion: 3},
+		{name: "document version", ifMatch: `"document-7"`, document: true, wantVersion: 7},
+		{name: "document ETag sent to a page update", ifMatch: `"document-7"`, wantRejected: true},
+		{name: "page ETag sent to a document update", ifMatch: `"3"`, document: true, wantRejected: true},
+		{name: "invalid version", ifMatch: `"abc"`, wantRejected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("PUT", "/", nil)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			get := GetIfMatchVersion
+			if tt.document {
+				get = GetIfMatchDocumentVersion
+			}
+			version, ok := get(c)
+
+			switch {
+			case tt.wantRejected:
+				if ok {
+					t.Fatalf("If-Match %q accepted, want rejected", tt.ifMatch)
+				}
+			case tt.wantNil:
+				if !ok || version != nil {
+					t.Fatalf("If-Match %q = %v, %v, want nil", tt.ifMatch, version, ok)
+				}
+			default:
+				if !ok || version == nil || *version != tt.wantVersion {
+					t.Fatalf("If-Match %q = %v, %v, want %d", tt.ifMatch, version, ok, tt.wantVersion)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package pageutils
+
+import (
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+)
+
+func TransformPageExportModelToDomain(export model.PageExport) domain.PageExport {
+	exportError := ""
+	if export.Error != nil {
+		exportError = *export.Error
+	}
+	fileName := ""
+	if export.FileName != nil {
+		fileName = *export.FileName
+	}
+	completedAt := ""
+	if export.CompletedAt != nil {
+		completedAt = export.CompletedAt.Format(time.RFC3339)
+	}
+
+	return domain.PageExport{
+		PkID:          export.Pkid,
+		ID:            export.ID,
+		PagePkID:      export.PagePkid,
+		RequesterPkID: export.RequesterPkid,
+		Format:        domain.DocumentFormat(export.Format),
+		Status:        domain.PageExportStatus(export.Status),
+		Attempts:      int(export.Attempts),
+		Error:         exportError,
+		FileName:      fileName,
+		Size:          export.Size,
+		PageCount:     int(export.PageCount),
+		CreatedAt:     export.CreatedAt.Format(time.RFC3339),
+		CompletedAt:   completedAt,
+		ExpiresAt:     export.ExpiresAt.Format(time.RFC3339),
+	}
+}
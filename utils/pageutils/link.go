@@ -0,0 +1,17 @@
+package pageutils
+
+import (
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+)
+
+func TransformPageLinkModelToDomain(link model.PageLink) domain.PageLink {
+	return domain.PageLink{
+		SourcePagePkID: link.SourcePagePkid,
+		TargetPagePkID: link.TargetPagePkid,
+		Kind:           domain.PageLinkKind(link.Kind),
+		CreatedAt:      link.CreatedAt.Format(time.RFC3339),
+	}
+}
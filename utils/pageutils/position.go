@@ -0,0 +1,126 @@
+package pageutils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Positions are fractional index keys over positionDigits, compared bytewise (COLLATE "C").
+// A key never ends with the smallest digit, so there is always room before and between two keys.
+const positionDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var ErrInvalidPositionRange = errors.New("position lower bound must be less than the upper bound")
+
+// PositionBetween returns a key strictly between lower and upper,
+// an empty lower means the start and an empty upper means the end of the sibling set.
+func PositionBetween(lower string, upper string) (string, error) {
+	if upper != "" && lower >= upper {
+		return "", ErrInvalidPositionRange
+	}
+	if !isValidPosition(lower) || !isValidPosition(upper) {
+		return "", fmt.Errorf("invalid position %q - %q", lower, upper)
+	}
+	return positionMidpoint(lower, upper), nil
+}
+
+// PositionAfter returns a key after lower for appending to the end of a sibling set. Appending after a
+// sequence key returns the next sequence key, so sets appended in order keep fixed length keys.
+func PositionAfter(lower string) (string, error) {
+	if index, ok := sequenceIndex(lower); ok && index+1 < maxSequenceIndex {
+		return SequencePosition(index + 1), nil
+	}
+	if lower == "" {
+		return SequencePosition(0), nil
+	}
+	return PositionBetween(lower, "")
+}
+
+// SequencePosition returns evenly spaced keys for renumbering a sibling set, ordered by index.
+// Matches the keys used to backfill existing pages.
+func SequencePosition(index int) string {
+	return fmt.Sprintf("%010d1", index+1)
+}
+
+// maxSequenceIndex is the number of sequence keys, their index is written on 10 digits.
+const maxSequenceIndex = 9_999_999_999
+
+// sequenceIndex returns the index of a key made by SequencePosition.
+func sequenceIndex(position string) (int, bool) {
+	if len(position) != 11 || position[10] != '1' {
+		return 0, false
+	}
+	index := 0
+	for i := 0; i < 10; i++ {
+		if position[i] < '0' || position[i] > '9' {
+			return 0, false
+		}
+		index = index*10 + int(position[i]-'0')
+	}
+	if index == 0 {
+		return 0, false
+	}
+	return index - 1, true
+}
+
+func positionMidpoint(lower string, upper string) string {
+	if upper != "" {
+		// Skip the common prefix, missing digits of lower count as the smallest digit
+		n := 0
+		for n < len(upper) && positionDigitAt(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(lower) {
+				rest = lower[n:]
+			}
+			return upper[:n] + positionMidpoint(rest, upper[n:])
+		}
+	}
+
+	lowerDigit := 0
+	if lower != "" {
+		lowerDigit = strings.IndexByte(positionDigits, lower[0])
+	}
+	upperDigit := len(positionDigits)
+	if upper != "" {
+		upperDigit = strings.IndexByte(positionDigits, upper[0])
+	}
+
+	if upperDigit-lowerDigit > 1 {
+		return string(positionDigits[(lowerDigit+upperDigit+1)/2])
+	}
+
+	// Consecutive digits
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+	rest := ""
+	if lower != "" {
+		rest = lower[1:]
+	}
+	return string(positionDigits[lowerDigit]) + positionMidpoint(rest, "")
+}
+
+func positionDigitAt(position string, i int) byte {
+	if i < len(position) {
+		return position[i]
+	}
+	return positionDigits[0]
+}
+
+func isValidPosition(position string) bool {
+	if position == "" {
+		return true
+	}
+	if position[len(position)-1] == positionDigits[0] {
+		return false
+	}
+	for i := 0; i < len(position); i++ {
+		if strings.IndexByte(positionDigits, position[i]) == -1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package pageutils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPositionBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		lower   string
+		upper   string
+		wantErr bool
+	}{
+		{name: "empty set"},
+		{name: "start of the set", upper: "i"},
+		{name: "end of the set", lower: "i"},
+		{name: "between distant keys", lower: "1", upper: "z"},
+		{name: "between consecutive digits", lower: "a", upper: "b"},
+		{name: "lower is a prefix of upper", lower: "a", upper: "a1"},
+		{name: "between sequence keys", lower: SequencePosition(0), upper: SequencePosition(1)},
+		{name: "before the smallest key", upper: "01"},
+		{name: "last digit of the range", lower: "y", upper: "z"},
+		{name: "equal bounds", lower: "i", upper: "i", wantErr: true},
+		{name: "reversed bounds", lower: "r", upper: "i", wantErr: true},
+		{name: "key ending with the smallest digit", lower: "i0", wantErr: true},
+		{name: "key with an invalid digit", lower: "A", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PositionBetween(tt.lower, tt.upper)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("PositionBetween(%q, %q) = %q, want an error", tt.lower, tt.upper, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PositionBetween(%q, %q) error = %v", tt.lower, tt.upper, err)
+			}
+			if !isValidPosition(got) || got <= tt.lower || (tt.upper != "" && got >= tt.upper) {
+				t.Fatalf("PositionBetween(%q, %q) = %q, not strictly between", tt.lower, tt.upper, got)
+			}
+		})
+	}
+}
+
+func TestPositionBetweenRandomInserts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	positions := []string{}
+
+	for i := 0; i < 2000; i++ {
+		index := rng.Intn(len(positions) + 1)
+		lower, upper := "", ""
+		if index > 0 {
+			lower = positions[index-1]
+		}
+		if index < len(positions) {
+			upper = positions[index]
+		}
+
+		position, err := PositionBetween(lower, upper)
+		if err != nil {
+			t.Fatalf("PositionBetween(%q, %q) error = %v", lower, upper, err)
+		}
+		positions = slices.Insert(positions, index, position)
+	}
+
+	if !slices.IsSorted(positions) {
+		t.Fatal("positions are not sorted")
+	}
+	if len(slices.Compact(slices.Clone(positions))) != len(positions) {
+		t.Fatal("positions are not unique")
+	}
+}
+
+func TestPositionAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		lower string
+		want  string
+	}{
+		{name: "empty set", lower: "", want: SequencePosition(0)},
+		{name: "after a sequence key", lower: SequencePosition(41), want: SequencePosition(42)},
+		{name: "after a midpoint key", lower: "i", want: "r"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PositionAfter(tt.lower)
+			if err != nil {
+				t.Fatalf("PositionAfter(%q) error = %v", tt.lower, err)
+			}
+			if got != tt.want {
+				t.Fatalf("PositionAfter(%q) = %q, want %q", tt.lower, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionAfterKeepsKeysShort(t *testing.T) {
+	last := ""
+	for i := 0; i < 100000; i++ {
+		position, err := PositionAfter(last)
+		if err != nil {
+			t.Fatalf("PositionAfter(%q) error = %v", last, err)
+		}
+		if position <= last {
+			t.Fatalf("PositionAfter(%q) = %q, not after", last, position)
+		}
+		if len(position) != len(SequencePosition(0)) {
+			t.Fatalf("append %d made a key of %d characters", i, len(position))
+		}
+		last = position
+	}
+}
+
+func TestSequenceIndex(t *testing.T) {
+	for _, index := range []int{0, 1, 9, 10, 12345, maxSequenceIndex - 1} {
+		got, ok := sequenceIndex(SequencePosition(index))
+		if !ok || got != index {
+			t.Errorf("sequenceIndex(SequencePosition(%d)) = %d, %v", index, got, ok)
+		}
+	}
+	for _, position := range []string{"", "i", "00000000001", "0000000001i", "000000000012", "a0000000001"} {
+		if _, ok := sequenceIndex(position); ok {
+			t.Errorf("sequenceIndex(%q) reported a sequence key", position)
+		}
+	}
+}
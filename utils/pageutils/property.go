@@ -0,0 +1,57 @@
+package pageutils
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/internal/repository/model"
+)
+
+const PagePropertyDateLayout = "2006-01-02"
+
+func TransformPagePropertyModelToDomain(property model.PageProperty) domain.PageProperty {
+	options := []domain.PagePropertyOption{}
+	if err := json.Unmarshal([]byte(property.Options), &options); err != nil || options == nil {
+		options = []domain.PagePropertyOption{}
+	}
+
+	return domain.PageProperty{
+		PkID:           property.Pkid,
+		ID:             property.ID,
+		FolderPagePkID: property.FolderPagePkid,
+		Name:           property.Name,
+		Type:           domain.PagePropertyType(property.Type),
+		Options:        options,
+		Position:       int(property.Position),
+		CreatedAt:      property.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      property.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func TransformPagePropertyValueModelToData(
+	value model.PagePropertyValue,
+	propertyType domain.PagePropertyType,
+) domain.PagePropertyData {
+	data := domain.PagePropertyData{
+		Number:   value.NumberValue,
+		Checkbox: value.BoolValue,
+		UserPkID: value.UserPkid,
+	}
+	if value.DateValue != nil {
+		date := value.DateValue.Format(PagePropertyDateLayout)
+		data.Date = &date
+	}
+
+	// Select values are stored as text, the option ids of multi selects as a json array
+	switch {
+	case propertyType == domain.PagePropertySelect && value.TextValue != nil:
+		data.OptionIDs = []string{*value.TextValue}
+	case propertyType == domain.PagePropertyMultiSelect && value.OptionIds != nil:
+		_ = json.Unmarshal([]byte(*value.OptionIds), &data.OptionIDs)
+	default:
+		data.Text = value.TextValue
+	}
+
+	return data
+}
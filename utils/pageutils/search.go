@@ -0,0 +1,35 @@
+package pageutils
+
+import (
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+// TransformPageToSearchDocument builds the indexed form of a page,
+// the page needs its Document or Asset loaded for the body to be indexed.
+func TransformPageToSearchDocument(page domain.Page) domain.PageSearchDocument {
+	searchDocument := domain.PageSearchDocument{
+		PkID:           page.PkID,
+		ID:             page.ID,
+		OrgPkID:        page.OrganizationPkID,
+		ParentPagePkID: page.ParentPagePkID,
+		Name:           page.Name,
+		ViewType:       page.ViewType,
+		Path:           page.Path,
+		IsArchived:     page.ArchivedAt != "",
+		UpdatedAt:      page.UpdatedAt,
+	}
+
+	if page.Document != nil {
+		// Content of unparsable documents is skipped, the page stays searchable by name
+		searchDocument.Content = documentutils.PlainTextFromJSON(page.Document.JsonContent)
+	}
+
+	if page.Asset != nil {
+		searchDocument.AssetExtension = strings.ToLower(strings.TrimPrefix(page.Asset.Extension, "."))
+	}
+
+	return searchDocument
+}
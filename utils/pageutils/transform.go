@@ -6,6 +6,7 @@ import (
 
 	"github.com/Stuhub-io/core/domain"
 	"github.com/Stuhub-io/internal/repository/model"
+	"github.com/Stuhub-io/utils/organizationutils"
 	sliceutils "github.com/Stuhub-io/utils/slice"
 	"github.com/Stuhub-io/utils/userutils"
 	"github.com/lib/pq"
@@ -26,6 +27,7 @@ func TransformDocModelToDomain(doc *model.Document) *domain.Document {
 		JsonContent: jsonContent,
 		CreatedAt:   doc.CreatedAt.String(),
 		UpdatedAt:   doc.UpdatedAt.String(),
+		Version:     doc.Version,
 	}
 }
 
@@ -91,6 +93,9 @@ func TransformPageModelToDomain(
 		Permissions:      Permissions,
 		ParentPage:       params.ParentPage,
 		PageStar:         params.PageStar,
+		Version:          model.Version,
+		IsTemplate:       model.IsTemplate,
+		Position:         model.Position,
 	}
 }
 
@@ -142,18 +147,42 @@ func TransformAssetModalToDomain(asset *model.Asset) *domain.Asset {
 	}
 }
 
-func TransformPagePublicTokenModelToDomain(model model.PublicToken) *domain.PagePublicToken {
-	archivedAt := ""
-	if model.ArchivedAt != nil {
-		archivedAt = model.ArchivedAt.String()
+func TransformPageShareLinkModelToDomain(model model.PageShareLink, now time.Time) *domain.PageShareLink {
+	link := &domain.PageShareLink{
+		PkID:           model.Pkid,
+		ID:             model.ID,
+		PagePkID:       model.PagePkid,
+		CreatedByPkID:  model.CreatedByPkid,
+		Role:           domain.PageRoleFromString(model.Role),
+		HasPassword:    model.PasswordHash != nil,
+		MaxUses:        model.MaxUses,
+		UseCount:       model.UseCount,
+		OrgMembersOnly: model.OrgMembersOnly,
+		IsActive:       ShareLinkActive(model, now),
+		CreatedAt:      model.CreatedAt.String(),
+		UpdatedAt:      model.UpdatedAt.String(),
+	}
+	if model.ExpiresAt != nil {
+		link.ExpiresAt = model.ExpiresAt.String()
+	}
+	if model.LastUsedAt != nil {
+		link.LastUsedAt = model.LastUsedAt.String()
 	}
-	return &domain.PagePublicToken{
-		PkID:       model.Pkid,
-		ArchivedAt: archivedAt,
-		PagePkID:   model.PagePkid,
-		ID:         model.ID,
-		CreatedAt:  model.CreatedAt.String(),
+	if model.RevokedAt != nil {
+		link.RevokedAt = model.RevokedAt.String()
 	}
+	if model.PasswordHash != nil && model.PasswordSalt != nil {
+		link.PasswordHash = *model.PasswordHash
+		link.PasswordSalt = *model.PasswordSalt
+	}
+	return link
+}
+
+// ShareLinkActive reports whether the link can still be opened by users who did not open it yet.
+func ShareLinkActive(model model.PageShareLink, now time.Time) bool {
+	return model.RevokedAt == nil &&
+		(model.ExpiresAt == nil || model.ExpiresAt.After(now)) &&
+		(model.MaxUses == nil || model.UseCount < *model.MaxUses)
 }
 
 type PartialPage struct {
@@ -303,3 +332,59 @@ func GetPageStarByUserPkID(stars []model.PageStar, usrPkID *int64) *domain.PageS
 		Model: &star[0],
 	})
 }
+
+type DocumentRevisionToDomainParams struct {
+	Model  *model.DocumentRevision
+	Author *model.User
+}
+
+func TransformDocumentRevisionModelToDomain(params DocumentRevisionToDomainParams) *domain.DocumentRevision {
+	if params.Model == nil {
+		return nil
+	}
+
+	revision := params.Model
+	jsonContent := ""
+	if revision.JSONContent != nil {
+		jsonContent = *revision.JSONContent
+	}
+
+	return &domain.DocumentRevision{
+		PkID:         revision.Pkid,
+		DocumentPkID: revision.DocumentPkid,
+		PagePkID:     revision.PagePkid,
+		AuthorPkID:   revision.AuthorPkid,
+		Author:       userutils.TransformUserModelToDomain(params.Author),
+		JsonContent:  jsonContent,
+		Size:         revision.Size,
+		CreatedAt:    revision.CreatedAt.String(),
+	}
+}
+
+type PageGroupRoleWithGroup struct {
+	model.PageGroupRole
+	Group           *model.OrgGroup `gorm:"foreignKey:group_pkid" json:"group"`
+	InheritFromPage *domain.Page    `gorm:"-"                     json:"inherit_from_page"`
+	Page            *model.Page     `gorm:"foreignKey:page_pkid"  json:"page"`
+}
+
+func TransformPageGroupRoleModelToDomain(
+	model PageGroupRoleWithGroup,
+) *domain.PageRoleUser {
+	var group *domain.OrgGroup
+	if model.Group != nil {
+		group = organizationutils.TransformOrgGroupModelToDomain(organizationutils.OrgGroupWithMembers{
+			OrgGroup: *model.Group,
+		})
+	}
+
+	return &domain.PageRoleUser{
+		PkID:            model.Pkid,
+		PagePkID:        model.PagePkid,
+		Role:            domain.PageRoleFromString(model.Role),
+		CreatedAt:       model.CreatedAt.String(),
+		UpdatedAt:       model.UpdatedAt.String(),
+		InheritFromPage: model.InheritFromPage,
+		Group:           group,
+	}
+}
@@ -0,0 +1,181 @@
+package renderutils
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+var codeLanguagePattern = regexp.MustCompile(`^[a-zA-Z0-9_+#-]+$`)
+
+// HTML renders the document as sanitized HTML, all text and attributes are escaped,
+// only the tags below are produced and urls with unsafe schemes are dropped.
+func HTML(doc *documentutils.Node) string {
+	if doc == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	htmlBlocks(&sb, doc.Content)
+	return sb.String()
+}
+
+func htmlBlocks(sb *strings.Builder, nodes []documentutils.Node) {
+	for i := range nodes {
+		htmlBlock(sb, &nodes[i])
+	}
+}
+
+func htmlBlock(sb *strings.Builder, node *documentutils.Node) {
+	switch node.Type {
+	case nodeParagraph:
+		htmlElement(sb, "p", "", func() { htmlInline(sb, node.Content) })
+
+	case nodeHeading:
+		level := min(max(intAttr(node, "level", 1), 1), 6)
+		htmlElement(sb, "h"+strconv.Itoa(level), "", func() { htmlInline(sb, node.Content) })
+
+	case nodeBlockquote:
+		htmlElement(sb, "blockquote", "", func() { htmlBlocks(sb, node.Content) })
+
+	case nodeBulletList:
+		htmlElement(sb, "ul", "", func() { htmlBlocks(sb, node.Content) })
+
+	case nodeOrderedList:
+		attrs := ""
+		if start := intAttr(node, "start", 1); start != 1 {
+			attrs = ` start="` + strconv.Itoa(start) + `"`
+		}
+		htmlElement(sb, "ol", attrs, func() { htmlBlocks(sb, node.Content) })
+
+	case nodeTaskList:
+		htmlElement(sb, "ul", ` data-type="taskList"`, func() { htmlBlocks(sb, node.Content) })
+
+	case nodeListItem:
+		htmlElement(sb, "li", "", func() { htmlBlocks(sb, node.Content) })
+
+	case nodeTaskItem:
+		checked := ""
+		if boolAttr(node, "checked") {
+			checked = " checked"
+		}
+		htmlElement(sb, "li", ` data-type="taskItem"`, func() {
+			sb.WriteString(`<input type="checkbox" disabled` + checked + `>`)
+			htmlBlocks(sb, node.Content)
+		})
+
+	case nodeCodeBlock:
+		attrs := ""
+		if language := node.Attr("language"); codeLanguagePattern.MatchString(language) {
+			attrs = ` class="language-` + language + `"`
+		}
+		sb.WriteString("<pre><code" + attrs + ">" + html.EscapeString(node.TextContent()) + "</code></pre>")
+
+	case nodeHorizontalRule:
+		sb.WriteString("<hr>")
+
+	case nodeImage:
+		src := safeURL(node.Attr("src"))
+		if src == "" {
+			return
+		}
+		sb.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(node.Attr("alt")) + `"`)
+		if title := node.Attr("title"); title != "" {
+			sb.WriteString(` title="` + html.EscapeString(title) + `"`)
+		}
+		sb.WriteString(">")
+
+	case nodeTable:
+		htmlElement(sb, "table", "", func() {
+			htmlElement(sb, "tbody", "", func() { htmlBlocks(sb, node.Content) })
+		})
+
+	case nodeTableRow:
+		htmlElement(sb, "tr", "", func() { htmlBlocks(sb, node.Content) })
+
+	case nodeTableHeader, nodeTableCell:
+		tag := "td"
+		if node.Type == nodeTableHeader {
+			tag = "th"
+		}
+		attrs := ""
+		if colspan := intAttr(node, "colspan", 1); colspan > 1 {
+			attrs += ` colspan="` + strconv.Itoa(colspan) + `"`
+		}
+		if rowspan := intAttr(node, "rowspan", 1); rowspan > 1 {
+			attrs += ` rowspan="` + strconv.Itoa(rowspan) + `"`
+		}
+		htmlElement(sb, tag, attrs, func() { htmlBlocks(sb, node.Content) })
+
+	default:
+		if node.IsInline() {
+			htmlInline(sb, []documentutils.Node{*node})
+		} else if node.IsTextBlock() {
+			htmlElement(sb, "p", "", func() { htmlInline(sb, node.Content) })
+		} else {
+			htmlBlocks(sb, node.Content)
+		}
+	}
+}
+
+// htmlElement writes the element, attrs must be escaped already.
+func htmlElement(sb *strings.Builder, tag string, attrs string, content func()) {
+	sb.WriteString("<" + tag + attrs + ">")
+	content()
+	sb.WriteString("</" + tag + ">")
+}
+
+func htmlInline(sb *strings.Builder, nodes []documentutils.Node) {
+	for _, run := range inlineRuns(nodes) {
+		switch run.node.Type {
+		case documentutils.NodeText:
+			htmlText(sb, run.node, run.text)
+		case documentutils.NodeHardBreak:
+			sb.WriteString("<br>")
+		case documentutils.NodeMention:
+			sb.WriteString(`<span class="mention" data-id="` + html.EscapeString(run.node.Attr("id")) + `">@` +
+				html.EscapeString(run.node.Attr("label")) + "</span>")
+		default:
+			htmlInline(sb, run.node.Content)
+		}
+	}
+}
+
+var htmlMarkTags = map[string]string{
+	markBold:        "strong",
+	markItalic:      "em",
+	markStrike:      "s",
+	markUnderline:   "u",
+	markCode:        "code",
+	markHighlight:   "mark",
+	markSubscript:   "sub",
+	markSuperscript: "sup",
+}
+
+func htmlText(sb *strings.Builder, node documentutils.Node, text string) {
+	closing := []string{}
+	for _, mark := range node.Marks {
+		if mark.Type == markLink {
+			href := safeURL(markAttr(mark, "href"))
+			if href == "" {
+				continue
+			}
+			sb.WriteString(`<a href="` + html.EscapeString(href) + `" rel="noopener noreferrer nofollow">`)
+			closing = append(closing, "</a>")
+			continue
+		}
+		if tag, ok := htmlMarkTags[mark.Type]; ok {
+			sb.WriteString("<" + tag + ">")
+			closing = append(closing, "</"+tag+">")
+		}
+	}
+
+	sb.WriteString(html.EscapeString(text))
+
+	for i := len(closing) - 1; i >= 0; i-- {
+		sb.WriteString(closing[i])
+	}
+}
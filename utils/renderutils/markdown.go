@@ -0,0 +1,239 @@
+package renderutils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`~`, `\~`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`#`, `\#`,
+	`|`, `\|`,
+)
+
+// Markdown renders the document as GitHub flavored markdown.
+func Markdown(doc *documentutils.Node) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(markdownBlocks(doc.Content)) + "\n"
+}
+
+// markdownBlocks renders the blocks separated by blank lines.
+func markdownBlocks(nodes []documentutils.Node) string {
+	blocks := []string{}
+	for i := range nodes {
+		if block := markdownBlock(&nodes[i]); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func markdownBlock(node *documentutils.Node) string {
+	switch node.Type {
+	case nodeParagraph:
+		return markdownInline(node.Content)
+
+	case nodeHeading:
+		level := min(max(intAttr(node, "level", 1), 1), 6)
+		return strings.Repeat("#", level) + " " + markdownInline(node.Content)
+
+	case nodeBlockquote:
+		return prefixLines(markdownBlocks(node.Content), "> ", "> ")
+
+	case nodeBulletList, nodeTaskList:
+		return markdownList(node, func(int) string { return "- " })
+
+	case nodeOrderedList:
+		start := intAttr(node, "start", 1)
+		return markdownList(node, func(idx int) string { return strconv.Itoa(start+idx) + ". " })
+
+	case nodeCodeBlock:
+		code := node.TextContent()
+		// The fence must be longer than any backtick run of the code
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		language := node.Attr("language")
+		if !codeLanguagePattern.MatchString(language) {
+			language = ""
+		}
+		return fence + language + "\n" + code + "\n" + fence
+
+	case nodeHorizontalRule:
+		return "---"
+
+	case nodeImage:
+		src := safeURL(node.Attr("src"))
+		if src == "" {
+			return ""
+		}
+		title := ""
+		if t := node.Attr("title"); t != "" {
+			title = ` "` + strings.ReplaceAll(t, `"`, `\"`) + `"`
+		}
+		return "![" + markdownEscaper.Replace(node.Attr("alt")) + "](" + markdownURL(src) + title + ")"
+
+	case nodeTable:
+		return markdownTable(node)
+
+	default:
+		if node.IsTextBlock() {
+			return markdownInline(node.Content)
+		}
+		return markdownBlocks(node.Content)
+	}
+}
+
+// markdownList renders the items with their marker, item content is indented below the marker.
+func markdownList(node *documentutils.Node, marker func(idx int) string) string {
+	items := []string{}
+	for idx := range node.Content {
+		item := &node.Content[idx]
+		prefix := marker(idx)
+		if item.Type == nodeTaskItem {
+			if boolAttr(item, "checked") {
+				prefix += "[x] "
+			} else {
+				prefix += "[ ] "
+			}
+		}
+		content := markdownBlocks(item.Content)
+		items = append(items, prefixLines(content, prefix, strings.Repeat(" ", len(marker(idx)))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// markdownTable renders a table, the first row is the header as markdown tables require one.
+func markdownTable(node *documentutils.Node) string {
+	rows := [][]string{}
+	columns := 0
+	for i := range node.Content {
+		row := &node.Content[i]
+		cells := []string{}
+		for j := range row.Content {
+			// Cells hold blocks, markdown cells are single line
+			cell := &row.Content[j]
+			text := strings.ReplaceAll(markdownBlocks(cell.Content), "\n", " ")
+			cells = append(cells, text)
+			// Spanned columns are left empty to keep the columns aligned
+			for span := intAttr(cell, "colspan", 1); span > 1; span-- {
+				cells = append(cells, "")
+			}
+		}
+		columns = max(columns, len(cells))
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 || columns == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for idx, cells := range rows {
+		for len(cells) < columns {
+			cells = append(cells, "")
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if idx == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownInline renders the inline content of a text block, trailing spaces are dropped
+// as two of them would be a line break.
+func markdownInline(nodes []documentutils.Node) string {
+	var sb strings.Builder
+	for _, run := range inlineRuns(nodes) {
+		switch run.node.Type {
+		case documentutils.NodeText:
+			sb.WriteString(markdownText(run.node, run.text))
+		case documentutils.NodeHardBreak:
+			sb.WriteString("\\\n")
+		case documentutils.NodeMention:
+			sb.WriteString("@" + markdownEscaper.Replace(run.node.Attr("label")))
+		default:
+			sb.WriteString(markdownInline(run.node.Content))
+		}
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// markdownText renders a text run with its marks, surrounding spaces are kept outside the
+// delimiters as markdown does not allow emphasis to start or end with a space.
+func markdownText(node documentutils.Node, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+
+	if hasMark(node, markCode) {
+		fence := "`"
+		for strings.Contains(trimmed, fence) {
+			fence += "`"
+		}
+		trimmed = fence + trimmed + fence
+	} else {
+		trimmed = markdownEscaper.Replace(trimmed)
+	}
+
+	for _, mark := range node.Marks {
+		switch mark.Type {
+		case markBold:
+			trimmed = "**" + trimmed + "**"
+		case markItalic:
+			trimmed = "_" + trimmed + "_"
+		case markStrike:
+			trimmed = "~~" + trimmed + "~~"
+		case markHighlight:
+			trimmed = "==" + trimmed + "=="
+		}
+	}
+	for _, mark := range node.Marks {
+		if mark.Type != markLink {
+			continue
+		}
+		if href := safeURL(markAttr(mark, "href")); href != "" {
+			trimmed = "[" + trimmed + "](" + markdownURL(href) + ")"
+		}
+	}
+
+	return leading + trimmed + trailing
+}
+
+// markdownURL escapes the characters ending a markdown link destination.
+func markdownURL(rawURL string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(rawURL)
+}
+
+// prefixLines prefixes the first line with first and the following ones with rest,
+// blank lines only get the trimmed prefix.
+func prefixLines(text string, first string, rest string) string {
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		prefix := rest
+		if idx == 0 {
+			prefix = first
+		}
+		if line == "" {
+			prefix = strings.TrimRight(prefix, " ")
+		}
+		lines[idx] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,610 @@
+package renderutils
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Stuhub-io/utils/documentutils"
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Page layout of pdf documents, in millimeters.
+const (
+	pdfMargin      = 20.0
+	pdfBlockGap    = 3.0
+	pdfListGap     = 1.0
+	pdfListIndent  = 7.0
+	pdfQuoteIndent = 6.0
+	pdfCellPadding = 1.5
+	pdfMaxCover    = 70.0
+
+	pdfFontSize     = 11.0
+	pdfCodeFontSize = 9.0
+	pdfTitleSize    = 24.0
+	pdfLineSpacing  = 1.45
+	pdfPointToMM    = 25.4 / 72
+)
+
+var pdfHeadingSizes = map[int]float64{1: 20, 2: 16, 3: 14, 4: 12, 5: 11, 6: 11}
+
+// Files of the fonts in PDFOptions.FontDir by style, styles missing fall back to the regular font.
+var pdfFontFiles = map[string]string{
+	"":   "regular.ttf",
+	"B":  "bold.ttf",
+	"I":  "italic.ttf",
+	"BI": "bold-italic.ttf",
+}
+
+const pdfMonoFontFile = "mono.ttf"
+
+type PDFOptions struct {
+	Title  string
+	Author string
+	// Url of the image drawn above the title
+	CoverImage string
+	// LoadImage returns the content of the image at the url, images not loaded are rendered as links.
+	LoadImage func(url string) ([]byte, bool)
+	// Directory of TrueType fonts, the standard pdf fonts are used when empty
+	FontDir string
+}
+
+// PDF renders the document as an A4 pdf, the title and author are written on the first page.
+func PDF(doc *documentutils.Node, options PDFOptions) ([]byte, error) {
+	r := newPDFRenderer(options)
+	if err := r.pdf.Error(); err != nil {
+		return nil, err
+	}
+
+	r.pdf.AddPage()
+	r.cover()
+	if doc != nil {
+		r.blocks(doc.Content, pdfBlockGap)
+	}
+
+	var buf bytes.Buffer
+	if err := r.pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type pdfRenderer struct {
+	pdf      *fpdf.Fpdf
+	options  PDFOptions
+	sans     string
+	mono     string
+	tr       func(string) string
+	color    [3]int
+	imageIdx int
+}
+
+func newPDFRenderer(options PDFOptions) *pdfRenderer {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetCellMargin(0)
+	pdf.AliasNbPages("")
+	pdf.SetTitle(options.Title, true)
+	if options.Author != "" {
+		pdf.SetAuthor(options.Author, true)
+	}
+
+	r := &pdfRenderer{
+		pdf:     pdf,
+		options: options,
+		sans:    "Helvetica",
+		mono:    "Courier",
+		tr:      pdfLatinTranslator(pdf),
+	}
+	if options.FontDir != "" {
+		r.loadFonts()
+	}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin + 5)
+		pdf.SetFont(r.sans, "", 9)
+		pdf.SetTextColor(140, 140, 140)
+		pdf.CellFormat(0, 5, strconv.Itoa(pdf.PageNo())+" / {nb}", "", 0, "C", false, 0, "")
+		pdf.SetTextColor(r.color[0], r.color[1], r.color[2])
+	})
+	return r
+}
+
+// loadFonts registers the TrueType fonts of the font directory, which cover any script they have glyphs for.
+func (r *pdfRenderer) loadFonts() {
+	regular, err := os.ReadFile(filepath.Join(r.options.FontDir, pdfFontFiles[""]))
+	if err != nil {
+		r.pdf.SetError(err)
+		return
+	}
+
+	for style, file := range pdfFontFiles {
+		content, err := os.ReadFile(filepath.Join(r.options.FontDir, file))
+		if err != nil {
+			content = regular
+		}
+		r.pdf.AddUTF8FontFromBytes("sans", style, content)
+	}
+
+	mono, err := os.ReadFile(filepath.Join(r.options.FontDir, pdfMonoFontFile))
+	if err != nil {
+		mono = regular
+	}
+	for style := range pdfFontFiles {
+		r.pdf.AddUTF8FontFromBytes("mono", style, mono)
+	}
+
+	r.sans = "sans"
+	r.mono = "mono"
+	r.tr = func(s string) string { return s }
+}
+
+// pdfLatinTranslator encodes text in cp1252 for the standard fonts,
+// accented letters missing from it are written without their accents.
+func pdfLatinTranslator(pdf *fpdf.Fpdf) func(string) string {
+	cp1252 := pdf.UnicodeTranslatorFromDescriptor("")
+	return func(s string) string {
+		var sb strings.Builder
+		for _, char := range s {
+			encoded := cp1252(string(char))
+			if encoded == "." && char != '.' {
+				encoded = "?"
+				if base := []rune(norm.NFD.String(string(char))); len(base) > 1 && !unicode.Is(unicode.Mn, base[0]) {
+					encoded = cp1252(string(base[0]))
+				}
+			}
+			sb.WriteString(encoded)
+		}
+		return sb.String()
+	}
+}
+
+func (r *pdfRenderer) cover() {
+	if r.options.CoverImage != "" {
+		if name, w, h, ok := r.image(r.options.CoverImage, r.contentWidth(), pdfMaxCover); ok {
+			r.pdf.ImageOptions(name, r.left()+(r.contentWidth()-w)/2, -1, w, h, true, fpdf.ImageOptions{}, 0, "")
+			r.pdf.Ln(6)
+		}
+	}
+
+	r.pdf.SetFont(r.sans, "B", pdfTitleSize)
+	r.pdf.MultiCell(0, lineHeight(pdfTitleSize), r.tr(r.options.Title), "", "L", false)
+
+	if r.options.Author != "" {
+		r.pdf.SetFont(r.sans, "", 10)
+		r.pdf.SetTextColor(110, 110, 110)
+		r.pdf.Ln(1)
+		r.pdf.MultiCell(0, lineHeight(10), r.tr(r.options.Author), "", "L", false)
+		r.setColor(r.color)
+	}
+	r.pdf.Ln(6)
+}
+
+// blocks renders the blocks one below the other, gap apart.
+func (r *pdfRenderer) blocks(nodes []documentutils.Node, gap float64) {
+	for i := range nodes {
+		if i > 0 {
+			r.pdf.Ln(gap)
+		}
+		r.block(&nodes[i])
+	}
+}
+
+func (r *pdfRenderer) block(node *documentutils.Node) {
+	switch node.Type {
+	case nodeParagraph:
+		r.paragraph(node.Content, pdfFontSize, "")
+
+	case nodeHeading:
+		size := pdfHeadingSizes[min(max(intAttr(node, "level", 1), 1), 6)]
+		// Headings are kept with the first lines of their section
+		r.keepSpace(lineHeight(size) + 2*lineHeight(pdfFontSize))
+		r.pdf.Ln(2)
+		r.paragraph(node.Content, size, "B")
+
+	case nodeBlockquote:
+		r.blockquote(node)
+
+	case nodeBulletList, nodeTaskList:
+		r.list(node, func(int) string { return "•" })
+
+	case nodeOrderedList:
+		start := intAttr(node, "start", 1)
+		r.list(node, func(idx int) string { return strconv.Itoa(start+idx) + "." })
+
+	case nodeCodeBlock:
+		r.codeBlock(node)
+
+	case nodeHorizontalRule:
+		r.keepSpace(4)
+		y := r.pdf.GetY() + 2
+		r.pdf.SetDrawColor(200, 200, 200)
+		r.pdf.Line(r.left(), y, r.left()+r.contentWidth(), y)
+		r.pdf.SetY(y + 2)
+
+	case nodeImage:
+		r.blockImage(node)
+
+	case nodeTable:
+		r.table(node)
+
+	default:
+		if node.IsTextBlock() {
+			r.paragraph(node.Content, pdfFontSize, "")
+			return
+		}
+		r.blocks(node.Content, pdfBlockGap)
+	}
+}
+
+// paragraph writes the inline content, wrapped at the right margin.
+func (r *pdfRenderer) paragraph(nodes []documentutils.Node, size float64, style string) {
+	r.pdf.SetX(r.left())
+	r.inline(nodes, size, style)
+	r.pdf.Ln(lineHeight(size))
+}
+
+func (r *pdfRenderer) inline(nodes []documentutils.Node, size float64, style string) {
+	h := lineHeight(size)
+	for _, run := range inlineRuns(nodes) {
+		switch run.node.Type {
+		case documentutils.NodeText:
+			r.text(run.node, run.text, size, style)
+		case documentutils.NodeHardBreak:
+			r.pdf.Ln(h)
+		case documentutils.NodeMention:
+			r.pdf.SetFont(r.sans, style, size)
+			r.pdf.Write(h, r.tr("@"+run.node.Attr("label")))
+		default:
+			r.inline(run.node.Content, size, style)
+		}
+	}
+}
+
+func (r *pdfRenderer) text(node documentutils.Node, text string, size float64, style string) {
+	h := lineHeight(size)
+	family := r.sans
+	href := ""
+	script := 0
+	for _, mark := range node.Marks {
+		switch mark.Type {
+		case markBold:
+			style += "B"
+		case markItalic:
+			style += "I"
+		case markUnderline:
+			style += "U"
+		case markStrike:
+			style += "S"
+		case markCode:
+			family = r.mono
+			size *= 0.9
+		case markLink:
+			href = safeURL(markAttr(mark, "href"))
+		case markSubscript:
+			script = -1
+		case markSuperscript:
+			script = 1
+		}
+	}
+
+	if href != "" {
+		style += "U"
+		r.pdf.SetTextColor(30, 90, 200)
+		defer r.setColor(r.color)
+	}
+	r.pdf.SetFont(family, pdfStyle(style), size)
+
+	text = r.tr(text)
+	switch {
+	case script != 0:
+		subSize := size * 0.7
+		r.pdf.SubWrite(h, text, subSize, float64(script)*size*0.35, 0, href)
+	case href != "":
+		r.pdf.WriteLinkString(h, text, href)
+	default:
+		r.pdf.Write(h, text)
+	}
+}
+
+// pdfStyle returns the fpdf style of the marks, each style once in the order fpdf expects.
+func pdfStyle(style string) string {
+	result := ""
+	for _, s := range []string{"B", "I", "U", "S"} {
+		if strings.Contains(style, s) {
+			result += s
+		}
+	}
+	return result
+}
+
+func (r *pdfRenderer) blockquote(node *documentutils.Node) {
+	startPage, startY := r.pdf.PageNo(), r.pdf.GetY()
+	x := r.left() + 1
+
+	previous := r.color
+	r.setColor([3]int{90, 90, 90})
+	r.indent(pdfQuoteIndent, func() {
+		r.blocks(node.Content, pdfBlockGap)
+	})
+	r.setColor(previous)
+
+	// The bar is drawn once the quote is written, on every page the quote spans
+	endPage, endY := r.pdf.PageNo(), r.pdf.GetY()
+	_, pageHeight := r.pdf.GetPageSize()
+	r.pdf.SetDrawColor(200, 200, 200)
+	r.pdf.SetLineWidth(0.8)
+	for page := startPage; page <= endPage; page++ {
+		top, bottom := pdfMargin, pageHeight-pdfMargin
+		if page == startPage {
+			top = startY
+		}
+		if page == endPage {
+			bottom = endY
+		}
+		r.pdf.SetPage(page)
+		r.pdf.Line(x, top, x, bottom)
+	}
+	r.pdf.SetPage(endPage)
+	r.pdf.SetY(endY)
+	r.pdf.SetLineWidth(0.2)
+}
+
+// list writes the marker of each item in the indent, the item content next to it.
+func (r *pdfRenderer) list(node *documentutils.Node, marker func(idx int) string) {
+	h := lineHeight(pdfFontSize)
+	for idx := range node.Content {
+		item := &node.Content[idx]
+		if idx > 0 {
+			r.pdf.Ln(pdfListGap)
+		}
+		r.keepSpace(h)
+
+		x, y := r.left(), r.pdf.GetY()
+		if item.Type == nodeTaskItem {
+			r.checkbox(x+pdfListIndent-5.5, y+(h-3.2)/2, boolAttr(item, "checked"))
+		} else {
+			r.pdf.SetFont(r.sans, "", pdfFontSize)
+			r.pdf.SetXY(x, y)
+			r.pdf.CellFormat(pdfListIndent-2, h, r.tr(marker(idx)), "", 0, "R", false, 0, "")
+		}
+		r.pdf.SetY(y)
+
+		r.indent(pdfListIndent, func() {
+			r.blocks(item.Content, pdfListGap)
+		})
+	}
+}
+
+func (r *pdfRenderer) checkbox(x, y float64, checked bool) {
+	const size = 3.2
+	r.pdf.SetDrawColor(90, 90, 90)
+	r.pdf.SetLineWidth(0.3)
+	r.pdf.Rect(x, y, size, size, "D")
+	if checked {
+		r.pdf.Line(x+0.7, y+1.7, x+1.4, y+2.5)
+		r.pdf.Line(x+1.4, y+2.5, x+2.6, y+0.7)
+	}
+	r.pdf.SetLineWidth(0.2)
+}
+
+func (r *pdfRenderer) codeBlock(node *documentutils.Node) {
+	code := strings.ReplaceAll(node.TextContent(), "\t", "    ")
+	h := lineHeight(pdfCodeFontSize)
+
+	r.pdf.SetFont(r.mono, "", pdfCodeFontSize)
+	r.pdf.SetFillColor(245, 245, 245)
+	r.pdf.SetCellMargin(2)
+	r.pdf.SetX(r.left())
+	r.pdf.CellFormat(0, 2, "", "", 2, "", true, 0, "")
+	r.pdf.MultiCell(0, h, r.tr(code), "", "L", true)
+	r.pdf.CellFormat(0, 2, "", "", 2, "", true, 0, "")
+	r.pdf.SetCellMargin(0)
+}
+
+// blockImage draws the image scaled down to the content width, images not loaded are written as a link.
+func (r *pdfRenderer) blockImage(node *documentutils.Node) {
+	src := safeURL(node.Attr("src"))
+	if src == "" {
+		return
+	}
+
+	_, pageHeight := r.pdf.GetPageSize()
+	if name, w, h, ok := r.image(src, r.contentWidth(), pageHeight-2*pdfMargin); ok {
+		r.pdf.ImageOptions(name, r.left(), -1, w, h, true, fpdf.ImageOptions{}, 0, "")
+		return
+	}
+
+	label := node.Attr("alt")
+	if label == "" {
+		label = src
+	}
+	r.paragraph([]documentutils.Node{{
+		Type:  documentutils.NodeText,
+		Text:  label,
+		Marks: []documentutils.Mark{{Type: markLink, Attrs: map[string]any{"href": src}}},
+	}}, pdfFontSize, "")
+}
+
+// image registers the image at the url and returns its name and size fitting maxWidth and maxHeight.
+func (r *pdfRenderer) image(url string, maxWidth, maxHeight float64) (string, float64, float64, bool) {
+	if r.options.LoadImage == nil {
+		return "", 0, 0, false
+	}
+	content, ok := r.options.LoadImage(url)
+	if !ok {
+		return "", 0, 0, false
+	}
+
+	imageType := ""
+	switch http.DetectContentType(content) {
+	case "image/png":
+		imageType = "PNG"
+	case "image/jpeg":
+		imageType = "JPG"
+	case "image/gif":
+		imageType = "GIF"
+	default:
+		return "", 0, 0, false
+	}
+
+	r.imageIdx++
+	name := "image" + strconv.Itoa(r.imageIdx)
+	info := r.pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: imageType, ReadDpi: true}, bytes.NewReader(content))
+	if r.pdf.Err() || info == nil {
+		// Images fpdf can not decode do not fail the whole document
+		r.pdf.ClearError()
+		return "", 0, 0, false
+	}
+
+	w, h := info.Extent()
+	if w <= 0 || h <= 0 {
+		return "", 0, 0, false
+	}
+	scale := min(1, maxWidth/w, maxHeight/h)
+	return name, w * scale, h * scale, true
+}
+
+// table draws the rows with equal column widths, a header row is repeated on each page the table spans.
+func (r *pdfRenderer) table(node *documentutils.Node) {
+	columns := 0
+	for _, row := range node.Content {
+		count := 0
+		for i := range row.Content {
+			count += max(intAttr(&row.Content[i], "colspan", 1), 1)
+		}
+		columns = max(columns, count)
+	}
+	if columns == 0 {
+		return
+	}
+
+	columnWidth := r.contentWidth() / float64(columns)
+	var header *documentutils.Node
+	if len(node.Content) > 0 && isHeaderRow(&node.Content[0]) {
+		header = &node.Content[0]
+	}
+
+	for i := range node.Content {
+		row := &node.Content[i]
+		height := r.rowHeight(row, columnWidth)
+		if r.pdf.GetY()+height > r.pageBottom() {
+			r.pdf.AddPage()
+			if header != nil && row != header {
+				r.tableRow(header, columnWidth, r.rowHeight(header, columnWidth))
+			}
+		}
+		r.tableRow(row, columnWidth, height)
+	}
+}
+
+func isHeaderRow(row *documentutils.Node) bool {
+	for _, cell := range row.Content {
+		if cell.Type != nodeTableHeader {
+			return false
+		}
+	}
+	return len(row.Content) > 0
+}
+
+func (r *pdfRenderer) rowHeight(row *documentutils.Node, columnWidth float64) float64 {
+	lines := 1
+	for i := range row.Content {
+		cell := &row.Content[i]
+		r.setCellFont(cell)
+		width := columnWidth*float64(max(intAttr(cell, "colspan", 1), 1)) - 2*pdfCellPadding
+		count := 0
+		for _, line := range strings.Split(r.tr(cellText(cell)), "\n") {
+			count += max(len(r.pdf.SplitText(line, width)), 1)
+		}
+		lines = max(lines, count)
+	}
+	return float64(lines)*lineHeight(pdfFontSize) + 2*pdfCellPadding
+}
+
+func (r *pdfRenderer) tableRow(row *documentutils.Node, columnWidth, height float64) {
+	// Rows are never split, their height is known
+	r.pdf.SetAutoPageBreak(false, pdfMargin)
+	defer r.pdf.SetAutoPageBreak(true, pdfMargin)
+
+	x, y := r.left(), r.pdf.GetY()
+	r.pdf.SetDrawColor(200, 200, 200)
+	r.pdf.SetFillColor(242, 242, 242)
+	for i := range row.Content {
+		cell := &row.Content[i]
+		width := columnWidth * float64(max(intAttr(cell, "colspan", 1), 1))
+
+		style := "D"
+		if cell.Type == nodeTableHeader {
+			style = "FD"
+		}
+		r.pdf.Rect(x, y, width, height, style)
+
+		r.setCellFont(cell)
+		r.pdf.SetXY(x+pdfCellPadding, y+pdfCellPadding)
+		r.pdf.MultiCell(width-2*pdfCellPadding, lineHeight(pdfFontSize), r.tr(cellText(cell)), "", "L", false)
+		x += width
+	}
+	r.pdf.SetXY(r.left(), y+height)
+}
+
+func (r *pdfRenderer) setCellFont(cell *documentutils.Node) {
+	style := ""
+	if cell.Type == nodeTableHeader {
+		style = "B"
+	}
+	r.pdf.SetFont(r.sans, style, pdfFontSize)
+}
+
+// cellText is the text of the cell, a line per block, marks are not rendered in tables.
+func cellText(cell *documentutils.Node) string {
+	return strings.TrimSpace(documentutils.PlainText(cell))
+}
+
+// indent renders fn with the left margin moved right by width.
+func (r *pdfRenderer) indent(width float64, fn func()) {
+	left := r.left()
+	r.pdf.SetLeftMargin(left + width)
+	r.pdf.SetX(left + width)
+	fn()
+	r.pdf.SetLeftMargin(left)
+	r.pdf.SetX(left)
+}
+
+// keepSpace starts a new page unless height fits on the current one.
+func (r *pdfRenderer) keepSpace(height float64) {
+	if r.pdf.GetY()+height > r.pageBottom() {
+		r.pdf.AddPage()
+	}
+}
+
+func (r *pdfRenderer) setColor(color [3]int) {
+	r.color = color
+	r.pdf.SetTextColor(color[0], color[1], color[2])
+}
+
+func (r *pdfRenderer) left() float64 {
+	left, _, _, _ := r.pdf.GetMargins()
+	return left
+}
+
+func (r *pdfRenderer) contentWidth() float64 {
+	pageWidth, _ := r.pdf.GetPageSize()
+	left, _, right, _ := r.pdf.GetMargins()
+	return pageWidth - left - right
+}
+
+func (r *pdfRenderer) pageBottom() float64 {
+	_, pageHeight := r.pdf.GetPageSize()
+	return pageHeight - pdfMargin
+}
+
+func lineHeight(size float64) float64 {
+	return size * pdfPointToMM * pdfLineSpacing
+}
@@ -0,0 +1,165 @@
+package renderutils
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+func pdfText(text string, marks ...string) documentutils.Node {
+	node := documentutils.Node{Type: documentutils.NodeText, Text: text}
+	for _, mark := range marks {
+		node.Marks = append(node.Marks, documentutils.Mark{Type: mark})
+	}
+	return node
+}
+
+func pdfBlock(nodeType string, content ...documentutils.Node) documentutils.Node {
+	return documentutils.Node{Type: nodeType, Content: content}
+}
+
+func pdfPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 20))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// pdfPageCount counts the page objects of the uncompressed pdf structure.
+func pdfPageCount(out []byte) int {
+	return bytes.Count(out, []byte("/Type /Page\n"))
+}
+
+func TestPDF(t *testing.T) {
+	images := map[string][]byte{
+		"https://example.com/a.png":   pdfPNG(t),
+		"https://example.com/bad.png": []byte("\x89PNG\r\n\x1a\nnot a png"),
+	}
+	loadImage := func(url string) ([]byte, bool) {
+		content, ok := images[url]
+		return content, ok
+	}
+
+	paragraphs := make([]documentutils.Node, 0, 200)
+	for i := 0; i < 200; i++ {
+		paragraphs = append(paragraphs, pdfBlock(nodeParagraph, pdfText(strings.Repeat("long text ", 30))))
+	}
+
+	rows := make([]documentutils.Node, 0, 100)
+	rows = append(rows, pdfBlock(nodeTableRow,
+		pdfBlock(nodeTableHeader, pdfBlock(nodeParagraph, pdfText("a"))),
+		pdfBlock(nodeTableHeader, pdfBlock(nodeParagraph, pdfText("b"))),
+	))
+	for i := 0; i < 99; i++ {
+		rows = append(rows, pdfBlock(nodeTableRow,
+			pdfBlock(nodeTableCell, pdfBlock(nodeParagraph, pdfText("cell"))),
+			pdfBlock(nodeTableCell),
+		))
+	}
+
+	tests := []struct {
+		name     string
+		doc      *documentutils.Node
+		options  PDFOptions
+		minPages int
+	}{
+		{name: "nil document", options: PDFOptions{Title: "Empty"}, minPages: 1},
+		{
+			name: "every block type",
+			doc: &documentutils.Node{Type: documentutils.NodeDoc, Content: []documentutils.Node{
+				{Type: nodeHeading, Attrs: map[string]any{"level": float64(9)}, Content: []documentutils.Node{pdfText("Heading")}},
+				pdfBlock(nodeParagraph,
+					pdfText("bold ", markBold), pdfText("italic ", markItalic), pdfText("code ", markCode),
+					documentutils.Node{Type: documentutils.NodeHardBreak},
+					documentutils.Node{Type: documentutils.NodeText, Text: "link", Marks: []documentutils.Mark{
+						{Type: markLink, Attrs: map[string]any{"href": "javascript:alert(1)"}},
+					}},
+				),
+				pdfBlock(nodeBlockquote, pdfBlock(nodeParagraph, pdfText("quote"))),
+				pdfBlock(nodeBulletList, pdfBlock(nodeListItem, pdfBlock(nodeParagraph, pdfText("item")))),
+				{Type: nodeOrderedList, Attrs: map[string]any{"start": float64(3)}, Content: []documentutils.Node{
+					pdfBlock(nodeListItem, pdfBlock(nodeParagraph, pdfText("third"))),
+				}},
+				pdfBlock(nodeTaskList, documentutils.Node{
+					Type: nodeTaskItem, Attrs: map[string]any{"checked": true},
+					Content: []documentutils.Node{pdfBlock(nodeParagraph, pdfText("done"))},
+				}),
+				pdfBlock(nodeCodeBlock, pdfText("func main() {}\n\treturn")),
+				pdfBlock(nodeHorizontalRule),
+				{Type: nodeImage, Attrs: map[string]any{"src": "https://example.com/a.png"}},
+				{Type: nodeImage, Attrs: map[string]any{"src": "https://example.com/bad.png", "alt": "bad"}},
+				{Type: nodeImage, Attrs: map[string]any{"src": "https://example.com/missing.png"}},
+				pdfBlock("unknownBlock", pdfBlock(nodeParagraph, pdfText("nested"))),
+			}},
+			options:  PDFOptions{Title: "Blocks", Author: "Author", CoverImage: "https://example.com/a.png", LoadImage: loadImage},
+			minPages: 1,
+		},
+		{
+			name:     "text outside of cp1252",
+			doc:      &documentutils.Node{Type: documentutils.NodeDoc, Content: []documentutils.Node{pdfBlock(nodeParagraph, pdfText("Tiếng Việt 中文 ő €"))}},
+			options:  PDFOptions{Title: "Đà Nẵng"},
+			minPages: 1,
+		},
+		{
+			name:     "long document breaks pages",
+			doc:      &documentutils.Node{Type: documentutils.NodeDoc, Content: paragraphs},
+			options:  PDFOptions{Title: "Long"},
+			minPages: 2,
+		},
+		{
+			name:     "table spanning pages",
+			doc:      &documentutils.Node{Type: documentutils.NodeDoc, Content: []documentutils.Node{pdfBlock(nodeTable, rows...)}},
+			options:  PDFOptions{Title: "Table"},
+			minPages: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := PDF(tt.doc, tt.options)
+			if err != nil {
+				t.Fatalf("PDF() error = %v", err)
+			}
+			if !bytes.HasPrefix(out, []byte("%PDF-")) {
+				t.Fatalf("PDF() output does not start with a pdf header: %q", out[:min(len(out), 16)])
+			}
+			if got := pdfPageCount(out); got < tt.minPages {
+				t.Fatalf("PDF() rendered %d pages, want at least %d", got, tt.minPages)
+			}
+		})
+	}
+}
+
+func TestPDFMissingFontDir(t *testing.T) {
+	if _, err := PDF(nil, PDFOptions{Title: "Fonts", FontDir: t.TempDir()}); err == nil {
+		t.Fatal("PDF() with a font directory without fonts returned no error")
+	}
+}
+
+func TestPDFLatinTranslator(t *testing.T) {
+	tr := newPDFRenderer(PDFOptions{}).tr
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "plain.", want: "plain."},
+		{text: "café", want: "caf\xe9"},
+		{text: "€", want: "\x80"},
+		{text: "ő", want: "o"},
+		{text: "Việt", want: "Viet"},
+		{text: "中", want: "?"},
+	}
+
+	for _, tt := range tests {
+		if got := tr(tt.text); got != tt.want {
+			t.Errorf("tr(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
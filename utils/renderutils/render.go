@@ -0,0 +1,175 @@
+package renderutils
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/Stuhub-io/core/domain"
+	"github.com/Stuhub-io/utils/documentutils"
+)
+
+// Editor node and mark types rendered, nodes of other types are rendered through their content.
+const (
+	nodeParagraph      = "paragraph"
+	nodeHeading        = "heading"
+	nodeBlockquote     = "blockquote"
+	nodeBulletList     = "bulletList"
+	nodeOrderedList    = "orderedList"
+	nodeListItem       = "listItem"
+	nodeTaskList       = "taskList"
+	nodeTaskItem       = "taskItem"
+	nodeCodeBlock      = "codeBlock"
+	nodeHorizontalRule = "horizontalRule"
+	nodeImage          = "image"
+	nodeTable          = "table"
+	nodeTableRow       = "tableRow"
+	nodeTableHeader    = "tableHeader"
+	nodeTableCell      = "tableCell"
+
+	markBold        = "bold"
+	markItalic      = "italic"
+	markStrike      = "strike"
+	markUnderline   = "underline"
+	markCode        = "code"
+	markLink        = "link"
+	markHighlight   = "highlight"
+	markSubscript   = "subscript"
+	markSuperscript = "superscript"
+)
+
+// Render renders an editor json document in the format.
+func Render(jsonContent string, format domain.DocumentFormat) (string, error) {
+	doc, err := documentutils.ParseDocument(jsonContent)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case domain.DocumentFormatMarkdown:
+		return Markdown(doc), nil
+	case domain.DocumentFormatHTML:
+		return HTML(doc), nil
+	case domain.DocumentFormatText:
+		return PlainText(doc), nil
+	default:
+		return "", fmt.Errorf("unsupported document format %q", format)
+	}
+}
+
+// RenderFile renders the document as a standalone file titled with the page name,
+// html documents are complete pages and markdown ones start with the title heading.
+func RenderFile(title, jsonContent string, format domain.DocumentFormat) (string, error) {
+	body, err := Render(jsonContent, format)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case domain.DocumentFormatMarkdown:
+		return strings.TrimRight("# "+markdownEscaper.Replace(title)+"\n\n"+body, "\n") + "\n", nil
+	case domain.DocumentFormatHTML:
+		escapedTitle := html.EscapeString(title)
+		return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>" + escapedTitle + "</title>\n</head>\n" +
+			"<body>\n<h1>" + escapedTitle + "</h1>\n" + body + "\n</body>\n</html>\n", nil
+	default:
+		return title + "\n\n" + body, nil
+	}
+}
+
+// ContentType returns the media type of documents rendered in the format.
+func ContentType(format domain.DocumentFormat) string {
+	switch format {
+	case domain.DocumentFormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case domain.DocumentFormatHTML:
+		return "text/html; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// PlainText is the text stored in documents.content, one line per text block.
+func PlainText(doc *documentutils.Node) string {
+	return documentutils.PlainText(doc)
+}
+
+// safeURL returns the url when it is relative or uses a safe scheme, empty otherwise,
+// so rendered links and images can not run scripts.
+func safeURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto", "tel":
+		return rawURL
+	default:
+		return ""
+	}
+}
+
+// inlineRun is a text node merged with the following ones of the same marks.
+type inlineRun struct {
+	node documentutils.Node
+	text string
+}
+
+// inlineRuns merges adjacent text nodes of the same marks, so their marks are rendered once.
+func inlineRuns(nodes []documentutils.Node) []inlineRun {
+	runs := []inlineRun{}
+	for _, node := range nodes {
+		if last := len(runs) - 1; node.IsText() && last >= 0 && runs[last].node.IsText() && sameMarks(runs[last].node.Marks, node.Marks) {
+			runs[last].text += node.Text
+			continue
+		}
+		runs = append(runs, inlineRun{node: node, text: node.Text})
+	}
+	return runs
+}
+
+func sameMarks(a, b []documentutils.Mark) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || markAttr(a[i], "href") != markAttr(b[i], "href") {
+			return false
+		}
+	}
+	return true
+}
+
+func hasMark(node documentutils.Node, markType string) bool {
+	for _, mark := range node.Marks {
+		if mark.Type == markType {
+			return true
+		}
+	}
+	return false
+}
+
+func markAttr(mark documentutils.Mark, key string) string {
+	value, _ := mark.Attrs[key].(string)
+	return value
+}
+
+// intAttr returns the integer attribute of the node, or def if missing.
+func intAttr(node *documentutils.Node, key string, def int) int {
+	if value, ok := node.Attrs[key].(float64); ok {
+		return int(value)
+	}
+	return def
+}
+
+func boolAttr(node *documentutils.Node, key string) bool {
+	value, _ := node.Attrs[key].(bool)
+	return value
+}